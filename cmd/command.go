@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/features/admin"
+	"lalan-be/internal/model"
+)
+
+/*
+Fungsi untuk menjalankan subcommand CLI.
+Subcommand dijalankan sesuai argumen pertama atau error dikembalikan jika tidak dikenal.
+*/
+func runCommand(db *sqlx.DB, args []string) error {
+	switch args[0] {
+	case "create-admin":
+		return createAdminCommand(db, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q, available commands: create-admin", args[0])
+	}
+}
+
+/*
+Fungsi untuk membuat admin pertama dari CLI.
+Admin dibuat hanya jika belum ada admin, password dibaca dari ADMIN_BOOTSTRAP_PASSWORD atau stdin.
+*/
+func createAdminCommand(db *sqlx.DB, args []string) error {
+	fs := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	email := fs.String("email", "", "email of the first admin")
+	name := fs.String("name", "", "full name of the first admin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	if !emailRegex.MatchString(strings.TrimSpace(*email)) {
+		return errors.New("a valid -email is required")
+	}
+	if strings.TrimSpace(*name) == "" {
+		return errors.New("-name is required")
+	}
+
+	password := os.Getenv("ADMIN_BOOTSTRAP_PASSWORD")
+	if password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("read password: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+	passwords, err := auth.NewPasswordPolicy()
+	if err != nil {
+		return err
+	}
+
+	input := &model.AdminModel{
+		FullName:     strings.TrimSpace(*name),
+		Email:        strings.TrimSpace(*email),
+		PasswordHash: password,
+	}
+	if err := admin.BootstrapAdmin(admin.NewAdminRepository(db), passwords, input); err != nil {
+		return err
+	}
+
+	fmt.Printf("Admin %s created with ID %s\n", input.Email, input.ID)
+	return nil
+}
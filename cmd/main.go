@@ -9,11 +9,19 @@ import (
 	"syscall"
 	"time"
 
+	"lalan-be/internal/auth"
 	"lalan-be/internal/config"
 	"lalan-be/internal/features/admin"
+	"lalan-be/internal/features/booking"
+	"lalan-be/internal/features/cancellation"
+	"lalan-be/internal/features/cart"
+	"lalan-be/internal/features/customer"
+	"lalan-be/internal/features/deposit"
 	"lalan-be/internal/features/hoster"
+	"lalan-be/internal/features/pricing"
 	"lalan-be/internal/features/public"
 	"lalan-be/internal/middleware"
+	"lalan-be/pkg/mailer"
 
 	"github.com/gorilla/mux"
 )
@@ -24,6 +32,9 @@ Aplikasi server diinisialisasi dan dijalankan dengan shutdown yang graceful.
 */
 func main() {
 	config.LoadEnv()
+	if err := config.ValidateSecrets(); err != nil {
+		log.Fatalf("Insecure configuration: %v", err)
+	}
 	cfg, err := config.DatabaseConfig()
 	if err != nil {
 		log.Fatalf("DB connection failed: %v", err)
@@ -31,16 +42,72 @@ func main() {
 	db := cfg.DB
 	defer db.Close()
 	log.Printf(
-		"Database connected → host=%s port=%d db=%s sslmode=%s",
+		"Database connected → host=%s port=%s db=%s sslmode=%s",
 		cfg.Host,
 		cfg.Port,
 		cfg.DBName,
 		cfg.SSLMode,
 	)
 
+	// Jalankan subcommand CLI jika diberikan, misalnya create-admin
+	if len(os.Args) > 1 {
+		if err := runCommand(db, os.Args[1:]); err != nil {
+			log.Fatalf("Command failed: %v", err)
+		}
+		return
+	}
+
+	// auth setup
+	rtRepo := auth.NewRefreshTokenRepository(db)
+	sessRepo := auth.NewSessionRepository(db)
+	rtService := auth.NewRefreshTokenService(rtRepo, sessRepo)
+	issuer, err := auth.NewTokenIssuer(rtService)
+	if err != nil {
+		log.Fatalf("Token issuer setup failed: %v", err)
+	}
+	middleware.SetTokenVerifier(issuer)
+	revRepo := auth.NewRevocationRepository(db)
+	revStore := auth.NewRevocationStore(revRepo, rtRepo)
+	middleware.SetRevocationStore(revStore)
+	sessStore := auth.NewSessionStore(sessRepo, rtRepo)
+	middleware.SetSessionStore(sessStore)
+	guard := auth.NewLoginGuard(auth.NewLoginAttemptRepository(db))
+	mfaService := auth.NewMFAService(auth.NewMFARepository(db))
+	permStore := auth.NewPermissionStore(auth.NewPermissionRepository(db))
+	middleware.SetPermissionStore(permStore)
+	apiKeyStore := auth.NewAPIKeyStore(auth.NewAPIKeyRepository(db))
+	middleware.SetAPIKeyStore(apiKeyStore)
+	oidcProvider := auth.NewOIDCProvider(auth.NewOIDCRepository(db))
+	go func() {
+		ticker := time.NewTicker(10 * time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			revStore.Purge()
+			sessStore.Purge()
+			apiKeyStore.Purge()
+			oidcProvider.Purge()
+			guard.Purge()
+		}
+	}()
+	// mailer setup
+	mail, err := mailer.New(config.GetMailConfig())
+	if err != nil {
+		log.Fatalf("Mailer setup failed: %v", err)
+	}
+	resetRepo := auth.NewPasswordResetRepository(db)
+	resetService := auth.NewPasswordResetService(resetRepo, mail)
+	verifier := auth.NewEmailVerifier(mail)
+	deletion := auth.NewAccountDeletionService(auth.NewAccountDeletionRepository(db), revStore)
+	impersonation := auth.NewImpersonationService(auth.NewImpersonationRepository(db), issuer, revStore)
+	middleware.SetImpersonationService(impersonation)
+	passwordPolicy, err := auth.NewPasswordPolicy()
+	if err != nil {
+		log.Fatalf("Password policy setup failed: %v", err)
+	}
+	authHandler := auth.NewAuthHandler(issuer)
 	// admin setup
 	aRepo := admin.NewAdminRepository(db)
-	aService := admin.NewAdminService(aRepo)
+	aService := admin.NewAdminService(aRepo, issuer, revStore, sessStore, resetService, passwordPolicy, guard, mfaService, permStore, impersonation, mail)
 	aHandler := admin.NewAdminHandler(aService)
 	// public setup
 	pRepo := public.NewPublicRepository(db)
@@ -48,15 +115,59 @@ func main() {
 	pHandler := public.NewPublicHandler(pService)
 	// hoster setup
 	hRepo := hoster.NewHosterRepository(db)
-	hService := hoster.NewHosterService(hRepo)
+	hService := hoster.NewHosterService(hRepo, issuer, revStore, sessStore, resetService, passwordPolicy, guard, mfaService, permStore, apiKeyStore, verifier, oidcProvider, deletion, mail)
 	hHandler := hoster.NewHosterHandler(hService)
+	// customer setup
+	cRepo := customer.NewCustomerRepository(db)
+	cService := customer.NewCustomerService(cRepo, issuer, revStore, sessStore, resetService, passwordPolicy, guard, verifier, oidcProvider, deletion)
+	cHandler := customer.NewCustomerHandler(cService)
+	// pricing setup
+	prService := pricing.NewPricingService(pricing.NewPricingRepository(db), config.GetRentalDayRule())
+	prHandler := pricing.NewPricingHandler(prService)
+	// cancellation policy setup
+	cpService := cancellation.NewCancellationService(cancellation.NewCancellationRepository(db))
+	cpHandler := cancellation.NewCancellationHandler(cpService)
+	// deposit setup
+	dService := deposit.NewDepositService(deposit.NewDepositRepository(db))
+	dHandler := deposit.NewDepositHandler(dService)
+	// booking setup
+	bRepo := booking.NewBookingRepository(db)
+	bService := booking.NewBookingService(bRepo, prService, cpService, config.GetStockHoldConfig())
+	bHandler := booking.NewBookingHandler(bService)
+	// cart setup
+	ctService := cart.NewCartService(cart.NewCartRepository(db), prService, bService)
+	ctHandler := cart.NewCartHandler(ctService)
+	// Penghapusan akun yang melewati masa tenggang diproses setiap jam
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			hService.ProcessDeletionsHoster()
+			cService.ProcessDeletionsCustomer()
+		}
+	}()
+	// Hold stok yang melewati masa berlakunya ditandai kedaluwarsa setiap menit
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			bService.ExpireHolds()
+		}
+	}()
 
 	router := mux.NewRouter()
 	// Setup CORS Middleware
 	router.Use(middleware.CORSMiddleware)
 
+	auth.SetupAuthRoutes(router, authHandler)
 	admin.SetupAdminRoutes(router, aHandler)
 	hoster.SetupHosterRoutes(router, hHandler)
+	customer.SetupCustomerRoutes(router, cHandler)
+	booking.SetupBookingRoutes(router, bHandler)
+	pricing.SetupPricingRoutes(router, prHandler)
+	cancellation.SetupCancellationRoutes(router, cpHandler)
+	deposit.SetupDepositRoutes(router, dHandler)
+	cart.SetupCartRoutes(router, ctHandler)
 	public.SetupPublicRoutes(router, pHandler)
 
 	srv := &http.Server{
@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori penghapusan akun.
+Struktur ini menyediakan akses ke operasi database untuk jadwal penghapusan dan data autentikasi akun.
+*/
+type accountDeletionRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk menyimpan jadwal penghapusan akun baru.
+ID dan timestamp jadwal dikembalikan setelah penyisipan.
+*/
+func (r *accountDeletionRepository) CreateAccountDeletion(deletion *model.AccountDeletionModel) error {
+	query := `
+		INSERT INTO account_deletions (
+			user_id,
+			role,
+			scheduled_for
+		) VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRow(query, deletion.UserID, deletion.Role, deletion.ScheduledFor).Scan(&deletion.ID, &deletion.CreatedAt, &deletion.UpdatedAt)
+	if err != nil {
+		log.Printf("CreateAccountDeletion: error inserting deletion for %s: %v", deletion.UserID, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk mencari jadwal penghapusan akun yang masih menunggu.
+Jadwal dikembalikan, atau nil jika akun tidak sedang dijadwalkan untuk dihapus.
+*/
+func (r *accountDeletionRepository) FindPendingAccountDeletion(userID, role string) (*model.AccountDeletionModel, error) {
+	var deletion model.AccountDeletionModel
+	query := `
+		SELECT
+			id,
+			user_id,
+			role,
+			scheduled_for,
+			cancelled_at,
+			completed_at,
+			created_at,
+			updated_at
+		FROM account_deletions
+		WHERE user_id = $1 AND role = $2 AND cancelled_at IS NULL AND completed_at IS NULL
+	`
+	err := r.db.Get(&deletion, query, userID, role)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindPendingAccountDeletion: error querying deletion for %s: %v", userID, err)
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+/*
+Metode untuk membatalkan jadwal penghapusan akun yang masih menunggu.
+Nilai true dikembalikan jika ada jadwal yang dibatalkan.
+*/
+func (r *accountDeletionRepository) CancelAccountDeletion(userID, role string) (bool, error) {
+	query := `
+		UPDATE account_deletions
+		SET cancelled_at = NOW()
+		WHERE user_id = $1 AND role = $2 AND cancelled_at IS NULL AND completed_at IS NULL
+	`
+	result, err := r.db.Exec(query, userID, role)
+	if err != nil {
+		log.Printf("CancelAccountDeletion: error cancelling deletion for %s: %v", userID, err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+/*
+Metode untuk mengambil jadwal penghapusan yang sudah melewati masa tenggang.
+Daftar jadwal yang jatuh tempo untuk role tersebut dikembalikan.
+*/
+func (r *accountDeletionRepository) GetDueAccountDeletions(role string) ([]*model.AccountDeletionModel, error) {
+	var deletions []*model.AccountDeletionModel
+	query := `
+		SELECT
+			id,
+			user_id,
+			role,
+			scheduled_for,
+			cancelled_at,
+			completed_at,
+			created_at,
+			updated_at
+		FROM account_deletions
+		WHERE role = $1 AND cancelled_at IS NULL AND completed_at IS NULL AND scheduled_for <= NOW()
+		ORDER BY scheduled_for
+	`
+	if err := r.db.Select(&deletions, query, role); err != nil {
+		log.Printf("GetDueAccountDeletions: error querying deletions: %v", err)
+		return nil, err
+	}
+	return deletions, nil
+}
+
+/*
+Metode untuk menghapus data autentikasi milik akun.
+Sesi, refresh token, MFA, identitas OpenID Connect, token reset, role, dan riwayat login dihapus dalam satu transaksi.
+*/
+func (r *accountDeletionRepository) EraseAccountAuthData(userID, role, email string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	statements := []string{
+		`DELETE FROM sessions WHERE user_id = $1 AND role = $2`,
+		`DELETE FROM refresh_tokens WHERE user_id = $1 AND role = $2`,
+		`DELETE FROM mfa_recovery_codes WHERE user_id = $1 AND role = $2`,
+		`DELETE FROM mfa_totp WHERE user_id = $1 AND role = $2`,
+		`DELETE FROM oidc_identities WHERE user_id = $1 AND role = $2`,
+		`DELETE FROM password_reset_tokens WHERE user_id = $1 AND role = $2`,
+		`DELETE FROM user_roles WHERE user_id = $1 AND user_type = $2`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement, userID, role); err != nil {
+			log.Printf("EraseAccountAuthData: error erasing data for %s: %v", userID, err)
+			return err
+		}
+	}
+	if email != "" {
+		query := `DELETE FROM login_attempts WHERE kind = 'email' AND identifier = $1 AND role = $2`
+		if _, err := tx.Exec(query, email, role); err != nil {
+			log.Printf("EraseAccountAuthData: error erasing login attempts for %s: %v", userID, err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+/*
+Metode untuk menandai jadwal penghapusan akun sudah selesai.
+Jadwal tidak lagi diproses pada putaran berikutnya.
+*/
+func (r *accountDeletionRepository) CompleteAccountDeletion(id string) error {
+	query := `
+		UPDATE account_deletions
+		SET completed_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := r.db.Exec(query, id); err != nil {
+		log.Printf("CompleteAccountDeletion: error completing deletion %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Antarmuka untuk repositori penghapusan akun.
+Antarmuka ini mendefinisikan metode penjadwalan penghapusan dan pembersihan data autentikasi.
+*/
+type AccountDeletionRepository interface {
+	CreateAccountDeletion(deletion *model.AccountDeletionModel) error
+	FindPendingAccountDeletion(userID, role string) (*model.AccountDeletionModel, error)
+	CancelAccountDeletion(userID, role string) (bool, error)
+	GetDueAccountDeletions(role string) ([]*model.AccountDeletionModel, error)
+	EraseAccountAuthData(userID, role, email string) error
+	CompleteAccountDeletion(id string) error
+}
+
+/*
+Fungsi untuk membuat instance baru dari AccountDeletionRepository.
+Instance repositori dikembalikan.
+*/
+func NewAccountDeletionRepository(db *sqlx.DB) AccountDeletionRepository {
+	return &accountDeletionRepository{db: db}
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Variabel untuk error penghapusan akun.
+Variabel ini menandai akun yang sudah dijadwalkan untuk dihapus atau tidak memiliki jadwal penghapusan.
+*/
+var (
+	ErrAccountDeletionPending  = errors.New(message.MsgAccountDeletionPending)
+	ErrAccountDeletionNotFound = errors.New(message.MsgAccountDeletionNotFound)
+)
+
+/*
+Struktur untuk layanan penghapusan akun.
+Struktur ini menjadwalkan penghapusan dengan masa tenggang dan membersihkan data autentikasi saat jatuh tempo.
+*/
+type accountDeletionService struct {
+	repo       AccountDeletionRepository
+	revocation RevocationStore
+}
+
+/*
+Metode untuk menjadwalkan penghapusan akun setelah masa tenggang.
+Jadwal penghapusan dikembalikan, atau ErrAccountDeletionPending jika akun sudah dijadwalkan.
+*/
+func (s *accountDeletionService) Schedule(userID, role string) (*model.AccountDeletionModel, error) {
+	existing, err := s.repo.FindPendingAccountDeletion(userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAccountDeletionPending
+	}
+
+	deletion := &model.AccountDeletionModel{
+		UserID:       userID,
+		Role:         role,
+		ScheduledFor: time.Now().Add(config.GetAccountDeletionGracePeriod()),
+	}
+	if err := s.repo.CreateAccountDeletion(deletion); err != nil {
+		// Permintaan ganda yang bersamaan ditolak oleh index unik
+		if strings.Contains(err.Error(), "duplicate") {
+			return nil, ErrAccountDeletionPending
+		}
+		return nil, err
+	}
+	return deletion, nil
+}
+
+/*
+Metode untuk mengambil jadwal penghapusan akun yang masih menunggu.
+Jadwal dikembalikan, atau nil jika akun tidak sedang dijadwalkan untuk dihapus.
+*/
+func (s *accountDeletionService) Pending(userID, role string) (*model.AccountDeletionModel, error) {
+	return s.repo.FindPendingAccountDeletion(userID, role)
+}
+
+/*
+Metode untuk membatalkan penghapusan akun selama masa tenggang.
+ErrAccountDeletionNotFound dikembalikan jika tidak ada jadwal yang menunggu.
+*/
+func (s *accountDeletionService) Cancel(userID, role string) error {
+	cancelled, err := s.repo.CancelAccountDeletion(userID, role)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return ErrAccountDeletionNotFound
+	}
+	return nil
+}
+
+/*
+Metode untuk mengambil penghapusan yang sudah melewati masa tenggang.
+Daftar jadwal yang siap diproses untuk role tersebut dikembalikan.
+*/
+func (s *accountDeletionService) Due(role string) ([]*model.AccountDeletionModel, error) {
+	return s.repo.GetDueAccountDeletions(role)
+}
+
+/*
+Metode untuk mencabut semua token dan menghapus data autentikasi sebuah akun.
+Akun keluar dari semua perangkat dan tidak lagi memiliki sesi, MFA, maupun identitas tertaut.
+*/
+func (s *accountDeletionService) Erase(userID, role, email string) error {
+	if err := s.revocation.RevokeAllForUser(userID, role); err != nil {
+		return err
+	}
+	return s.repo.EraseAccountAuthData(userID, role, email)
+}
+
+/*
+Metode untuk menyelesaikan penghapusan akun yang jatuh tempo.
+Data autentikasi dihapus lalu jadwal ditandai selesai setelah data profil dianonimkan oleh pemanggil.
+*/
+func (s *accountDeletionService) Finish(deletion *model.AccountDeletionModel, email string) error {
+	if err := s.Erase(deletion.UserID, deletion.Role, email); err != nil {
+		return err
+	}
+	return s.repo.CompleteAccountDeletion(deletion.ID)
+}
+
+/*
+Antarmuka untuk layanan penghapusan akun.
+Antarmuka ini mendefinisikan metode penjadwalan, pembatalan, dan penyelesaian penghapusan akun.
+*/
+type AccountDeletionService interface {
+	Schedule(userID, role string) (*model.AccountDeletionModel, error)
+	Pending(userID, role string) (*model.AccountDeletionModel, error)
+	Cancel(userID, role string) error
+	Due(role string) ([]*model.AccountDeletionModel, error)
+	Erase(userID, role, email string) error
+	Finish(deletion *model.AccountDeletionModel, email string) error
+}
+
+/*
+Fungsi untuk membuat instance baru dari AccountDeletionService.
+Instance layanan dikembalikan.
+*/
+func NewAccountDeletionService(repo AccountDeletionRepository, revocation RevocationStore) AccountDeletionService {
+	return &accountDeletionService{repo: repo, revocation: revocation}
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori autentikasi API key.
+Struktur ini menyediakan akses database yang dibutuhkan middleware untuk memeriksa API key.
+*/
+type apiKeyRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk mencari API key aktif berdasarkan hash.
+Model API key dikembalikan atau nil jika tidak ditemukan atau sudah dicabut.
+*/
+func (r *apiKeyRepository) FindActiveAPIKeyByHash(keyHash string) (*model.APIKeyModel, error) {
+	var key model.APIKeyModel
+	query := `
+		SELECT
+			id,
+			store_id,
+			created_by,
+			name,
+			prefix,
+			key_hash,
+			scopes,
+			last_used_at,
+			revoked_at,
+			created_at,
+			updated_at
+		FROM hoster_api_keys
+		WHERE key_hash = $1 AND revoked_at IS NULL
+	`
+	err := r.db.Get(&key, query, keyHash)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindActiveAPIKeyByHash: error querying api key: %v", err)
+		return nil, err
+	}
+	return &key, nil
+}
+
+/*
+Metode untuk mencatat waktu penggunaan terakhir API key.
+Kolom last_used_at diperbarui ke waktu sekarang.
+*/
+func (r *apiKeyRepository) TouchAPIKey(id string) error {
+	query := `
+		UPDATE hoster_api_keys
+		SET last_used_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	if _, err := r.db.Exec(query, id); err != nil {
+		log.Printf("TouchAPIKey: error updating api key %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Antarmuka untuk repositori autentikasi API key.
+Antarmuka ini mendefinisikan metode pencarian dan pencatatan penggunaan API key.
+*/
+type APIKeyRepository interface {
+	FindActiveAPIKeyByHash(keyHash string) (*model.APIKeyModel, error)
+	TouchAPIKey(id string) error
+}
+
+/*
+Fungsi untuk membuat instance baru dari APIKeyRepository.
+Instance repositori dikembalikan.
+*/
+func NewAPIKeyRepository(db *sqlx.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
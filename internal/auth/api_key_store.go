@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk format API key.
+Awalan memudahkan pemindaian kebocoran kunci, dan panjang prefix menentukan bagian yang boleh ditampilkan.
+*/
+const (
+	APIKeyPrefix       = "lalan_"
+	apiKeyDisplayChars = 12
+)
+
+/*
+Variabel untuk error dan scope API key.
+Scope API key dibatasi pada permission inventaris yang aman dipakai skrip tanpa login interaktif.
+*/
+var (
+	ErrAPIKeyInvalid = errors.New(message.MsgAPIKeyInvalid)
+	APIKeyScopes     = []string{PermItemRead, PermItemWrite}
+)
+
+/*
+Struktur untuk entri cache API key.
+Struktur ini menyimpan API key aktif atau hasil negatif beserta batas waktu cache.
+*/
+type apiKeyCacheEntry struct {
+	key     *model.APIKeyModel
+	expires time.Time
+}
+
+/*
+Struktur untuk penyimpanan API key.
+Struktur ini menggabungkan database dengan cache memori agar middleware tidak selalu mengakses database.
+*/
+type apiKeyStore struct {
+	repo          APIKeyRepository
+	ttl           time.Duration
+	touchInterval time.Duration
+	mu            sync.RWMutex
+	keys          map[string]apiKeyCacheEntry
+	touched       map[string]time.Time
+}
+
+/*
+Metode untuk mengautentikasi API key mentah.
+Model API key aktif dikembalikan, atau ErrAPIKeyInvalid jika kunci tidak dikenal atau sudah dicabut.
+*/
+func (s *apiKeyStore) Authenticate(raw string) (*model.APIKeyModel, error) {
+	if !strings.HasPrefix(raw, APIKeyPrefix) {
+		return nil, ErrAPIKeyInvalid
+	}
+	keyHash := HashToken(raw)
+	now := time.Now()
+	s.mu.RLock()
+	entry, ok := s.keys[keyHash]
+	s.mu.RUnlock()
+
+	if !ok || now.After(entry.expires) {
+		key, err := s.repo.FindActiveAPIKeyByHash(keyHash)
+		if err != nil {
+			return nil, err
+		}
+		entry = apiKeyCacheEntry{key: key, expires: now.Add(s.ttl)}
+		s.mu.Lock()
+		s.keys[keyHash] = entry
+		s.mu.Unlock()
+	}
+	if entry.key == nil {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	s.touch(entry.key.ID, now)
+	return entry.key, nil
+}
+
+/*
+Metode untuk mencatat penggunaan API key.
+Database hanya diperbarui jika jeda sejak pembaruan terakhir sudah terlewati.
+*/
+func (s *apiKeyStore) touch(id string, now time.Time) {
+	s.mu.Lock()
+	last, ok := s.touched[id]
+	if ok && now.Sub(last) < s.touchInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.touched[id] = now
+	s.mu.Unlock()
+
+	if err := s.repo.TouchAPIKey(id); err != nil {
+		log.Printf("APIKeyStore: failed to touch api key %s: %v", id, err)
+	}
+}
+
+/*
+Metode untuk menghapus satu API key dari cache.
+API key dibaca ulang dari database pada permintaan berikutnya.
+*/
+func (s *apiKeyStore) Invalidate(id string) {
+	s.mu.Lock()
+	for keyHash, entry := range s.keys {
+		if entry.key != nil && entry.key.ID == id {
+			delete(s.keys, keyHash)
+		}
+	}
+	s.mu.Unlock()
+}
+
+/*
+Metode untuk menghapus seluruh cache API key.
+Dipakai saat banyak API key terdampak sekaligus, misalnya staf pembuatnya dihapus.
+*/
+func (s *apiKeyStore) InvalidateAll() {
+	s.mu.Lock()
+	s.keys = make(map[string]apiKeyCacheEntry)
+	s.mu.Unlock()
+}
+
+/*
+Metode untuk membersihkan entri cache yang kedaluwarsa.
+Entri yang tidak lagi relevan dihapus.
+*/
+func (s *apiKeyStore) Purge() {
+	now := time.Now()
+	s.mu.Lock()
+	for keyHash, entry := range s.keys {
+		if now.After(entry.expires) {
+			delete(s.keys, keyHash)
+		}
+	}
+	for id, last := range s.touched {
+		if now.Sub(last) > s.touchInterval {
+			delete(s.touched, id)
+		}
+	}
+	s.mu.Unlock()
+}
+
+/*
+Antarmuka untuk penyimpanan API key.
+Antarmuka ini mendefinisikan metode yang digunakan middleware dan layanan pengelolaan API key.
+*/
+type APIKeyStore interface {
+	Authenticate(raw string) (*model.APIKeyModel, error)
+	Invalidate(id string)
+	InvalidateAll()
+	Purge()
+}
+
+/*
+Fungsi untuk menghasilkan API key baru.
+Kunci mentah untuk diberikan sekali ke pengguna, prefix untuk ditampilkan, dan hash untuk disimpan dikembalikan.
+*/
+func GenerateAPIKey() (string, string, string, error) {
+	token, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", "", err
+	}
+	raw := APIKeyPrefix + token
+	return raw, raw[:apiKeyDisplayChars], HashToken(raw), nil
+}
+
+/*
+Fungsi untuk memeriksa apakah scope boleh diberikan ke API key.
+Nilai true dikembalikan jika scope termasuk daftar APIKeyScopes.
+*/
+func IsAPIKeyScope(scope string) bool {
+	for _, allowed := range APIKeyScopes {
+		if scope == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Fungsi untuk membuat instance baru dari APIKeyStore.
+Instance penyimpanan dengan cache memori dikembalikan.
+*/
+func NewAPIKeyStore(repo APIKeyRepository) APIKeyStore {
+	return &apiKeyStore{
+		repo:          repo,
+		ttl:           config.GetRevocationCacheTTL(),
+		touchInterval: config.GetSessionTouchInterval(),
+		keys:          make(map[string]apiKeyCacheEntry),
+		touched:       make(map[string]time.Time),
+	}
+}
@@ -0,0 +1,130 @@
+package authtest
+
+import (
+	"net/url"
+	"os"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori token reset password di memori.
+Struktur ini meniru pembatalan token lama, pemakaian sekali, dan masa berlaku seperti query database.
+*/
+type ResetRepository struct {
+	mu     sync.Mutex
+	tokens []*model.PasswordResetTokenModel
+}
+
+/*
+Metode untuk menyimpan token reset baru.
+Token lama milik pengguna yang belum dipakai dibatalkan terlebih dahulu.
+*/
+func (r *ResetRepository) CreateResetToken(token *model.PasswordResetTokenModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, existing := range r.tokens {
+		if existing.UserID == token.UserID && existing.Role == token.Role && existing.UsedAt == nil {
+			existing.UsedAt = &now
+		}
+	}
+	token.ID = token.TokenHash[:8]
+	token.CreatedAt = now
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+/*
+Metode untuk memakai token reset.
+User ID dikembalikan jika token valid, atau string kosong jika tidak.
+*/
+func (r *ResetRepository) ConsumeResetToken(hash, role string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token := r.find(hash, role)
+	if token == nil {
+		return "", nil
+	}
+	now := time.Now()
+	token.UsedAt = &now
+	return token.UserID, nil
+}
+
+/*
+Metode untuk mencari pemilik token reset tanpa memakainya.
+User ID dikembalikan jika token valid, atau string kosong jika tidak.
+*/
+func (r *ResetRepository) FindResetTokenUser(hash, role string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token := r.find(hash, role)
+	if token == nil {
+		return "", nil
+	}
+	return token.UserID, nil
+}
+
+/*
+Metode untuk memajukan waktu semua token reset.
+Masa berlaku token dimundurkan seolah-olah waktu sudah berlalu.
+*/
+func (r *ResetRepository) Elapse(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, token := range r.tokens {
+		token.ExpiresAt = token.ExpiresAt.Add(-d)
+	}
+}
+
+/*
+Metode untuk mencari token reset yang masih berlaku.
+Token dikembalikan jika hash dan role cocok, belum dipakai, dan belum kedaluwarsa.
+*/
+func (r *ResetRepository) find(hash, role string) *model.PasswordResetTokenModel {
+	for _, token := range r.tokens {
+		if token.TokenHash == hash && token.Role == role && token.UsedAt == nil && time.Now().Before(token.ExpiresAt) {
+			return token
+		}
+	}
+	return nil
+}
+
+/*
+Variabel untuk pola token pada tautan reset di email.
+Variabel ini mengambil nilai parameter token dari tautan.
+*/
+var resetLinkToken = regexp.MustCompile(`reset-password\?role=[^&\s]+&token=([^&\s]+)`)
+
+/*
+Fungsi untuk membaca token reset terakhir dari file mailer.
+Token mentah dari tautan email terakhir dikembalikan.
+*/
+func LastResetToken(t testing.TB, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read mail file: %v", err)
+	}
+	matches := resetLinkToken.FindAllStringSubmatch(string(content), -1)
+	if len(matches) == 0 {
+		t.Fatalf("no reset link in mail file:\n%s", content)
+	}
+	raw, err := url.QueryUnescape(matches[len(matches)-1][1])
+	if err != nil {
+		t.Fatalf("unescape token: %v", err)
+	}
+	return raw
+}
+
+/*
+Fungsi untuk membuat instance baru dari ResetRepository.
+Instance repositori kosong dikembalikan.
+*/
+func NewResetRepository() *ResetRepository {
+	return &ResetRepository{}
+}
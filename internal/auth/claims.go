@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+)
+
+/*
+Struktur untuk claims JWT.
+Struktur ini berisi claims JWT standar, role pengguna, sesi login, penanda login yang sudah melewati MFA, toko untuk akun hoster, admin yang bertindak saat impersonasi, dan API key yang dipakai jika permintaan tidak memakai JWT.
+*/
+type Claims struct {
+	jwt.RegisteredClaims
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
+	MFA       bool   `json:"mfa,omitempty"`
+	Store     string `json:"store,omitempty"`
+	StoreRole string `json:"store_role,omitempty"`
+	Act       *Actor `json:"act,omitempty"`
+	APIKeyID  string `json:"-"`
+}
+
+/*
+Struktur untuk klaim act pada token impersonasi.
+Struktur ini berisi ID admin yang bertindak atas nama pemilik token seperti klaim act RFC 8693.
+*/
+type Actor struct {
+	Subject string `json:"sub"`
+}
+
+/*
+Fungsi untuk membuat claims dasar bagi pengguna.
+Claims dengan subject dan role dikembalikan, sedangkan jti dan waktu diisi saat penerbitan.
+*/
+func NewClaims(userID, role string) *Claims {
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: userID},
+		Role:             role,
+	}
+}
+
+/*
+Metode untuk memeriksa apakah token adalah token impersonasi.
+Nilai true dikembalikan jika token membawa klaim act dari admin yang bertindak.
+*/
+func (c *Claims) Impersonating() bool {
+	return c != nil && c.Act != nil && c.Act.Subject != ""
+}
+
+/*
+Metode untuk mengambil ID toko dari claims hoster.
+ID toko dikembalikan, yaitu subject untuk pemilik toko dan token lama yang belum membawa klaim store.
+*/
+func (c *Claims) StoreID() string {
+	if c.Store == "" {
+		return c.Subject
+	}
+	return c.Store
+}
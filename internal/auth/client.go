@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"lalan-be/internal/config"
+)
+
+/*
+Struktur untuk informasi klien.
+Struktur ini berisi alamat IP dan user agent dari permintaan.
+*/
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+/*
+Fungsi untuk membaca informasi klien dari permintaan HTTP.
+Header X-Forwarded-For hanya dipercaya jika TRUST_PROXY_HEADERS aktif.
+*/
+func ClientInfoFromRequest(r *http.Request) ClientInfo {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		ip = host
+	}
+
+	if config.GetLoginGuardConfig().TrustProxyHeaders {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			// Ambil alamat paling kiri yang merupakan klien asli
+			first, _, _ := strings.Cut(forwarded, ",")
+			if candidate := strings.TrimSpace(first); net.ParseIP(candidate) != nil {
+				ip = candidate
+			}
+		} else if real := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(real) != nil {
+			ip = real
+		}
+	}
+
+	return ClientInfo{IP: ip, UserAgent: r.UserAgent()}
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
+)
+
+/*
+Struktur untuk handler auth.
+Struktur ini menangani endpoint auth yang dipakai bersama semua role.
+*/
+type AuthHandler struct {
+	issuer TokenIssuer
+}
+
+/*
+Metode untuk menampilkan kunci publik penandatangan token.
+Dokumen JWKS dikembalikan tanpa pembungkus respons agar dapat dibaca pustaka JWT lain.
+*/
+func (h *AuthHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetJWKS: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(h.issuer.JWKS()); err != nil {
+		log.Printf("GetJWKS: error encoding keys: %v", err)
+	}
+}
+
+/*
+Fungsi untuk membuat instance baru dari AuthHandler.
+Instance handler dikembalikan.
+*/
+func NewAuthHandler(issuer TokenIssuer) *AuthHandler {
+	return &AuthHandler{issuer: issuer}
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"errors"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Variabel untuk error impersonasi.
+Variabel ini menandai sesi impersonasi yang tidak ditemukan atau sudah berakhir.
+*/
+var ErrImpersonationNotFound = errors.New(message.MsgImpersonationNotFound)
+
+/*
+Struktur untuk hasil penerbitan token impersonasi.
+Struktur ini berisi data sesi impersonasi beserta access token singkat tanpa refresh token.
+*/
+type ImpersonationGrant struct {
+	*model.ImpersonationModel
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+/*
+Struktur untuk layanan impersonasi.
+Struktur ini menerbitkan token impersonasi bertanda klaim act, mengakhirinya, dan mencatat jejak audit.
+*/
+type impersonationService struct {
+	repo       ImpersonationRepository
+	tokens     TokenIssuer
+	revocation RevocationStore
+}
+
+/*
+Metode untuk memulai impersonasi pemilik toko oleh admin.
+Access token singkat dengan klaim act berisi ID admin dikembalikan tanpa refresh token dan tanpa sesi login.
+*/
+func (s *impersonationService) Start(adminID, hosterID, storeID, reason string) (*ImpersonationGrant, error) {
+	claims := NewClaims(hosterID, "hoster")
+	claims.Store = storeID
+	claims.StoreRole = model.StoreRoleOwner
+	claims.Act = &Actor{Subject: adminID}
+	accessToken, expiresIn, err := s.tokens.IssueAccessTokenFor(claims, config.GetImpersonationTTL())
+	if err != nil {
+		return nil, err
+	}
+
+	impersonation := &model.ImpersonationModel{
+		ID:        claims.ID,
+		AdminID:   adminID,
+		HosterID:  hosterID,
+		StoreID:   storeID,
+		Reason:    reason,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+	if err := s.repo.CreateImpersonation(impersonation); err != nil {
+		return nil, err
+	}
+
+	return &ImpersonationGrant{
+		ImpersonationModel: impersonation,
+		AccessToken:        accessToken,
+		TokenType:          "Bearer",
+		ExpiresIn:          expiresIn,
+	}, nil
+}
+
+/*
+Metode untuk mengakhiri impersonasi sebelum masa berlakunya habis.
+Token impersonasi dicabut sehingga permintaan berikutnya ditolak.
+*/
+func (s *impersonationService) End(id, endedBy string) error {
+	impersonation, err := s.repo.FindImpersonation(id)
+	if err != nil {
+		return err
+	}
+	if impersonation == nil || impersonation.EndedAt != nil {
+		return ErrImpersonationNotFound
+	}
+	if err := s.revocation.RevokeToken(impersonation.ID, impersonation.HosterID, "hoster", impersonation.ExpiresAt); err != nil {
+		return err
+	}
+	ended, err := s.repo.EndImpersonation(id, endedBy)
+	if err != nil {
+		return err
+	}
+	if !ended {
+		return ErrImpersonationNotFound
+	}
+	return nil
+}
+
+/*
+Metode untuk mengambil sesi impersonasi terbaru.
+Daftar sesi dikembalikan, dapat difilter berdasarkan hoster.
+*/
+func (s *impersonationService) List(hosterID string) ([]*model.ImpersonationModel, error) {
+	return s.repo.GetImpersonations(hosterID)
+}
+
+/*
+Metode untuk mengambil jejak audit sebuah sesi impersonasi.
+ErrImpersonationNotFound dikembalikan jika sesi tidak dikenal.
+*/
+func (s *impersonationService) Logs(id string) ([]*model.ImpersonationLogModel, error) {
+	impersonation, err := s.repo.FindImpersonation(id)
+	if err != nil {
+		return nil, err
+	}
+	if impersonation == nil {
+		return nil, ErrImpersonationNotFound
+	}
+	return s.repo.GetImpersonationLogs(id)
+}
+
+/*
+Metode untuk mencatat satu permintaan yang dilakukan selama impersonasi.
+Jejak audit disimpan ke database.
+*/
+func (s *impersonationService) Record(entry *model.ImpersonationLogModel) error {
+	return s.repo.CreateImpersonationLog(entry)
+}
+
+/*
+Antarmuka untuk layanan impersonasi.
+Antarmuka ini mendefinisikan metode penerbitan, pengakhiran, dan audit impersonasi hoster.
+*/
+type ImpersonationService interface {
+	Start(adminID, hosterID, storeID, reason string) (*ImpersonationGrant, error)
+	End(id, endedBy string) error
+	List(hosterID string) ([]*model.ImpersonationModel, error)
+	Logs(id string) ([]*model.ImpersonationLogModel, error)
+	Record(entry *model.ImpersonationLogModel) error
+}
+
+/*
+Fungsi untuk membuat instance baru dari ImpersonationService.
+Instance layanan dikembalikan.
+*/
+func NewImpersonationService(repo ImpersonationRepository, tokens TokenIssuer, revocation RevocationStore) ImpersonationService {
+	return &impersonationService{repo: repo, tokens: tokens, revocation: revocation}
+}
@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori impersonasi.
+Struktur ini menyediakan akses ke operasi database untuk sesi impersonasi dan jejak auditnya.
+*/
+type impersonationRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk menyimpan sesi impersonasi baru.
+Timestamp pembuatan dikembalikan setelah penyisipan.
+*/
+func (r *impersonationRepository) CreateImpersonation(impersonation *model.ImpersonationModel) error {
+	query := `
+		INSERT INTO impersonations (
+			id,
+			admin_id,
+			hoster_id,
+			store_id,
+			reason,
+			expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+	err := r.db.QueryRow(query, impersonation.ID, impersonation.AdminID, impersonation.HosterID, impersonation.StoreID, impersonation.Reason, impersonation.ExpiresAt).Scan(&impersonation.CreatedAt)
+	if err != nil {
+		log.Printf("CreateImpersonation: error inserting impersonation for hoster %s: %v", impersonation.HosterID, err)
+		return err
+	}
+	log.Printf("CreateImpersonation: admin %s started impersonating hoster %s", impersonation.AdminID, impersonation.HosterID)
+	return nil
+}
+
+/*
+Metode untuk mencari sesi impersonasi berdasarkan ID.
+Sesi dikembalikan, atau nil jika tidak ditemukan.
+*/
+func (r *impersonationRepository) FindImpersonation(id string) (*model.ImpersonationModel, error) {
+	var impersonation model.ImpersonationModel
+	query := `
+		SELECT
+			id,
+			admin_id,
+			hoster_id,
+			store_id,
+			reason,
+			expires_at,
+			ended_at,
+			ended_by,
+			created_at
+		FROM impersonations
+		WHERE id = $1
+	`
+	err := r.db.Get(&impersonation, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindImpersonation: error querying impersonation %s: %v", id, err)
+		return nil, err
+	}
+	return &impersonation, nil
+}
+
+/*
+Metode untuk mengambil sesi impersonasi terbaru.
+Daftar sesi dikembalikan, dapat difilter berdasarkan hoster jika hosterID diisi.
+*/
+func (r *impersonationRepository) GetImpersonations(hosterID string) ([]*model.ImpersonationModel, error) {
+	impersonations := []*model.ImpersonationModel{}
+	query := `
+		SELECT
+			id,
+			admin_id,
+			hoster_id,
+			store_id,
+			reason,
+			expires_at,
+			ended_at,
+			ended_by,
+			created_at
+		FROM impersonations
+		WHERE $1 = '' OR hoster_id::text = $1
+		ORDER BY created_at DESC
+		LIMIT 100
+	`
+	if err := r.db.Select(&impersonations, query, hosterID); err != nil {
+		log.Printf("GetImpersonations: error querying impersonations: %v", err)
+		return nil, err
+	}
+	return impersonations, nil
+}
+
+/*
+Metode untuk mengakhiri sesi impersonasi yang masih berjalan.
+Nilai true dikembalikan jika sesi berhasil diakhiri.
+*/
+func (r *impersonationRepository) EndImpersonation(id, endedBy string) (bool, error) {
+	query := `
+		UPDATE impersonations
+		SET
+			ended_at = NOW(),
+			ended_by = $2
+		WHERE id = $1 AND ended_at IS NULL
+	`
+	result, err := r.db.Exec(query, id, endedBy)
+	if err != nil {
+		log.Printf("EndImpersonation: error ending impersonation %s: %v", id, err)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+/*
+Metode untuk menyimpan satu jejak audit permintaan impersonasi.
+ID dan timestamp jejak dikembalikan setelah penyisipan.
+*/
+func (r *impersonationRepository) CreateImpersonationLog(entry *model.ImpersonationLogModel) error {
+	query := `
+		INSERT INTO impersonation_audit_logs (
+			impersonation_id,
+			admin_id,
+			hoster_id,
+			method,
+			path,
+			status,
+			ip_address,
+			user_agent
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRow(query, entry.ImpersonationID, entry.AdminID, entry.HosterID, entry.Method, entry.Path, entry.Status, entry.IPAddress, entry.UserAgent).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		log.Printf("CreateImpersonationLog: error inserting audit log for %s: %v", entry.ImpersonationID, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk mengambil jejak audit sebuah sesi impersonasi.
+Daftar permintaan dikembalikan sesuai urutan waktu.
+*/
+func (r *impersonationRepository) GetImpersonationLogs(impersonationID string) ([]*model.ImpersonationLogModel, error) {
+	logs := []*model.ImpersonationLogModel{}
+	query := `
+		SELECT
+			id,
+			impersonation_id,
+			admin_id,
+			hoster_id,
+			method,
+			path,
+			status,
+			COALESCE(ip_address, '') AS ip_address,
+			COALESCE(user_agent, '') AS user_agent,
+			created_at
+		FROM impersonation_audit_logs
+		WHERE impersonation_id = $1
+		ORDER BY created_at
+	`
+	if err := r.db.Select(&logs, query, impersonationID); err != nil {
+		log.Printf("GetImpersonationLogs: error querying audit logs for %s: %v", impersonationID, err)
+		return nil, err
+	}
+	return logs, nil
+}
+
+/*
+Antarmuka untuk repositori impersonasi.
+Antarmuka ini mendefinisikan metode penyimpanan sesi impersonasi dan jejak auditnya.
+*/
+type ImpersonationRepository interface {
+	CreateImpersonation(impersonation *model.ImpersonationModel) error
+	FindImpersonation(id string) (*model.ImpersonationModel, error)
+	GetImpersonations(hosterID string) ([]*model.ImpersonationModel, error)
+	EndImpersonation(id, endedBy string) (bool, error)
+	CreateImpersonationLog(entry *model.ImpersonationLogModel) error
+	GetImpersonationLogs(impersonationID string) ([]*model.ImpersonationLogModel, error)
+}
+
+/*
+Fungsi untuk membuat instance baru dari ImpersonationRepository.
+Instance repositori dikembalikan.
+*/
+func NewImpersonationRepository(db *sqlx.DB) ImpersonationRepository {
+	return &impersonationRepository{db: db}
+}
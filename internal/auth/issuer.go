@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/model"
+)
+
+/*
+Variabel untuk error verifikasi access token.
+Variabel ini dikembalikan jika token tidak dapat diverifikasi dengan kunci yang dikenal.
+*/
+var ErrAccessTokenInvalid = errors.New("invalid or expired token")
+
+/*
+Struktur untuk penerbit token.
+Struktur ini menerbitkan access token bertanda tangan dan mendelegasikan refresh token ke layanan refresh.
+*/
+type tokenIssuer struct {
+	keys    *keyManager
+	refresh RefreshTokenService
+	ttl     time.Duration
+}
+
+/*
+Metode untuk menerbitkan access token dari claims.
+Token bertanda tangan beserta masa berlakunya dalam detik dikembalikan.
+*/
+func (i *tokenIssuer) IssueAccessToken(claims *Claims) (string, int, error) {
+	return i.IssueAccessTokenFor(claims, i.ttl)
+}
+
+/*
+Metode untuk menerbitkan access token dengan masa berlaku tertentu.
+Token bertanda tangan beserta masa berlakunya dalam detik dikembalikan, misalnya untuk token impersonasi yang lebih singkat.
+*/
+func (i *tokenIssuer) IssueAccessTokenFor(claims *Claims, ttl time.Duration) (string, int, error) {
+	now := time.Now()
+	if claims.ID == "" {
+		claims.ID = uuid.New().String()
+	}
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+
+	token, err := i.keys.sign(claims)
+	if err != nil {
+		return "", 0, err
+	}
+	return token, int(ttl.Seconds()), nil
+}
+
+/*
+Metode untuk menerbitkan refresh token baru dalam sesi baru.
+Token mentah dan ID sesi untuk klaim sid dikembalikan.
+*/
+func (i *tokenIssuer) IssueRefreshToken(userID, role string, client ClientInfo) (string, string, error) {
+	return i.refresh.Issue(userID, role, client)
+}
+
+/*
+Metode untuk merotasi refresh token.
+Token baru dan pemiliknya dikembalikan jika token lama valid.
+*/
+func (i *tokenIssuer) RotateRefreshToken(raw, role string) (*model.RefreshTokenModel, string, error) {
+	return i.refresh.Rotate(raw, role)
+}
+
+/*
+Metode untuk mencabut refresh token beserta keluarganya.
+Pemilik token dikembalikan jika token ditemukan.
+*/
+func (i *tokenIssuer) RevokeRefreshToken(raw, role string) (*model.RefreshTokenModel, error) {
+	return i.refresh.Revoke(raw, role)
+}
+
+/*
+Metode untuk memverifikasi access token.
+Claims dikembalikan jika tanda tangan, kid, dan masa berlaku valid.
+*/
+func (i *tokenIssuer) Verify(raw string) (*Claims, error) {
+	claims := &Claims{}
+	if err := i.keys.parse(raw, claims); err != nil {
+		return nil, ErrAccessTokenInvalid
+	}
+	return claims, nil
+}
+
+/*
+Metode untuk mendapatkan kunci publik dalam format JWKS.
+Kumpulan kunci publik yang masih diterima dikembalikan.
+*/
+func (i *tokenIssuer) JWKS() JWKSet {
+	return i.keys.jwks()
+}
+
+/*
+Antarmuka untuk verifikasi access token.
+Antarmuka ini digunakan middleware untuk memvalidasi token semua role.
+*/
+type TokenVerifier interface {
+	Verify(raw string) (*Claims, error)
+}
+
+/*
+Antarmuka untuk penerbit token.
+Antarmuka ini mendefinisikan penerbitan dan verifikasi token yang dipakai semua role.
+*/
+type TokenIssuer interface {
+	TokenVerifier
+	IssueAccessToken(claims *Claims) (string, int, error)
+	IssueAccessTokenFor(claims *Claims, ttl time.Duration) (string, int, error)
+	IssueRefreshToken(userID, role string, client ClientInfo) (string, string, error)
+	RotateRefreshToken(raw, role string) (*model.RefreshTokenModel, string, error)
+	RevokeRefreshToken(raw, role string) (*model.RefreshTokenModel, error)
+	JWKS() JWKSet
+}
+
+/*
+Fungsi untuk membuat instance baru dari TokenIssuer.
+Instance penerbit dikembalikan atau error jika kunci tidak dapat dimuat.
+*/
+func NewTokenIssuer(refresh RefreshTokenService) (TokenIssuer, error) {
+	keys, err := loadKeyManager()
+	if err != nil {
+		return nil, err
+	}
+	return &tokenIssuer{keys: keys, refresh: refresh, ttl: config.GetAccessTokenTTL()}, nil
+}
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"lalan-be/internal/config"
+)
+
+/*
+Struktur untuk kunci penandatanganan JWT.
+Struktur ini menyimpan kid, algoritma, kunci privat (jika ada), dan kunci verifikasi.
+*/
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private any
+	public  any
+}
+
+/*
+Struktur untuk JSON Web Key publik.
+Struktur ini mengikuti RFC 7517 untuk kunci RSA, EC, dan Ed25519.
+*/
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+/*
+Struktur untuk kumpulan JSON Web Key.
+Struktur ini dikembalikan oleh endpoint JWKS.
+*/
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+/*
+Struktur untuk pengelola kunci JWT.
+Struktur ini memilih kunci penandatangan aktif dan menyimpan semua kunci yang masih diterima berdasarkan kid.
+*/
+type keyManager struct {
+	signing *signingKey
+	keys    map[string]*signingKey
+}
+
+/*
+Metode untuk menandatangani claims dengan kunci aktif.
+Token JWT bertanda tangan dengan header kid dikembalikan.
+*/
+func (m *keyManager) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(m.signing.method, claims)
+	token.Header["kid"] = m.signing.kid
+	return token.SignedString(m.signing.private)
+}
+
+/*
+Metode untuk memverifikasi token dengan kunci sesuai kid.
+Claims dikembalikan jika tanda tangan dan masa berlaku valid.
+*/
+func (m *keyManager) parse(raw string, claims jwt.Claims) error {
+	token, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (interface{}, error) {
+		key := m.signing
+		if kid, ok := t.Header["kid"].(string); ok && kid != "" {
+			key = m.keys[kid]
+		}
+		if key == nil {
+			return nil, errors.New("unknown signing key")
+		}
+		if t.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+		}
+		return key.public, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !token.Valid {
+		return errors.New("invalid token")
+	}
+	return nil
+}
+
+/*
+Metode untuk membentuk JWKS dari kunci publik asimetris.
+Kunci HMAC tidak pernah dipublikasikan.
+*/
+func (m *keyManager) jwks() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	kids := make([]string, 0, len(m.keys))
+	for kid := range m.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	for _, kid := range kids {
+		key := m.keys[kid]
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: kid,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP",
+				Kid: kid,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	return set
+}
+
+/*
+Fungsi untuk memuat pengelola kunci dari konfigurasi.
+Kunci HMAC dibaca dari environment, sedangkan kunci RS256/EdDSA dibaca dari file PEM.
+*/
+func loadKeyManager() (*keyManager, error) {
+	m := &keyManager{keys: make(map[string]*signingKey)}
+	alg := strings.ToUpper(config.GetJWTAlgorithm())
+
+	switch alg {
+	case "HS256":
+		secret := config.GetJWTSecret()
+		kid := config.GetJWTKeyID()
+		m.signing = &signingKey{kid: kid, method: jwt.SigningMethodHS256, private: secret, public: secret}
+		m.keys[kid] = m.signing
+		// Rahasia lama tetap diterima selama masa rotasi
+		for prevKid, prevSecret := range config.GetJWTPreviousSecrets() {
+			m.keys[prevKid] = &signingKey{kid: prevKid, method: jwt.SigningMethodHS256, public: []byte(prevSecret)}
+		}
+	case "RS256", "EDDSA":
+		dir := config.GetJWTKeysDir()
+		if dir == "" {
+			return nil, errors.New("JWT_KEYS_DIR is required for asymmetric JWT signing")
+		}
+		files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			key, err := loadPEMKey(file)
+			if err != nil {
+				return nil, fmt.Errorf("load key %s: %w", file, err)
+			}
+			m.keys[key.kid] = key
+		}
+		signingKID := config.GetJWTKeyID()
+		m.signing = m.keys[signingKID]
+		if m.signing == nil || m.signing.private == nil {
+			return nil, fmt.Errorf("signing key %q not found or has no private key in %s", signingKID, dir)
+		}
+		if (alg == "RS256") != (m.signing.method == jwt.SigningMethodRS256) {
+			return nil, fmt.Errorf("signing key %q does not match JWT_ALG %s", signingKID, alg)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALG %q", alg)
+	}
+
+	kids := make([]string, 0, len(m.keys))
+	for kid := range m.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	log.Printf("Auth: signing with kid=%s alg=%s, accepting kids=%v", m.signing.kid, m.signing.method.Alg(), kids)
+	return m, nil
+}
+
+/*
+Fungsi untuk memuat satu kunci dari file PEM.
+Kid diambil dari nama file tanpa ekstensi .pem atau .pub.pem.
+*/
+func loadPEMKey(path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	kid := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".pem"), ".pub")
+
+	if priv, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return &signingKey{kid: kid, method: jwt.SigningMethodRS256, private: priv, public: &priv.PublicKey}, nil
+	}
+	if priv, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		edPriv, ok := priv.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("unsupported EdDSA private key")
+		}
+		return &signingKey{kid: kid, method: jwt.SigningMethodEdDSA, private: edPriv, public: edPriv.Public()}, nil
+	}
+	if pub, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return &signingKey{kid: kid, method: jwt.SigningMethodRS256, public: pub}, nil
+	}
+	if pub, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return &signingKey{kid: kid, method: jwt.SigningMethodEdDSA, public: pub}, nil
+	}
+	return nil, errors.New("unsupported PEM key, expected RSA or Ed25519")
+}
@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk jenis identifier percobaan login.
+Konstanta ini membedakan pencatatan per email dan per alamat IP.
+*/
+const (
+	loginKindEmail = "email"
+	loginKindIP    = "ip"
+)
+
+/*
+Variabel untuk error blokir login yang tidak ditemukan.
+Variabel ini dikembalikan saat admin menghapus blokir yang sudah tidak ada.
+*/
+var ErrLoginBlockNotFound = errors.New(message.MsgLoginBlockNotFound)
+
+/*
+Struktur untuk error login yang diblokir.
+Struktur ini membawa jenis blokir dan sisa waktu tunggu agar handler dapat memilih status 423 atau 429.
+*/
+type LoginBlockedError struct {
+	Locked     bool
+	RetryAfter time.Duration
+}
+
+/*
+Metode untuk mendapatkan pesan error blokir login.
+Pesan lockout atau terlalu banyak percobaan dikembalikan.
+*/
+func (e *LoginBlockedError) Error() string {
+	if e.Locked {
+		return message.MsgAccountLocked
+	}
+	return message.MsgTooManyLoginAttempts
+}
+
+/*
+Struktur untuk pelindung login.
+Struktur ini menerapkan backoff eksponensial per email dan IP serta lockout akun sementara.
+*/
+type loginGuard struct {
+	repo LoginAttemptRepository
+	cfg  config.LoginGuardConfig
+}
+
+/*
+Metode untuk memeriksa apakah login boleh dicoba.
+LoginBlockedError dikembalikan jika email terkunci atau email/IP masih dalam masa backoff.
+*/
+func (g *loginGuard) Check(role, email string, client ClientInfo) error {
+	now := time.Now()
+	var blocked *LoginBlockedError
+
+	if attempt, err := g.repo.FindLoginAttempt(loginKindEmail, normalizeEmail(email), role); err != nil {
+		return err
+	} else if attempt != nil {
+		if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+			return &LoginBlockedError{Locked: true, RetryAfter: attempt.LockedUntil.Sub(now)}
+		}
+		if attempt.RetryAt != nil && attempt.RetryAt.After(now) {
+			blocked = &LoginBlockedError{RetryAfter: attempt.RetryAt.Sub(now)}
+		}
+	}
+
+	if client.IP != "" {
+		attempt, err := g.repo.FindLoginAttempt(loginKindIP, client.IP, role)
+		if err != nil {
+			return err
+		}
+		if attempt != nil && attempt.RetryAt != nil && attempt.RetryAt.After(now) {
+			wait := attempt.RetryAt.Sub(now)
+			if blocked == nil || wait > blocked.RetryAfter {
+				blocked = &LoginBlockedError{RetryAfter: wait}
+			}
+		}
+	}
+
+	if blocked != nil {
+		return blocked
+	}
+	return nil
+}
+
+/*
+Metode untuk mencatat login yang gagal.
+Backoff dan lockout diperbarui sesuai jumlah kegagalan terbaru.
+*/
+func (g *loginGuard) RecordFailure(role, email string, client ClientInfo) {
+	now := time.Now()
+
+	if attempt, err := g.repo.RecordLoginFailure(loginKindEmail, normalizeEmail(email), role, g.cfg.AttemptWindow); err != nil {
+		log.Printf("LoginGuard: failed to record email failure: %v", err)
+	} else {
+		retryAt := g.retryAt(now, attempt.FailedCount, g.cfg.FreeAttempts)
+		var lockedUntil *time.Time
+		if attempt.FailedCount >= g.cfg.LockoutThreshold {
+			until := now.Add(g.cfg.LockoutDuration)
+			lockedUntil = &until
+			log.Printf("LoginGuard: locked %s %s until %s after %d failures", role, attempt.Identifier, until.Format(time.RFC3339), attempt.FailedCount)
+		}
+		if retryAt != nil || lockedUntil != nil {
+			if err := g.repo.SetLoginBlock(attempt.ID, retryAt, lockedUntil); err != nil {
+				log.Printf("LoginGuard: failed to set email block: %v", err)
+			}
+		}
+	}
+
+	if client.IP == "" {
+		return
+	}
+	if attempt, err := g.repo.RecordLoginFailure(loginKindIP, client.IP, role, g.cfg.AttemptWindow); err != nil {
+		log.Printf("LoginGuard: failed to record IP failure: %v", err)
+	} else if retryAt := g.retryAt(now, attempt.FailedCount, g.cfg.IPFreeAttempts); retryAt != nil {
+		if err := g.repo.SetLoginBlock(attempt.ID, retryAt, nil); err != nil {
+			log.Printf("LoginGuard: failed to set IP block: %v", err)
+		}
+	}
+}
+
+/*
+Metode untuk mencatat login yang berhasil.
+Catatan kegagalan email dihapus sedangkan catatan IP tetap agar satu akun valid tidak membuka blokir IP.
+*/
+func (g *loginGuard) RecordSuccess(role, email string) {
+	if err := g.repo.DeleteLoginAttempt(loginKindEmail, normalizeEmail(email), role); err != nil {
+		log.Printf("LoginGuard: failed to clear attempts: %v", err)
+	}
+}
+
+/*
+Metode untuk mengambil daftar blokir login yang masih aktif.
+Daftar catatan percobaan dengan backoff atau lockout dikembalikan.
+*/
+func (g *loginGuard) GetActiveBlocks() ([]*model.LoginAttemptModel, error) {
+	return g.repo.GetActiveLoginBlocks()
+}
+
+/*
+Metode untuk menghapus blokir login berdasarkan ID.
+ErrLoginBlockNotFound dikembalikan jika catatan tidak ditemukan.
+*/
+func (g *loginGuard) ClearBlock(id string) error {
+	deleted, err := g.repo.DeleteLoginAttemptByID(id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrLoginBlockNotFound
+	}
+	return nil
+}
+
+/*
+Metode untuk membersihkan catatan percobaan login yang sudah usang.
+Catatan di luar jendela waktu tanpa blokir aktif dihapus.
+*/
+func (g *loginGuard) Purge() {
+	if n, err := g.repo.DeleteStaleLoginAttempts(g.cfg.AttemptWindow); err == nil && n > 0 {
+		log.Printf("LoginGuard: purged %d stale login attempts", n)
+	}
+}
+
+/*
+Metode untuk menghitung waktu percobaan berikutnya dengan backoff eksponensial.
+Nil dikembalikan jika jumlah kegagalan masih dalam batas percobaan bebas.
+*/
+func (g *loginGuard) retryAt(now time.Time, failed, free int) *time.Time {
+	if failed <= free {
+		return nil
+	}
+	delay := g.cfg.BackoffBase
+	for i := free + 1; i < failed && delay < g.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > g.cfg.BackoffMax {
+		delay = g.cfg.BackoffMax
+	}
+	at := now.Add(delay)
+	return &at
+}
+
+/*
+Antarmuka untuk pelindung login.
+Antarmuka ini mendefinisikan pemeriksaan, pencatatan, dan pengelolaan blokir login.
+*/
+type LoginGuard interface {
+	Check(role, email string, client ClientInfo) error
+	RecordFailure(role, email string, client ClientInfo)
+	RecordSuccess(role, email string)
+	GetActiveBlocks() ([]*model.LoginAttemptModel, error)
+	ClearBlock(id string) error
+	Purge()
+}
+
+/*
+Fungsi untuk menormalkan email sebagai identifier percobaan login.
+Email huruf kecil tanpa spasi dikembalikan.
+*/
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+/*
+Fungsi untuk membuat instance baru dari LoginGuard.
+Instance pelindung dengan konfigurasi dari environment dikembalikan.
+*/
+func NewLoginGuard(repo LoginAttemptRepository) LoginGuard {
+	return &loginGuard{repo: repo, cfg: config.GetLoginGuardConfig()}
+}
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori percobaan login.
+Struktur ini menyediakan akses database untuk pencatatan kegagalan login.
+*/
+type loginAttemptRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk mengambil catatan percobaan login.
+Model percobaan dikembalikan atau nil jika belum ada kegagalan.
+*/
+func (r *loginAttemptRepository) FindLoginAttempt(kind, identifier, role string) (*model.LoginAttemptModel, error) {
+	var attempt model.LoginAttemptModel
+	query := `
+		SELECT
+			id,
+			kind,
+			identifier,
+			role,
+			failed_count,
+			last_failed_at,
+			retry_at,
+			locked_until,
+			created_at,
+			updated_at
+		FROM login_attempts
+		WHERE kind = $1 AND identifier = $2 AND role = $3
+	`
+	err := r.db.Get(&attempt, query, kind, identifier, role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Printf("FindLoginAttempt: error querying %s %s: %v", kind, identifier, err)
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+/*
+Metode untuk mencatat satu kegagalan login.
+Jumlah kegagalan diulang dari satu jika kegagalan terakhir di luar jendela waktu.
+*/
+func (r *loginAttemptRepository) RecordLoginFailure(kind, identifier, role string, window time.Duration) (*model.LoginAttemptModel, error) {
+	var attempt model.LoginAttemptModel
+	query := `
+		INSERT INTO login_attempts (
+			kind,
+			identifier,
+			role,
+			failed_count,
+			last_failed_at
+		) VALUES ($1, $2, $3, 1, NOW())
+		ON CONFLICT (kind, identifier, role) DO UPDATE
+		SET
+			failed_count = CASE
+				WHEN login_attempts.last_failed_at < NOW() - make_interval(secs => $4) THEN 1
+				ELSE login_attempts.failed_count + 1
+			END,
+			last_failed_at = NOW(),
+			updated_at = NOW()
+		RETURNING id, kind, identifier, role, failed_count, last_failed_at, retry_at, locked_until, created_at, updated_at
+	`
+	if err := r.db.Get(&attempt, query, kind, identifier, role, window.Seconds()); err != nil {
+		log.Printf("RecordLoginFailure: error recording %s %s: %v", kind, identifier, err)
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+/*
+Metode untuk menetapkan waktu backoff dan lockout.
+Catatan percobaan diperbarui dengan batas waktu baru.
+*/
+func (r *loginAttemptRepository) SetLoginBlock(id string, retryAt, lockedUntil *time.Time) error {
+	query := `
+		UPDATE login_attempts
+		SET
+			retry_at = $1,
+			locked_until = COALESCE($2, locked_until),
+			updated_at = NOW()
+		WHERE id = $3
+	`
+	if _, err := r.db.Exec(query, retryAt, lockedUntil, id); err != nil {
+		log.Printf("SetLoginBlock: error updating attempt %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk menghapus catatan percobaan login setelah login berhasil.
+Catatan kegagalan untuk identifier tersebut dihapus.
+*/
+func (r *loginAttemptRepository) DeleteLoginAttempt(kind, identifier, role string) error {
+	query := `DELETE FROM login_attempts WHERE kind = $1 AND identifier = $2 AND role = $3`
+	if _, err := r.db.Exec(query, kind, identifier, role); err != nil {
+		log.Printf("DeleteLoginAttempt: error deleting %s %s: %v", kind, identifier, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk menghapus catatan percobaan login berdasarkan ID.
+Nilai true dikembalikan jika catatan ditemukan dan dihapus.
+*/
+func (r *loginAttemptRepository) DeleteLoginAttemptByID(id string) (bool, error) {
+	res, err := r.db.Exec(`DELETE FROM login_attempts WHERE id = $1`, id)
+	if err != nil {
+		log.Printf("DeleteLoginAttemptByID: error deleting %s: %v", id, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+/*
+Metode untuk mengambil daftar blokir login yang masih aktif.
+Daftar catatan dengan backoff atau lockout yang belum berakhir dikembalikan.
+*/
+func (r *loginAttemptRepository) GetActiveLoginBlocks() ([]*model.LoginAttemptModel, error) {
+	var attempts []*model.LoginAttemptModel
+	query := `
+		SELECT
+			id,
+			kind,
+			identifier,
+			role,
+			failed_count,
+			last_failed_at,
+			retry_at,
+			locked_until,
+			created_at,
+			updated_at
+		FROM login_attempts
+		WHERE locked_until > NOW() OR retry_at > NOW()
+		ORDER BY last_failed_at DESC
+	`
+	if err := r.db.Select(&attempts, query); err != nil {
+		log.Printf("GetActiveLoginBlocks: error querying blocks: %v", err)
+		return nil, err
+	}
+	return attempts, nil
+}
+
+/*
+Metode untuk menghapus catatan percobaan login yang sudah usang.
+Jumlah baris yang dihapus dikembalikan.
+*/
+func (r *loginAttemptRepository) DeleteStaleLoginAttempts(window time.Duration) (int64, error) {
+	query := `
+		DELETE FROM login_attempts
+		WHERE last_failed_at < NOW() - make_interval(secs => $1)
+			AND (locked_until IS NULL OR locked_until < NOW())
+			AND (retry_at IS NULL OR retry_at < NOW())
+	`
+	res, err := r.db.Exec(query, window.Seconds())
+	if err != nil {
+		log.Printf("DeleteStaleLoginAttempts: error: %v", err)
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+/*
+Antarmuka untuk repositori percobaan login.
+Antarmuka ini mendefinisikan metode pencatatan dan pembersihan kegagalan login.
+*/
+type LoginAttemptRepository interface {
+	FindLoginAttempt(kind, identifier, role string) (*model.LoginAttemptModel, error)
+	RecordLoginFailure(kind, identifier, role string, window time.Duration) (*model.LoginAttemptModel, error)
+	SetLoginBlock(id string, retryAt, lockedUntil *time.Time) error
+	DeleteLoginAttempt(kind, identifier, role string) error
+	DeleteLoginAttemptByID(id string) (bool, error)
+	GetActiveLoginBlocks() ([]*model.LoginAttemptModel, error)
+	DeleteStaleLoginAttempts(window time.Duration) (int64, error)
+}
+
+/*
+Fungsi untuk membuat instance baru dari LoginAttemptRepository.
+Instance repositori dikembalikan.
+*/
+func NewLoginAttemptRepository(db *sqlx.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
@@ -0,0 +1,251 @@
+package auth
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori MFA.
+Struktur ini menyediakan akses database untuk secret TOTP dan kode pemulihan.
+*/
+type mfaRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk mengambil data TOTP pengguna.
+Model TOTP dikembalikan atau nil jika pengguna belum mendaftar.
+*/
+func (r *mfaRepository) FindTOTP(userID, role string) (*model.MFATOTPModel, error) {
+	var totp model.MFATOTPModel
+	query := `
+		SELECT
+			user_id,
+			role,
+			secret_encrypted,
+			enabled_at,
+			last_used_step,
+			created_at,
+			updated_at
+		FROM mfa_totp
+		WHERE user_id = $1 AND role = $2
+	`
+	err := r.db.Get(&totp, query, userID, role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Printf("FindTOTP: error querying %s %s: %v", role, userID, err)
+		return nil, err
+	}
+	return &totp, nil
+}
+
+/*
+Metode untuk menyimpan secret TOTP yang belum dikonfirmasi.
+Nilai false dikembalikan jika pengguna sudah memiliki TOTP aktif.
+*/
+func (r *mfaRepository) UpsertPendingTOTP(userID, role, secretEncrypted string) (bool, error) {
+	query := `
+		INSERT INTO mfa_totp (
+			user_id,
+			role,
+			secret_encrypted
+		) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, role) DO UPDATE
+		SET
+			secret_encrypted = EXCLUDED.secret_encrypted,
+			last_used_step = NULL,
+			updated_at = NOW()
+		WHERE mfa_totp.enabled_at IS NULL
+	`
+	res, err := r.db.Exec(query, userID, role, secretEncrypted)
+	if err != nil {
+		log.Printf("UpsertPendingTOTP: error saving secret for %s %s: %v", role, userID, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+/*
+Metode untuk mengaktifkan TOTP beserta kode pemulihan baru.
+Nilai false dikembalikan jika tidak ada pendaftaran yang menunggu konfirmasi.
+*/
+func (r *mfaRepository) EnableTOTP(userID, role string, step int64, codeHashes []string) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	enable := `
+		UPDATE mfa_totp
+		SET
+			enabled_at = NOW(),
+			last_used_step = $3,
+			updated_at = NOW()
+		WHERE user_id = $1 AND role = $2 AND enabled_at IS NULL
+	`
+	res, err := tx.Exec(enable, userID, role, step)
+	if err != nil {
+		log.Printf("EnableTOTP: error enabling TOTP for %s %s: %v", role, userID, err)
+		return false, err
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		return false, err
+	}
+
+	if err := replaceRecoveryCodes(tx, userID, role, codeHashes); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	log.Printf("EnableTOTP: enabled TOTP for %s %s", role, userID)
+	return true, nil
+}
+
+/*
+Metode untuk mencatat langkah TOTP yang baru dipakai.
+Nilai false dikembalikan jika langkah tersebut atau yang lebih baru sudah pernah dipakai.
+*/
+func (r *mfaRepository) UseTOTPStep(userID, role string, step int64) (bool, error) {
+	query := `
+		UPDATE mfa_totp
+		SET
+			last_used_step = $3,
+			updated_at = NOW()
+		WHERE user_id = $1 AND role = $2 AND enabled_at IS NOT NULL
+			AND (last_used_step IS NULL OR last_used_step < $3)
+	`
+	res, err := r.db.Exec(query, userID, role, step)
+	if err != nil {
+		log.Printf("UseTOTPStep: error updating step for %s %s: %v", role, userID, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+/*
+Metode untuk mengganti seluruh kode pemulihan pengguna.
+Kode lama dihapus dan kode baru disimpan dalam satu transaksi.
+*/
+func (r *mfaRepository) ReplaceRecoveryCodes(userID, role string, codeHashes []string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := replaceRecoveryCodes(tx, userID, role, codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+/*
+Metode untuk memakai satu kode pemulihan secara atomik.
+Nilai true dikembalikan jika kode valid dan belum pernah dipakai.
+*/
+func (r *mfaRepository) ConsumeRecoveryCode(userID, role, codeHash string) (bool, error) {
+	query := `
+		UPDATE mfa_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND role = $2 AND code_hash = $3 AND used_at IS NULL
+	`
+	res, err := r.db.Exec(query, userID, role, codeHash)
+	if err != nil {
+		log.Printf("ConsumeRecoveryCode: error consuming code for %s %s: %v", role, userID, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+/*
+Metode untuk menghapus TOTP dan kode pemulihan pengguna.
+MFA pengguna dinonaktifkan sepenuhnya.
+*/
+func (r *mfaRepository) DeleteTOTP(userID, role string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM mfa_recovery_codes WHERE user_id = $1 AND role = $2`, userID, role); err != nil {
+		log.Printf("DeleteTOTP: error deleting recovery codes for %s %s: %v", role, userID, err)
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM mfa_totp WHERE user_id = $1 AND role = $2`, userID, role); err != nil {
+		log.Printf("DeleteTOTP: error deleting TOTP for %s %s: %v", role, userID, err)
+		return err
+	}
+
+	log.Printf("DeleteTOTP: disabled MFA for %s %s", role, userID)
+	return tx.Commit()
+}
+
+/*
+Fungsi untuk mengganti kode pemulihan di dalam transaksi.
+Kode lama dihapus lalu hash kode baru disisipkan.
+*/
+func replaceRecoveryCodes(tx *sqlx.Tx, userID, role string, codeHashes []string) error {
+	if _, err := tx.Exec(`DELETE FROM mfa_recovery_codes WHERE user_id = $1 AND role = $2`, userID, role); err != nil {
+		log.Printf("replaceRecoveryCodes: error deleting old codes for %s %s: %v", role, userID, err)
+		return err
+	}
+	insert := `
+		INSERT INTO mfa_recovery_codes (
+			user_id,
+			role,
+			code_hash
+		) VALUES ($1, $2, $3)
+	`
+	for _, hash := range codeHashes {
+		if _, err := tx.Exec(insert, userID, role, hash); err != nil {
+			log.Printf("replaceRecoveryCodes: error inserting code for %s %s: %v", role, userID, err)
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+Antarmuka untuk repositori MFA.
+Antarmuka ini mendefinisikan metode penyimpanan TOTP dan kode pemulihan.
+*/
+type MFARepository interface {
+	FindTOTP(userID, role string) (*model.MFATOTPModel, error)
+	UpsertPendingTOTP(userID, role, secretEncrypted string) (bool, error)
+	EnableTOTP(userID, role string, step int64, codeHashes []string) (bool, error)
+	UseTOTPStep(userID, role string, step int64) (bool, error)
+	ReplaceRecoveryCodes(userID, role string, codeHashes []string) error
+	ConsumeRecoveryCode(userID, role, codeHash string) (bool, error)
+	DeleteTOTP(userID, role string) error
+}
+
+/*
+Fungsi untuk membuat instance baru dari MFARepository.
+Instance repositori dikembalikan.
+*/
+func NewMFARepository(db *sqlx.DB) MFARepository {
+	return &mfaRepository{db: db}
+}
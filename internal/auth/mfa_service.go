@@ -0,0 +1,379 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/pkg/message"
+	"lalan-be/pkg/totp"
+)
+
+/*
+Konstanta untuk kode pemulihan MFA.
+Konstanta ini menentukan jumlah kode yang diterbitkan setiap kali dibuat ulang.
+*/
+const recoveryCodeCount = 10
+
+/*
+Variabel untuk error MFA.
+Variabel ini digunakan handler untuk membedakan penyebab kegagalan MFA.
+*/
+var (
+	ErrMFACodeInvalid      = errors.New(message.MsgMFACodeInvalid)
+	ErrMFAAlreadyEnabled   = errors.New(message.MsgMFAAlreadyEnabled)
+	ErrMFANotEnabled       = errors.New(message.MsgMFANotEnabled)
+	ErrMFANotPending       = errors.New(message.MsgMFANotPending)
+	ErrMFAChallengeInvalid = errors.New(message.MsgMFAChallengeInvalid)
+)
+
+/*
+Struktur untuk data pendaftaran TOTP.
+Struktur ini berisi secret dan URI provisioning untuk kode QR.
+*/
+type MFAEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+/*
+Struktur untuk isi token tantangan MFA.
+Struktur ini berisi pengguna yang lolos verifikasi password dan menunggu kode OTP.
+*/
+type MFAChallenge struct {
+	UserID    string `json:"uid"`
+	Role      string `json:"role"`
+	Email     string `json:"email"`
+	Purpose   string `json:"purpose"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+/*
+Struktur untuk layanan MFA.
+Struktur ini menyediakan pendaftaran TOTP, verifikasi kode, kode pemulihan, dan token tantangan login.
+*/
+type mfaService struct {
+	repo MFARepository
+}
+
+/*
+Metode untuk memeriksa apakah pengguna sudah mengaktifkan MFA.
+Nilai true dikembalikan jika TOTP pengguna sudah dikonfirmasi.
+*/
+func (s *mfaService) IsEnabled(userID, role string) (bool, error) {
+	record, err := s.repo.FindTOTP(userID, role)
+	if err != nil {
+		return false, err
+	}
+	return record != nil && record.EnabledAt != nil, nil
+}
+
+/*
+Metode untuk memulai pendaftaran TOTP.
+Secret baru dan URI provisioning dikembalikan, menggantikan pendaftaran lama yang belum dikonfirmasi.
+*/
+func (s *mfaService) BeginEnrollment(userID, role, account string) (*MFAEnrollment, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := encryptSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.repo.UpsertPendingTOTP(userID, role, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	return &MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(config.GetMFAIssuer(), account, secret),
+	}, nil
+}
+
+/*
+Metode untuk mengonfirmasi pendaftaran TOTP dengan kode pertama.
+Kode pemulihan mentah dikembalikan satu kali untuk disimpan pengguna.
+*/
+func (s *mfaService) ConfirmEnrollment(userID, role, code string) ([]string, error) {
+	record, err := s.repo.FindTOTP(userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrMFANotPending
+	}
+	if record.EnabledAt != nil {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := decryptSecret(record.SecretEncrypted)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := totp.Validate(code, secret, time.Now())
+	if !ok {
+		return nil, ErrMFACodeInvalid
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := s.repo.EnableTOTP(userID, role, step, hashes)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, ErrMFANotPending
+	}
+	return codes, nil
+}
+
+/*
+Metode untuk memverifikasi kode OTP atau kode pemulihan.
+ErrMFACodeInvalid dikembalikan jika kode salah atau sudah pernah dipakai.
+*/
+func (s *mfaService) Verify(userID, role, code string) error {
+	record, err := s.repo.FindTOTP(userID, role)
+	if err != nil {
+		return err
+	}
+	if record == nil || record.EnabledAt == nil {
+		return ErrMFANotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		secret, err := decryptSecret(record.SecretEncrypted)
+		if err != nil {
+			return err
+		}
+		step, ok := totp.Validate(code, secret, time.Now())
+		if !ok {
+			return ErrMFACodeInvalid
+		}
+		// Tolak kode yang sama dipakai dua kali dalam jendela waktunya
+		fresh, err := s.repo.UseTOTPStep(userID, role, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return ErrMFACodeInvalid
+		}
+		return nil
+	}
+
+	consumed, err := s.repo.ConsumeRecoveryCode(userID, role, HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrMFACodeInvalid
+	}
+	return nil
+}
+
+/*
+Metode untuk menonaktifkan MFA setelah kode diverifikasi.
+Secret TOTP dan semua kode pemulihan dihapus.
+*/
+func (s *mfaService) Disable(userID, role, code string) error {
+	if err := s.Verify(userID, role, code); err != nil {
+		return err
+	}
+	return s.repo.DeleteTOTP(userID, role)
+}
+
+/*
+Metode untuk membuat ulang kode pemulihan setelah kode diverifikasi.
+Kode pemulihan baru dikembalikan dan kode lama tidak berlaku lagi.
+*/
+func (s *mfaService) RegenerateRecoveryCodes(userID, role, code string) ([]string, error) {
+	if err := s.Verify(userID, role, code); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceRecoveryCodes(userID, role, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+/*
+Metode untuk menerbitkan token tantangan MFA setelah password valid.
+Token bertanda tangan beserta masa berlakunya dalam detik dikembalikan.
+*/
+func (s *mfaService) IssueChallenge(userID, role, email string) (string, int, error) {
+	ttl := config.GetMFAChallengeTTL()
+	challenge := MFAChallenge{
+		UserID:    userID,
+		Role:      role,
+		Email:     strings.ToLower(email),
+		Purpose:   "mfa",
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+	}
+	payload, err := json.Marshal(challenge)
+	if err != nil {
+		return "", 0, err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + signChallenge(encoded), int(ttl.Seconds()), nil
+}
+
+/*
+Metode untuk memverifikasi token tantangan MFA.
+Isi tantangan dikembalikan jika tanda tangan cocok, role sesuai, dan belum kedaluwarsa.
+*/
+func (s *mfaService) ParseChallenge(token, role string) (*MFAChallenge, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrMFAChallengeInvalid
+	}
+	if !hmac.Equal([]byte(signChallenge(parts[0])), []byte(parts[1])) {
+		return nil, ErrMFAChallengeInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrMFAChallengeInvalid
+	}
+	var challenge MFAChallenge
+	if err := json.Unmarshal(payload, &challenge); err != nil {
+		return nil, ErrMFAChallengeInvalid
+	}
+	if challenge.Purpose != "mfa" || challenge.Role != role || time.Now().Unix() > challenge.ExpiresAt {
+		return nil, ErrMFAChallengeInvalid
+	}
+	return &challenge, nil
+}
+
+/*
+Antarmuka untuk layanan MFA.
+Antarmuka ini mendefinisikan pendaftaran, verifikasi, dan tantangan login MFA.
+*/
+type MFAService interface {
+	IsEnabled(userID, role string) (bool, error)
+	BeginEnrollment(userID, role, account string) (*MFAEnrollment, error)
+	ConfirmEnrollment(userID, role, code string) ([]string, error)
+	Verify(userID, role, code string) error
+	Disable(userID, role, code string) error
+	RegenerateRecoveryCodes(userID, role, code string) ([]string, error)
+	IssueChallenge(userID, role, email string) (string, int, error)
+	ParseChallenge(token, role string) (*MFAChallenge, error)
+}
+
+/*
+Fungsi untuk menghasilkan kode pemulihan beserta hash-nya.
+Kode mentah berformat xxxxx-xxxxx dan hash SHA-256 dikembalikan.
+*/
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	encoder := base32.StdEncoding.WithPadding(base32.NoPadding)
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 7)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(encoder.EncodeToString(buf))[:10]
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, HashToken(raw))
+	}
+	return codes, hashes, nil
+}
+
+/*
+Fungsi untuk menormalkan kode pemulihan dari input pengguna.
+Kode huruf kecil tanpa tanda hubung dan spasi dikembalikan.
+*/
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+/*
+Fungsi untuk menghitung tanda tangan token tantangan MFA.
+Tanda tangan HMAC-SHA256 base64 URL-safe dikembalikan.
+*/
+func signChallenge(payload string) string {
+	mac := hmac.New(sha256.New, config.GetMFAEncryptionKey())
+	mac.Write([]byte("mfa-challenge:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+/*
+Fungsi untuk membentuk cipher AES-GCM dari kunci enkripsi MFA.
+Cipher AEAD dikembalikan.
+*/
+func mfaCipher() (cipher.AEAD, error) {
+	key := sha256.Sum256(config.GetMFAEncryptionKey())
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+/*
+Fungsi untuk mengenkripsi secret TOTP sebelum disimpan.
+Nonce dan ciphertext dalam base64 dikembalikan.
+*/
+func encryptSecret(secret string) (string, error) {
+	aead, err := mfaCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+/*
+Fungsi untuk mendekripsi secret TOTP dari database.
+Secret base32 asli dikembalikan.
+*/
+func decryptSecret(encrypted string) (string, error) {
+	aead, err := mfaCipher()
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < aead.NonceSize() {
+		return "", errors.New("invalid encrypted secret")
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+/*
+Fungsi untuk membuat instance baru dari MFAService.
+Instance layanan dikembalikan.
+*/
+func NewMFAService(repo MFARepository) MFAService {
+	return &mfaService{repo: repo}
+}
@@ -0,0 +1,511 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Variabel untuk error login OpenID Connect.
+Variabel ini dikembalikan saat penyedia belum dikonfigurasi, state tidak valid, pertukaran kode gagal, atau akun dengan email yang sama belum terverifikasi.
+*/
+var (
+	ErrOIDCDisabled          = errors.New(message.MsgOIDCDisabled)
+	ErrOIDCStateInvalid      = errors.New(message.MsgOIDCStateInvalid)
+	ErrOIDCExchangeFailed    = errors.New(message.MsgOIDCExchangeFailed)
+	ErrOIDCEmailUnverified   = errors.New(message.MsgOIDCEmailUnverified)
+	ErrOIDCAccountUnverified = errors.New(message.MsgOIDCAccountUnverified)
+)
+
+/*
+Struktur untuk identitas pengguna dari penyedia OpenID Connect.
+Struktur ini berisi issuer, subject, email, status verifikasi email, dan nama dari ID token.
+*/
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+/*
+Struktur untuk dokumen discovery penyedia OpenID Connect.
+Struktur ini berisi endpoint yang dibaca dari /.well-known/openid-configuration.
+*/
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+/*
+Struktur untuk respons token endpoint penyedia.
+Struktur ini berisi ID token atau error dari pertukaran kode otorisasi.
+*/
+type oidcTokenResponse struct {
+	IDToken          string `json:"id_token"`
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+/*
+Struktur untuk claims ID token OpenID Connect.
+Struktur ini berisi claims standar, nonce, dan profil email pengguna.
+*/
+type oidcIDTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+/*
+Struktur untuk penyedia login OpenID Connect.
+Struktur ini menjalankan alur authorization code dengan PKCE dan menyimpan cache discovery serta JWKS penyedia.
+*/
+type oidcProvider struct {
+	cfg           config.OIDCConfig
+	repo          OIDCRepository
+	client        *http.Client
+	mu            sync.Mutex
+	discovery     *oidcDiscovery
+	keys          map[string]any
+	keysFetchedAt time.Time
+}
+
+/*
+Metode untuk memeriksa apakah login OpenID Connect dikonfigurasi.
+Nilai true dikembalikan jika issuer dan client ID diisi.
+*/
+func (p *oidcProvider) Enabled() bool {
+	return p.cfg.Issuer != "" && p.cfg.ClientID != ""
+}
+
+/*
+Metode untuk membuat URL otorisasi penyedia bagi role tertentu.
+State, nonce, dan code verifier PKCE disimpan lalu URL tujuan redirect pengguna dikembalikan.
+*/
+func (p *oidcProvider) AuthorizationURL(role string) (string, error) {
+	if !p.Enabled() {
+		return "", ErrOIDCDisabled
+	}
+	discovery, err := p.loadDiscovery()
+	if err != nil {
+		return "", err
+	}
+
+	state, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	if err := p.repo.CreateOIDCState(&model.OIDCStateModel{
+		StateHash:    HashToken(state),
+		Role:         role,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(config.GetOIDCStateTTL()),
+	}); err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+/*
+Metode untuk menukar kode otorisasi menjadi identitas pengguna.
+State dipakai sekali, ID token diverifikasi dengan JWKS penyedia, lalu identitas dikembalikan.
+*/
+func (p *oidcProvider) Exchange(role, state, code string) (*OIDCIdentity, error) {
+	if !p.Enabled() {
+		return nil, ErrOIDCDisabled
+	}
+	pending, err := p.repo.ConsumeOIDCState(HashToken(state), role)
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil {
+		return nil, ErrOIDCStateInvalid
+	}
+	discovery, err := p.loadDiscovery()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", pending.CodeVerifier)
+	req, err := http.NewRequest(http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		log.Printf("OIDC: token request failed: %v", err)
+		return nil, ErrOIDCExchangeFailed
+	}
+	defer resp.Body.Close()
+	var token oidcTokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		log.Printf("OIDC: invalid token response: %v", err)
+		return nil, ErrOIDCExchangeFailed
+	}
+	if resp.StatusCode != http.StatusOK || token.IDToken == "" {
+		log.Printf("OIDC: token endpoint returned %d: %s %s", resp.StatusCode, token.Error, token.ErrorDescription)
+		return nil, ErrOIDCExchangeFailed
+	}
+
+	claims, err := p.verifyIDToken(discovery, token.IDToken, pending.Nonce)
+	if err != nil {
+		log.Printf("OIDC: id token rejected: %v", err)
+		return nil, ErrOIDCExchangeFailed
+	}
+	return &OIDCIdentity{
+		Issuer:        discovery.Issuer,
+		Subject:       claims.Subject,
+		Email:         strings.TrimSpace(claims.Email),
+		EmailVerified: isTrueClaim(claims.EmailVerified),
+		Name:          strings.TrimSpace(claims.Name),
+	}, nil
+}
+
+/*
+Metode untuk mencari akun yang tertaut ke identitas.
+ID akun dikembalikan atau string kosong jika identitas belum tertaut.
+*/
+func (p *oidcProvider) LinkedUserID(identity *OIDCIdentity, role string) (string, error) {
+	linked, err := p.repo.FindOIDCIdentity(identity.Issuer, identity.Subject, role)
+	if err != nil || linked == nil {
+		return "", err
+	}
+	return linked.UserID, nil
+}
+
+/*
+Metode untuk menautkan identitas ke akun.
+Login berikutnya dengan identitas yang sama langsung memakai akun tersebut.
+*/
+func (p *oidcProvider) Link(identity *OIDCIdentity, role, userID string) error {
+	return p.repo.LinkOIDCIdentity(&model.OIDCIdentityModel{
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		Role:    role,
+		UserID:  userID,
+		Email:   identity.Email,
+	})
+}
+
+/*
+Metode untuk mengambil identitas penyedia yang tertaut ke akun.
+Daftar identitas dikembalikan walaupun login OpenID Connect sedang dimatikan.
+*/
+func (p *oidcProvider) Identities(userID, role string) ([]*model.OIDCIdentityModel, error) {
+	return p.repo.GetOIDCIdentitiesByUser(userID, role)
+}
+
+/*
+Metode untuk membersihkan state login yang kedaluwarsa.
+State yang tidak pernah diselesaikan dihapus dari database.
+*/
+func (p *oidcProvider) Purge() {
+	if !p.Enabled() {
+		return
+	}
+	if n, err := p.repo.DeleteExpiredOIDCStates(); err == nil && n > 0 {
+		log.Printf("OIDC: purged %d expired login states", n)
+	}
+}
+
+/*
+Metode untuk memverifikasi ID token dari penyedia.
+Claims dikembalikan jika tanda tangan, issuer, audience, masa berlaku, dan nonce valid.
+*/
+func (p *oidcProvider) verifyIDToken(discovery *oidcDiscovery, raw, nonce string) (*oidcIDTokenClaims, error) {
+	var claims oidcIDTokenClaims
+	_, err := jwt.ParseWithClaims(raw, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.key(discovery, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "EdDSA"}),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("nonce mismatch")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("missing subject")
+	}
+	return &claims, nil
+}
+
+/*
+Metode untuk mengambil kunci verifikasi penyedia berdasarkan kid.
+JWKS dibaca ulang paling sering sekali per menit jika kid belum dikenal.
+*/
+func (p *oidcProvider) key(discovery *oidcDiscovery, kid string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key := lookupOIDCKey(p.keys, kid); key != nil {
+		return key, nil
+	}
+	if p.keys != nil && time.Since(p.keysFetchedAt) < time.Minute {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	var set JWKSet
+	if err := p.getJSON(discovery.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]any, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(jwk)
+		if err != nil {
+			log.Printf("OIDC: skipping provider key %s: %v", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	if key := lookupOIDCKey(p.keys, kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+/*
+Metode untuk memuat dokumen discovery penyedia.
+Dokumen dibaca sekali lalu disimpan di memori.
+*/
+func (p *oidcProvider) loadDiscovery() (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var discovery oidcDiscovery
+	if err := p.getJSON(p.cfg.Issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		log.Printf("OIDC: discovery failed: %v", err)
+		return nil, ErrOIDCExchangeFailed
+	}
+	// Issuer pada dokumen wajib sama persis dengan issuer yang dikonfigurasi
+	if strings.TrimRight(discovery.Issuer, "/") != p.cfg.Issuer || discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		log.Printf("OIDC: discovery document for %s is incomplete or has a different issuer", p.cfg.Issuer)
+		return nil, ErrOIDCExchangeFailed
+	}
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+/*
+Metode untuk mengambil dokumen JSON dari penyedia.
+Isi respons didekode ke tujuan jika status HTTP 200.
+*/
+func (p *oidcProvider) getJSON(target string, out any) error {
+	resp, err := p.client.Get(target)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", target, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out)
+}
+
+/*
+Antarmuka untuk penyedia login OpenID Connect.
+Antarmuka ini mendefinisikan metode alur otorisasi dan penautan identitas yang dipakai layanan hoster dan customer.
+*/
+type OIDCProvider interface {
+	Enabled() bool
+	AuthorizationURL(role string) (string, error)
+	Exchange(role, state, code string) (*OIDCIdentity, error)
+	LinkedUserID(identity *OIDCIdentity, role string) (string, error)
+	Link(identity *OIDCIdentity, role, userID string) error
+	Identities(userID, role string) ([]*model.OIDCIdentityModel, error)
+	Purge()
+}
+
+/*
+Fungsi untuk mencari kunci penyedia berdasarkan kid.
+Satu-satunya kunci dipakai jika token tidak menyertakan kid.
+*/
+func lookupOIDCKey(keys map[string]any, kid string) any {
+	if key, ok := keys[kid]; ok {
+		return key
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key
+		}
+	}
+	return nil
+}
+
+/*
+Fungsi untuk mengubah JSON Web Key menjadi kunci publik.
+Kunci RSA, EC (P-256, P-384, P-521), atau Ed25519 dikembalikan.
+*/
+func parseJWK(jwk JWK) (any, error) {
+	decode := base64.RawURLEncoding.DecodeString
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decode(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := decode(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := decode(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+}
+
+/*
+Fungsi untuk membuat hash password yang tidak dapat dipakai login.
+Hash bcrypt dari token acak yang tidak pernah diketahui siapa pun dikembalikan, untuk akun yang hanya login melalui penyedia.
+*/
+func UnusablePasswordHash() (string, error) {
+	raw, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(raw), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.New(message.MsgFailedToHashPassword)
+	}
+	return string(hash), nil
+}
+
+/*
+Fungsi untuk membaca claim boolean yang bisa berupa bool atau string.
+Nilai true dikembalikan untuk true atau "true".
+*/
+func isTrueClaim(v any) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		return strings.EqualFold(b, "true")
+	}
+	return false
+}
+
+/*
+Fungsi untuk membuat instance baru dari OIDCProvider.
+Instance penyedia dengan konfigurasi dari environment dikembalikan.
+*/
+func NewOIDCProvider(repo OIDCRepository) OIDCProvider {
+	return &oidcProvider{
+		cfg:    config.GetOIDCConfig(),
+		repo:   repo,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
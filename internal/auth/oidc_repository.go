@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori OpenID Connect.
+Struktur ini menyediakan akses database untuk state login dan identitas yang tertaut.
+*/
+type oidcRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk menyimpan state login baru.
+State disimpan sebagai hash bersama code verifier dan nonce.
+*/
+func (r *oidcRepository) CreateOIDCState(state *model.OIDCStateModel) error {
+	query := `
+		INSERT INTO oidc_login_states (
+			state_hash,
+			role,
+			code_verifier,
+			nonce,
+			expires_at
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRow(query, state.StateHash, state.Role, state.CodeVerifier, state.Nonce, state.ExpiresAt).Scan(&state.ID, &state.CreatedAt)
+	if err != nil {
+		log.Printf("CreateOIDCState: error inserting state: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk memakai state login sekali pakai.
+State dihapus dan dikembalikan jika masih berlaku, atau nil jika tidak ditemukan.
+*/
+func (r *oidcRepository) ConsumeOIDCState(stateHash, role string) (*model.OIDCStateModel, error) {
+	var state model.OIDCStateModel
+	query := `
+		DELETE FROM oidc_login_states
+		WHERE state_hash = $1 AND role = $2 AND expires_at > NOW()
+		RETURNING id, state_hash, role, code_verifier, nonce, expires_at, created_at
+	`
+	err := r.db.Get(&state, query, stateHash, role)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("ConsumeOIDCState: error consuming state: %v", err)
+		return nil, err
+	}
+	return &state, nil
+}
+
+/*
+Metode untuk menghapus state login yang kedaluwarsa.
+Jumlah baris yang dihapus dikembalikan.
+*/
+func (r *oidcRepository) DeleteExpiredOIDCStates() (int64, error) {
+	res, err := r.db.Exec(`DELETE FROM oidc_login_states WHERE expires_at < NOW()`)
+	if err != nil {
+		log.Printf("DeleteExpiredOIDCStates: error deleting states: %v", err)
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+/*
+Metode untuk mencari identitas yang tertaut berdasarkan issuer dan subject.
+Model identitas dikembalikan atau nil jika belum tertaut.
+*/
+func (r *oidcRepository) FindOIDCIdentity(issuer, subject, role string) (*model.OIDCIdentityModel, error) {
+	var identity model.OIDCIdentityModel
+	query := `
+		SELECT
+			id,
+			issuer,
+			subject,
+			role,
+			user_id,
+			email,
+			created_at,
+			updated_at
+		FROM oidc_identities
+		WHERE issuer = $1 AND subject = $2 AND role = $3
+	`
+	err := r.db.Get(&identity, query, issuer, subject, role)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindOIDCIdentity: error querying identity: %v", err)
+		return nil, err
+	}
+	return &identity, nil
+}
+
+/*
+Metode untuk mengambil semua identitas OpenID Connect yang tertaut ke akun.
+Daftar identitas dikembalikan, urut dari yang paling lama ditautkan.
+*/
+func (r *oidcRepository) GetOIDCIdentitiesByUser(userID, role string) ([]*model.OIDCIdentityModel, error) {
+	identities := []*model.OIDCIdentityModel{}
+	query := `
+		SELECT
+			id,
+			issuer,
+			subject,
+			role,
+			user_id,
+			email,
+			created_at,
+			updated_at
+		FROM oidc_identities
+		WHERE user_id = $1 AND role = $2
+		ORDER BY created_at
+	`
+	if err := r.db.Select(&identities, query, userID, role); err != nil {
+		log.Printf("GetOIDCIdentitiesByUser: error querying identities for %s: %v", userID, err)
+		return nil, err
+	}
+	return identities, nil
+}
+
+/*
+Metode untuk menautkan identitas ke akun.
+Tautan yang sudah ada untuk issuer dan subject yang sama diperbarui.
+*/
+func (r *oidcRepository) LinkOIDCIdentity(identity *model.OIDCIdentityModel) error {
+	query := `
+		INSERT INTO oidc_identities (
+			issuer,
+			subject,
+			role,
+			user_id,
+			email
+		) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (issuer, subject, role) DO UPDATE
+		SET user_id = EXCLUDED.user_id, email = EXCLUDED.email
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRow(query, identity.Issuer, identity.Subject, identity.Role, identity.UserID, identity.Email).Scan(&identity.ID, &identity.CreatedAt, &identity.UpdatedAt)
+	if err != nil {
+		log.Printf("LinkOIDCIdentity: error linking identity for user %s: %v", identity.UserID, err)
+		return err
+	}
+	log.Printf("LinkOIDCIdentity: linked %s identity to user %s", identity.Role, identity.UserID)
+	return nil
+}
+
+/*
+Antarmuka untuk repositori OpenID Connect.
+Antarmuka ini mendefinisikan metode untuk state login dan identitas yang tertaut.
+*/
+type OIDCRepository interface {
+	CreateOIDCState(state *model.OIDCStateModel) error
+	ConsumeOIDCState(stateHash, role string) (*model.OIDCStateModel, error)
+	DeleteExpiredOIDCStates() (int64, error)
+	FindOIDCIdentity(issuer, subject, role string) (*model.OIDCIdentityModel, error)
+	GetOIDCIdentitiesByUser(userID, role string) ([]*model.OIDCIdentityModel, error)
+	LinkOIDCIdentity(identity *model.OIDCIdentityModel) error
+}
+
+/*
+Fungsi untuk membuat instance baru dari OIDCRepository.
+Instance repositori dikembalikan.
+*/
+func NewOIDCRepository(db *sqlx.DB) OIDCRepository {
+	return &oidcRepository{db: db}
+}
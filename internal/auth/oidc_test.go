@@ -0,0 +1,324 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori OpenID Connect di memori.
+Struktur ini menyimpan state login dan identitas tertaut tanpa database.
+*/
+type memoryOIDCRepository struct {
+	mu         sync.Mutex
+	states     map[string]*model.OIDCStateModel
+	identities []*model.OIDCIdentityModel
+}
+
+func (r *memoryOIDCRepository) CreateOIDCState(state *model.OIDCStateModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[state.StateHash] = state
+	return nil
+}
+
+func (r *memoryOIDCRepository) ConsumeOIDCState(stateHash, role string) (*model.OIDCStateModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state, ok := r.states[stateHash]
+	if !ok || state.Role != role || time.Now().After(state.ExpiresAt) {
+		return nil, nil
+	}
+	delete(r.states, stateHash)
+	return state, nil
+}
+
+func (r *memoryOIDCRepository) DeleteExpiredOIDCStates() (int64, error) {
+	return 0, nil
+}
+
+func (r *memoryOIDCRepository) FindOIDCIdentity(issuer, subject, role string) (*model.OIDCIdentityModel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, identity := range r.identities {
+		if identity.Issuer == issuer && identity.Subject == subject && identity.Role == role {
+			return identity, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryOIDCRepository) GetOIDCIdentitiesByUser(userID, role string) ([]*model.OIDCIdentityModel, error) {
+	return nil, nil
+}
+
+func (r *memoryOIDCRepository) LinkOIDCIdentity(identity *model.OIDCIdentityModel) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.identities = append(r.identities, identity)
+	return nil
+}
+
+/*
+Struktur untuk penyedia OpenID Connect tiruan.
+Struktur ini melayani discovery, JWKS, dan token endpoint, lalu menerbitkan ID token sesuai claims yang diatur tiap kasus uji.
+*/
+type stubIssuer struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	kid       string
+	claims    func(nonce string) jwt.MapClaims
+	signer    *rsa.PrivateKey
+	issuer    string
+	challenge string
+	nonces    map[string]string
+	mu        sync.Mutex
+}
+
+/*
+Fungsi untuk menjalankan penyedia OpenID Connect tiruan.
+Penyedia mencatat nonce dari URL otorisasi agar token endpoint dapat menyertakannya di ID token.
+*/
+func newStubIssuer(t *testing.T) *stubIssuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	stub := &stubIssuer{key: key, signer: key, kid: "stub-key", nonces: map[string]string{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		issuer := stub.server.URL
+		if stub.issuer != "" {
+			issuer = stub.issuer
+		}
+		json.NewEncoder(w).Encode(oidcDiscovery{
+			Issuer:                issuer,
+			AuthorizationEndpoint: stub.server.URL + "/authorize",
+			TokenEndpoint:         stub.server.URL + "/token",
+			JWKSURI:               stub.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(JWKSet{Keys: []JWK{{
+			Kty: "RSA",
+			Kid: stub.kid,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(stub.key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(stub.key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		stub.mu.Lock()
+		nonce, ok := stub.nonces[r.PostForm.Get("code")]
+		stub.mu.Unlock()
+		// Code verifier PKCE harus cocok dengan challenge dari URL otorisasi
+		if !ok || r.PostForm.Get("code_verifier") == "" || challengeOf(r.PostForm.Get("code_verifier")) != stub.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(oidcTokenResponse{Error: "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, stub.claims(nonce))
+		token.Header["kid"] = stub.kid
+		signed, err := token.SignedString(stub.signer)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(oidcTokenResponse{IDToken: signed, TokenType: "Bearer"})
+	})
+	stub.server = httptest.NewServer(mux)
+	t.Cleanup(stub.server.Close)
+	return stub
+}
+
+/*
+Metode untuk mensimulasikan persetujuan pengguna di penyedia.
+Nonce dan code challenge dari URL otorisasi dicatat untuk kode otorisasi yang dikembalikan.
+*/
+func (s *stubIssuer) authorize(t *testing.T, authURL string) (state, code string) {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse authorization URL: %v", err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		t.Fatalf("authorization URL lacks PKCE: %s", authURL)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	code = "code-" + q.Get("state")[:8]
+	s.nonces[code] = q.Get("nonce")
+	s.challenge = q.Get("code_challenge")
+	return q.Get("state"), code
+}
+
+/*
+Fungsi untuk menghitung code challenge S256 dari code verifier.
+Challenge base64 URL-safe tanpa padding dikembalikan.
+*/
+func challengeOf(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+/*
+Fungsi untuk membuat penyedia login yang mengarah ke penyedia tiruan.
+Penyedia dengan repositori di memori dikembalikan.
+*/
+func newTestOIDCProvider(issuer string) *oidcProvider {
+	return &oidcProvider{
+		cfg: config.OIDCConfig{
+			Issuer:      issuer,
+			ClientID:    "lalan-test",
+			RedirectURL: "http://localhost/callback",
+			Scopes:      []string{"openid", "email"},
+		},
+		repo:   &memoryOIDCRepository{states: map[string]*model.OIDCStateModel{}},
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func TestOIDCExchangeWithStubIssuer(t *testing.T) {
+	stub := newStubIssuer(t)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	valid := func(nonce string) jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            stub.server.URL,
+			"aud":            "lalan-test",
+			"sub":            "user-123",
+			"exp":            time.Now().Add(5 * time.Minute).Unix(),
+			"iat":            time.Now().Unix(),
+			"nonce":          nonce,
+			"email":          "user@example.com",
+			"email_verified": true,
+			"name":           "Test User",
+		}
+	}
+	with := func(key string, value any) func(string) jwt.MapClaims {
+		return func(nonce string) jwt.MapClaims {
+			claims := valid(nonce)
+			claims[key] = value
+			return claims
+		}
+	}
+
+	tests := []struct {
+		name    string
+		claims  func(string) jwt.MapClaims
+		signer  *rsa.PrivateKey
+		wantErr error
+	}{
+		{name: "valid token", claims: valid},
+		{name: "nonce mismatch", claims: with("nonce", "attacker-nonce"), wantErr: ErrOIDCExchangeFailed},
+		{name: "wrong issuer", claims: with("iss", "https://evil.example.com"), wantErr: ErrOIDCExchangeFailed},
+		{name: "wrong audience", claims: with("aud", "another-client"), wantErr: ErrOIDCExchangeFailed},
+		{name: "expired token", claims: with("exp", time.Now().Add(-time.Hour).Unix()), wantErr: ErrOIDCExchangeFailed},
+		{name: "signed with unknown key", claims: valid, signer: otherKey, wantErr: ErrOIDCExchangeFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub.claims = tt.claims
+			stub.signer = stub.key
+			if tt.signer != nil {
+				stub.signer = tt.signer
+			}
+			provider := newTestOIDCProvider(stub.server.URL)
+
+			authURL, err := provider.AuthorizationURL("customer")
+			if err != nil {
+				t.Fatalf("AuthorizationURL: %v", err)
+			}
+			state, code := stub.authorize(t, authURL)
+
+			identity, err := provider.Exchange("customer", state, code)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Exchange error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exchange: %v", err)
+			}
+			if identity.Issuer != stub.server.URL || identity.Subject != "user-123" || identity.Email != "user@example.com" || !identity.EmailVerified {
+				t.Fatalf("unexpected identity: %+v", identity)
+			}
+		})
+	}
+}
+
+func TestOIDCStateIsSingleUse(t *testing.T) {
+	stub := newStubIssuer(t)
+	stub.claims = func(nonce string) jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   stub.server.URL,
+			"aud":   "lalan-test",
+			"sub":   "user-123",
+			"exp":   time.Now().Add(5 * time.Minute).Unix(),
+			"nonce": nonce,
+		}
+	}
+	provider := newTestOIDCProvider(stub.server.URL)
+
+	authURL, err := provider.AuthorizationURL("hoster")
+	if err != nil {
+		t.Fatalf("AuthorizationURL: %v", err)
+	}
+	state, code := stub.authorize(t, authURL)
+
+	// State milik role lain tidak boleh dipakai
+	if _, err := provider.Exchange("customer", state, code); !errors.Is(err, ErrOIDCStateInvalid) {
+		t.Fatalf("Exchange with other role error = %v, want %v", err, ErrOIDCStateInvalid)
+	}
+
+	authURL, err = provider.AuthorizationURL("hoster")
+	if err != nil {
+		t.Fatalf("AuthorizationURL: %v", err)
+	}
+	state, code = stub.authorize(t, authURL)
+	if _, err := provider.Exchange("hoster", state, code); err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if _, err := provider.Exchange("hoster", state, code); !errors.Is(err, ErrOIDCStateInvalid) {
+		t.Fatalf("replayed Exchange error = %v, want %v", err, ErrOIDCStateInvalid)
+	}
+}
+
+func TestOIDCDiscoveryRejectsIssuerMismatch(t *testing.T) {
+	stub := newStubIssuer(t)
+	// Dokumen discovery mengaku sebagai issuer lain
+	stub.issuer = "https://evil.example.com"
+	provider := newTestOIDCProvider(stub.server.URL)
+
+	if _, err := provider.AuthorizationURL("customer"); !errors.Is(err, ErrOIDCExchangeFailed) {
+		t.Fatalf("AuthorizationURL error = %v, want %v", err, ErrOIDCExchangeFailed)
+	}
+}
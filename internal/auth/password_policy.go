@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"lalan-be/internal/config"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk kebijakan password.
+Batas panjang mengikuti batas input bcrypt, dan prefix rentang mengikuti format k-anonymity SHA-1 5 karakter.
+*/
+const (
+	passwordMaxBytes     = 72
+	breachedPrefixLength = 5
+)
+
+/*
+Struktur untuk error pelanggaran kebijakan password.
+Struktur ini berisi pesan yang aman ditampilkan ke pengguna.
+*/
+type PasswordPolicyError struct {
+	Message string
+}
+
+/*
+Metode untuk mendapatkan pesan pelanggaran kebijakan password.
+Pesan alasan password ditolak dikembalikan.
+*/
+func (e *PasswordPolicyError) Error() string {
+	return e.Message
+}
+
+/*
+Struktur untuk kebijakan password.
+Struktur ini memeriksa panjang, estimasi entropi, data pribadi, dan daftar hash password yang bocor.
+*/
+type passwordPolicy struct {
+	minLength   int
+	minEntropy  float64
+	breachedDir string
+	breached    map[string]map[string]struct{}
+}
+
+/*
+Metode untuk memvalidasi password terhadap kebijakan.
+PasswordPolicyError dikembalikan jika password ditolak, dan data pribadi seperti email atau nama tidak boleh terkandung.
+*/
+func (p *passwordPolicy) Validate(password string, personal ...string) error {
+	if utf8.RuneCountInString(password) < p.minLength {
+		return &PasswordPolicyError{Message: fmt.Sprintf(message.MsgPasswordTooShort, p.minLength)}
+	}
+	if len(password) > passwordMaxBytes {
+		return &PasswordPolicyError{Message: fmt.Sprintf(message.MsgPasswordTooLong, passwordMaxBytes)}
+	}
+	if containsPersonalInfo(password, personal) {
+		return &PasswordPolicyError{Message: message.MsgPasswordPersonal}
+	}
+	if estimateEntropy(password) < p.minEntropy {
+		return &PasswordPolicyError{Message: message.MsgPasswordTooWeak}
+	}
+
+	breached, err := p.isBreached(password)
+	if err != nil {
+		return err
+	}
+	if breached {
+		return &PasswordPolicyError{Message: message.MsgPasswordBreached}
+	}
+	return nil
+}
+
+/*
+Metode untuk memeriksa apakah password ada di daftar password bocor.
+Hanya rentang dengan prefix hash SHA-1 yang sama yang dibaca, sehingga daftar besar tidak perlu dimuat seluruhnya.
+*/
+func (p *passwordPolicy) isBreached(password string) (bool, error) {
+	if p.breached == nil && p.breachedDir == "" {
+		return false, nil
+	}
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:breachedPrefixLength], hash[breachedPrefixLength:]
+
+	if p.breached != nil {
+		_, found := p.breached[prefix][suffix]
+		return found, nil
+	}
+
+	// Direktori berisi satu file per prefix, misalnya ABCDE atau ABCDE.txt, dengan baris SUFFIX:COUNT
+	for _, name := range []string{prefix, prefix + ".txt"} {
+		file, err := os.Open(filepath.Join(p.breachedDir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		defer file.Close()
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if strings.EqualFold(breachedLineHash(scanner.Text()), suffix) {
+				return true, nil
+			}
+		}
+		return false, scanner.Err()
+	}
+	return false, nil
+}
+
+/*
+Antarmuka untuk kebijakan password.
+Antarmuka ini mendefinisikan validasi yang dipakai saat pendaftaran, reset, dan penggantian password.
+*/
+type PasswordPolicy interface {
+	Validate(password string, personal ...string) error
+}
+
+/*
+Fungsi untuk memeriksa apakah password memuat email atau nama pengguna.
+Nilai true dikembalikan jika email, bagian lokal email, atau kata dari nama dengan minimal 3 huruf ditemukan.
+*/
+func containsPersonalInfo(password string, personal []string) bool {
+	lower := strings.ToLower(password)
+	for _, value := range personal {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+		tokens := []string{value}
+		if local, _, found := strings.Cut(value, "@"); found {
+			tokens = append(tokens, local)
+		}
+		tokens = append(tokens, strings.FieldsFunc(value, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+		for _, token := range tokens {
+			if utf8.RuneCountInString(token) >= 3 && strings.Contains(lower, token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+Fungsi untuk memperkirakan entropi password dalam bit.
+Ukuran himpunan karakter dikalikan panjang efektif, dengan karakter berulang atau berurutan hanya dihitung seperempat.
+*/
+func estimateEntropy(password string) float64 {
+	var lower, upper, digit, symbol, other bool
+	var length float64
+	var prev rune = -1
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < utf8.RuneSelf && unicode.IsPrint(r):
+			symbol = true
+		default:
+			other = true
+		}
+		if prev >= 0 && (r == prev || r == prev+1 || r == prev-1) {
+			length += 0.25
+		} else {
+			length++
+		}
+		prev = r
+	}
+
+	pool := 0
+	if lower {
+		pool += 26
+	}
+	if upper {
+		pool += 26
+	}
+	if digit {
+		pool += 10
+	}
+	if symbol {
+		pool += 33
+	}
+	if other {
+		pool += 100
+	}
+	if pool == 0 {
+		return 0
+	}
+	return length * math.Log2(float64(pool))
+}
+
+/*
+Fungsi untuk mengambil bagian hash dari satu baris daftar password bocor.
+Hash tanpa jumlah kemunculan dan spasi dikembalikan.
+*/
+func breachedLineHash(line string) string {
+	hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+	return strings.TrimSpace(hash)
+}
+
+/*
+Fungsi untuk memuat file daftar hash SHA-1 password bocor.
+Hash dikelompokkan berdasarkan prefix 5 karakter seperti rentang k-anonymity.
+*/
+func loadBreachedFile(path string) (map[string]map[string]struct{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	ranges := make(map[string]map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		hash := strings.ToUpper(breachedLineHash(scanner.Text()))
+		if len(hash) != sha1.Size*2 {
+			continue
+		}
+		prefix := hash[:breachedPrefixLength]
+		if ranges[prefix] == nil {
+			ranges[prefix] = make(map[string]struct{})
+		}
+		ranges[prefix][hash[breachedPrefixLength:]] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ranges, nil
+}
+
+/*
+Fungsi untuk membuat instance baru dari PasswordPolicy.
+Kebijakan dari environment dikembalikan, dengan daftar password bocor dimuat dari file atau direktori rentang jika dikonfigurasi.
+*/
+func NewPasswordPolicy() (PasswordPolicy, error) {
+	policy := &passwordPolicy{
+		minLength:  config.GetPasswordMinLength(),
+		minEntropy: config.GetPasswordMinEntropy(),
+	}
+
+	path := config.GetBreachedPasswordListPath()
+	if path == "" {
+		return policy, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("breached password list: %w", err)
+	}
+	if info.IsDir() {
+		policy.breachedDir = path
+		return policy, nil
+	}
+	if policy.breached, err = loadBreachedFile(path); err != nil {
+		return nil, fmt.Errorf("breached password list: %w", err)
+	}
+	return policy, nil
+}
@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"lalan-be/internal/config"
+)
+
+/*
+Konstanta untuk katalog permission.
+Konstanta ini menjadi satu-satunya daftar permission yang boleh dipakai role dan rute.
+*/
+const (
+	PermAll           = "*"
+	PermCategoryWrite = "category:write"
+	PermAdminRead     = "admin:read"
+	PermAdminInvite   = "admin:invite"
+	PermSecurityRead  = "security:read"
+	PermSecurityWrite = "security:write"
+	PermUserRevoke    = "user:revoke-tokens"
+	PermRoleRead      = "role:read"
+	PermRoleWrite     = "role:write"
+	PermProfileRead   = "profile:read"
+	PermProfileWrite  = "profile:write"
+	PermItemRead      = "item:read"
+	PermItemWrite     = "item:write"
+	PermTermsRead     = "terms:read"
+	PermTermsWrite    = "terms:write"
+	PermStaffRead     = "staff:read"
+	PermStaffWrite    = "staff:write"
+	PermAPIKeyRead    = "apikey:read"
+	PermAPIKeyWrite   = "apikey:write"
+	PermImpersonate   = "hoster:impersonate"
+	PermAuditRead     = "audit:read"
+	PermBookingRead   = "booking:read"
+	PermBookingWrite  = "booking:write"
+	PermDepositRead   = "deposit:read"
+	PermDepositWrite  = "deposit:write"
+)
+
+/*
+Variabel untuk daftar permission yang dikenal.
+Variabel ini digunakan untuk validasi saat admin membuat atau mengubah role.
+*/
+var KnownPermissions = []string{
+	PermCategoryWrite,
+	PermAdminRead,
+	PermAdminInvite,
+	PermSecurityRead,
+	PermSecurityWrite,
+	PermUserRevoke,
+	PermRoleRead,
+	PermRoleWrite,
+	PermProfileRead,
+	PermProfileWrite,
+	PermItemRead,
+	PermItemWrite,
+	PermTermsRead,
+	PermTermsWrite,
+	PermStaffRead,
+	PermStaffWrite,
+	PermAPIKeyRead,
+	PermAPIKeyWrite,
+	PermImpersonate,
+	PermAuditRead,
+	PermBookingRead,
+	PermBookingWrite,
+	PermDepositRead,
+	PermDepositWrite,
+}
+
+/*
+Type untuk kumpulan permission pengguna.
+Type ini mendukung wildcard penuh "*" dan wildcard resource seperti "category:*".
+*/
+type PermissionSet map[string]struct{}
+
+/*
+Metode untuk memeriksa apakah kumpulan permission memuat permission tertentu.
+Nilai true dikembalikan jika permission, wildcard resource, atau wildcard penuh dimiliki.
+*/
+func (p PermissionSet) Has(permission string) bool {
+	if _, ok := p[PermAll]; ok {
+		return true
+	}
+	if _, ok := p[permission]; ok {
+		return true
+	}
+	if resource, _, found := strings.Cut(permission, ":"); found {
+		if _, ok := p[resource+":*"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Metode untuk mengubah kumpulan permission menjadi slice.
+Daftar permission dikembalikan.
+*/
+func (p PermissionSet) List() []string {
+	list := make([]string, 0, len(p))
+	for permission := range p {
+		list = append(list, permission)
+	}
+	return list
+}
+
+/*
+Fungsi untuk memeriksa apakah permission dikenal.
+Nilai true dikembalikan untuk permission di katalog, wildcard penuh, atau wildcard resource yang valid.
+*/
+func IsKnownPermission(permission string) bool {
+	if permission == PermAll {
+		return true
+	}
+	for _, known := range KnownPermissions {
+		if permission == known {
+			return true
+		}
+		if resource, _, _ := strings.Cut(known, ":"); permission == resource+":*" {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Struktur untuk entri cache permission pengguna.
+Struktur ini menyimpan kumpulan permission dan batas waktu cache.
+*/
+type permissionCacheEntry struct {
+	permissions PermissionSet
+	expires     time.Time
+}
+
+/*
+Struktur untuk penyimpanan permission.
+Struktur ini menggabungkan database dengan cache memori agar pemeriksaan permission tidak selalu mengakses database.
+*/
+type permissionStore struct {
+	repo    PermissionRepository
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[string]permissionCacheEntry
+}
+
+/*
+Metode untuk mengambil permission pengguna.
+Kumpulan permission dari role eksplisit atau role bawaan jenis akun dikembalikan.
+*/
+func (s *permissionStore) Permissions(userID, userType string) (PermissionSet, error) {
+	key := userKey(userID, userType)
+	now := time.Now()
+	s.mu.RLock()
+	entry, ok := s.entries[key]
+	s.mu.RUnlock()
+	if ok && now.Before(entry.expires) {
+		return entry.permissions, nil
+	}
+
+	list, err := s.repo.FindPermissionsForUser(userID, userType)
+	if err != nil {
+		return nil, err
+	}
+	permissions := make(PermissionSet, len(list))
+	for _, permission := range list {
+		permissions[permission] = struct{}{}
+	}
+
+	s.mu.Lock()
+	s.entries[key] = permissionCacheEntry{permissions: permissions, expires: now.Add(s.ttl)}
+	s.mu.Unlock()
+	return permissions, nil
+}
+
+/*
+Metode untuk menghapus cache permission satu pengguna.
+Permission pengguna dibaca ulang dari database pada pemeriksaan berikutnya.
+*/
+func (s *permissionStore) Invalidate(userID, userType string) {
+	s.mu.Lock()
+	delete(s.entries, userKey(userID, userType))
+	s.mu.Unlock()
+}
+
+/*
+Metode untuk menghapus seluruh cache permission.
+Dipakai saat definisi role berubah sehingga banyak pengguna terdampak.
+*/
+func (s *permissionStore) InvalidateAll() {
+	s.mu.Lock()
+	s.entries = make(map[string]permissionCacheEntry)
+	s.mu.Unlock()
+}
+
+/*
+Antarmuka untuk penyimpanan permission.
+Antarmuka ini mendefinisikan metode yang digunakan middleware dan layanan pengelolaan role.
+*/
+type PermissionStore interface {
+	Permissions(userID, userType string) (PermissionSet, error)
+	Invalidate(userID, userType string)
+	InvalidateAll()
+}
+
+/*
+Fungsi untuk membuat instance baru dari PermissionStore.
+Instance penyimpanan dengan cache memori dikembalikan.
+*/
+func NewPermissionStore(repo PermissionRepository) PermissionStore {
+	return &permissionStore{
+		repo:    repo,
+		ttl:     config.GetPermissionCacheTTL(),
+		entries: make(map[string]permissionCacheEntry),
+	}
+}
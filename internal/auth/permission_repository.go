@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+)
+
+/*
+Struktur untuk repositori permission.
+Struktur ini menyediakan akses database untuk menghitung permission pengguna.
+*/
+type permissionRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk mengambil permission pengguna dari role yang dimilikinya.
+Pengguna tanpa role eksplisit memakai role bawaan yang namanya sama dengan jenis akun.
+*/
+func (r *permissionRepository) FindPermissionsForUser(userID, userType string) ([]string, error) {
+	var permissions []string
+	query := `
+		WITH assigned AS (
+			SELECT role_id
+			FROM user_roles
+			WHERE user_id = $1 AND user_type = $2
+		)
+		SELECT DISTINCT rp.permission
+		FROM role_permissions rp
+		WHERE rp.role_id IN (SELECT role_id FROM assigned)
+			OR (
+				NOT EXISTS (SELECT 1 FROM assigned)
+				AND rp.role_id = (SELECT id FROM roles WHERE name = $2 AND user_type = $2)
+			)
+	`
+	if err := r.db.Select(&permissions, query, userID, userType); err != nil {
+		log.Printf("FindPermissionsForUser: error querying %s %s: %v", userType, userID, err)
+		return nil, err
+	}
+	return permissions, nil
+}
+
+/*
+Antarmuka untuk repositori permission.
+Antarmuka ini mendefinisikan metode pembacaan permission pengguna.
+*/
+type PermissionRepository interface {
+	FindPermissionsForUser(userID, userType string) ([]string, error)
+}
+
+/*
+Fungsi untuk membuat instance baru dari PermissionRepository.
+Instance repositori dikembalikan.
+*/
+func NewPermissionRepository(db *sqlx.DB) PermissionRepository {
+	return &permissionRepository{db: db}
+}
@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori refresh token.
+Struktur ini menyediakan akses ke operasi database untuk refresh token.
+*/
+type refreshTokenRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk menyimpan refresh token baru di database.
+ID dan timestamp token dikembalikan setelah penyisipan.
+*/
+func (r *refreshTokenRepository) CreateRefreshToken(token *model.RefreshTokenModel) error {
+	query := `
+		INSERT INTO refresh_tokens (
+			user_id,
+			role,
+			token_hash,
+			family_id,
+			expires_at
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRow(query, token.UserID, token.Role, token.TokenHash, token.FamilyID, token.ExpiresAt).Scan(&token.ID, &token.CreatedAt, &token.UpdatedAt)
+	if err != nil {
+		log.Printf("CreateRefreshToken: error inserting token for user %s: %v", token.UserID, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk mencari refresh token berdasarkan hash.
+Model refresh token dikembalikan jika ditemukan.
+*/
+func (r *refreshTokenRepository) FindRefreshTokenByHash(hash string) (*model.RefreshTokenModel, error) {
+	var token model.RefreshTokenModel
+	query := `
+		SELECT
+			id,
+			user_id,
+			role,
+			token_hash,
+			family_id,
+			expires_at,
+			rotated_at,
+			revoked_at,
+			replaced_by,
+			created_at,
+			updated_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+	err := r.db.Get(&token, query, hash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Printf("FindRefreshTokenByHash: error querying token: %v", err)
+		return nil, err
+	}
+	return &token, nil
+}
+
+/*
+Metode untuk merotasi refresh token secara atomik.
+Nilai false dikembalikan jika token lama sudah dirotasi atau dicabut sebelumnya.
+*/
+func (r *refreshTokenRepository) RotateRefreshToken(oldID string, next *model.RefreshTokenModel) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	insert := `
+		INSERT INTO refresh_tokens (
+			user_id,
+			role,
+			token_hash,
+			family_id,
+			expires_at
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	err = tx.QueryRow(insert, next.UserID, next.Role, next.TokenHash, next.FamilyID, next.ExpiresAt).Scan(&next.ID, &next.CreatedAt, &next.UpdatedAt)
+	if err != nil {
+		log.Printf("RotateRefreshToken: error inserting token: %v", err)
+		return false, err
+	}
+
+	update := `
+		UPDATE refresh_tokens
+		SET
+			rotated_at = NOW(),
+			replaced_by = $1
+		WHERE id = $2 AND rotated_at IS NULL AND revoked_at IS NULL
+	`
+	res, err := tx.Exec(update, next.ID, oldID)
+	if err != nil {
+		log.Printf("RotateRefreshToken: error marking token %s rotated: %v", oldID, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		log.Printf("RotateRefreshToken: token %s already rotated or revoked", oldID)
+		return false, nil
+	}
+
+	// Sesi diperpanjang mengikuti refresh token terbaru
+	session := `
+		UPDATE sessions
+		SET
+			last_seen_at = NOW(),
+			expires_at = $1
+		WHERE id = $2 AND revoked_at IS NULL
+	`
+	if _, err := tx.Exec(session, next.ExpiresAt, next.FamilyID); err != nil {
+		log.Printf("RotateRefreshToken: error extending session %s: %v", next.FamilyID, err)
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+/*
+Metode untuk mencabut satu refresh token.
+Token ditandai dicabut berdasarkan ID.
+*/
+func (r *refreshTokenRepository) RevokeRefreshToken(id string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		log.Printf("RevokeRefreshToken: error revoking token %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk mencabut seluruh keluarga refresh token.
+Semua token dalam keluarga ditandai dicabut dan sesi dengan ID yang sama diakhiri.
+*/
+func (r *refreshTokenRepository) RevokeRefreshTokenFamily(familyID string) error {
+	query := `
+		WITH revoked AS (
+			UPDATE refresh_tokens
+			SET revoked_at = NOW()
+			WHERE family_id = $1 AND revoked_at IS NULL
+		)
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(query, familyID)
+	if err != nil {
+		log.Printf("RevokeRefreshTokenFamily: error revoking family %s: %v", familyID, err)
+		return err
+	}
+	log.Printf("RevokeRefreshTokenFamily: revoked family %s", familyID)
+	return nil
+}
+
+/*
+Metode untuk mencabut semua refresh token milik pengguna.
+Semua token aktif pengguna ditandai dicabut dan semua sesinya diakhiri.
+*/
+func (r *refreshTokenRepository) RevokeRefreshTokensByUser(userID, role string) error {
+	query := `
+		WITH revoked AS (
+			UPDATE refresh_tokens
+			SET revoked_at = NOW()
+			WHERE user_id = $1 AND role = $2 AND revoked_at IS NULL
+		)
+		UPDATE sessions
+		SET revoked_at = NOW()
+		WHERE user_id = $1 AND role = $2 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(query, userID, role)
+	if err != nil {
+		log.Printf("RevokeRefreshTokensByUser: error revoking tokens for user %s: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Antarmuka untuk repositori refresh token.
+Antarmuka ini mendefinisikan metode untuk penyimpanan dan pencabutan refresh token.
+*/
+type RefreshTokenRepository interface {
+	CreateRefreshToken(token *model.RefreshTokenModel) error
+	FindRefreshTokenByHash(hash string) (*model.RefreshTokenModel, error)
+	RotateRefreshToken(oldID string, next *model.RefreshTokenModel) (bool, error)
+	RevokeRefreshToken(id string) error
+	RevokeRefreshTokenFamily(familyID string) error
+	RevokeRefreshTokensByUser(userID, role string) error
+}
+
+/*
+Fungsi untuk membuat instance baru dari RefreshTokenRepository.
+Instance repositori dikembalikan.
+*/
+func NewRefreshTokenRepository(db *sqlx.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Variabel untuk error refresh token.
+Variabel ini digunakan handler untuk membedakan penyebab kegagalan refresh.
+*/
+var (
+	ErrRefreshTokenInvalid = errors.New(message.MsgRefreshTokenInvalid)
+	ErrRefreshTokenExpired = errors.New(message.MsgRefreshTokenExpired)
+	ErrRefreshTokenReused  = errors.New(message.MsgRefreshTokenReused)
+)
+
+/*
+Struktur untuk layanan refresh token.
+Struktur ini menyediakan logika penerbitan, rotasi, dan pencabutan refresh token.
+*/
+type refreshTokenService struct {
+	repo     RefreshTokenRepository
+	sessions SessionRepository
+}
+
+/*
+Metode untuk menerbitkan refresh token baru dalam sesi baru.
+Token mentah dan ID sesi dikembalikan untuk diberikan ke klien.
+*/
+func (s *refreshTokenService) Issue(userID, role string, client ClientInfo) (string, string, error) {
+	raw, err := GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	expiresAt := time.Now().Add(config.GetRefreshTokenTTL())
+
+	// Satu login menjadi satu sesi yang ID-nya dipakai sebagai keluarga refresh token
+	session := &model.SessionModel{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Role:      role,
+		UserAgent: client.UserAgent,
+		IPAddress: client.IP,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.sessions.CreateSession(session); err != nil {
+		return "", "", err
+	}
+
+	token := &model.RefreshTokenModel{
+		UserID:    userID,
+		Role:      role,
+		TokenHash: HashToken(raw),
+		FamilyID:  session.ID,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.repo.CreateRefreshToken(token); err != nil {
+		return "", "", err
+	}
+	return raw, session.ID, nil
+}
+
+/*
+Metode untuk merotasi refresh token.
+Token baru dan pemiliknya dikembalikan, atau seluruh keluarga dicabut jika token dipakai ulang.
+*/
+func (s *refreshTokenService) Rotate(raw, role string) (*model.RefreshTokenModel, string, error) {
+	current, err := s.lookup(raw, role)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextRaw, err := GenerateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+	next := &model.RefreshTokenModel{
+		UserID:    current.UserID,
+		Role:      current.Role,
+		TokenHash: HashToken(nextRaw),
+		FamilyID:  current.FamilyID,
+		ExpiresAt: time.Now().Add(config.GetRefreshTokenTTL()),
+	}
+	ok, err := s.repo.RotateRefreshToken(current.ID, next)
+	if err != nil {
+		return nil, "", err
+	}
+	if !ok {
+		// Rotasi paralel dengan token yang sama dianggap pemakaian ulang
+		s.revokeFamily(current.FamilyID)
+		return nil, "", ErrRefreshTokenReused
+	}
+
+	return next, nextRaw, nil
+}
+
+/*
+Metode untuk mencabut refresh token saat logout.
+Token dicabut jika valid dan milik role yang sesuai.
+*/
+func (s *refreshTokenService) Revoke(raw, role string) (*model.RefreshTokenModel, error) {
+	current, err := s.lookup(raw, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.RevokeRefreshTokenFamily(current.FamilyID); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+/*
+Metode untuk mencabut semua refresh token milik pengguna.
+Semua sesi refresh pengguna diakhiri.
+*/
+func (s *refreshTokenService) RevokeAll(userID, role string) error {
+	return s.repo.RevokeRefreshTokensByUser(userID, role)
+}
+
+/*
+Metode untuk mencari dan memvalidasi refresh token mentah.
+Model token dikembalikan jika masih aktif, dan keluarga dicabut jika token sudah dirotasi.
+*/
+func (s *refreshTokenService) lookup(raw, role string) (*model.RefreshTokenModel, error) {
+	if raw == "" {
+		return nil, ErrRefreshTokenInvalid
+	}
+	current, err := s.repo.FindRefreshTokenByHash(HashToken(raw))
+	if err != nil {
+		return nil, err
+	}
+	if current == nil || current.Role != role || current.RevokedAt != nil {
+		return nil, ErrRefreshTokenInvalid
+	}
+	if current.RotatedAt != nil {
+		log.Printf("RefreshToken: reuse detected for family %s, user %s", current.FamilyID, current.UserID)
+		s.revokeFamily(current.FamilyID)
+		return nil, ErrRefreshTokenReused
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, ErrRefreshTokenExpired
+	}
+	return current, nil
+}
+
+/*
+Metode untuk mencabut keluarga token tanpa menghentikan alur.
+Kegagalan pencabutan hanya dicatat ke log.
+*/
+func (s *refreshTokenService) revokeFamily(familyID string) {
+	if err := s.repo.RevokeRefreshTokenFamily(familyID); err != nil {
+		log.Printf("RefreshToken: failed to revoke family %s: %v", familyID, err)
+	}
+}
+
+/*
+Antarmuka untuk layanan refresh token.
+Antarmuka ini mendefinisikan metode penerbitan, rotasi, dan pencabutan refresh token.
+*/
+type RefreshTokenService interface {
+	Issue(userID, role string, client ClientInfo) (string, string, error)
+	Rotate(raw, role string) (*model.RefreshTokenModel, string, error)
+	Revoke(raw, role string) (*model.RefreshTokenModel, error)
+	RevokeAll(userID, role string) error
+}
+
+/*
+Fungsi untuk membuat instance baru dari RefreshTokenService.
+Instance layanan dikembalikan.
+*/
+func NewRefreshTokenService(repo RefreshTokenRepository, sessions SessionRepository) RefreshTokenService {
+	return &refreshTokenService{repo: repo, sessions: sessions}
+}
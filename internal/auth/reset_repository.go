@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori token reset password.
+Struktur ini menyediakan akses ke operasi database untuk token reset password.
+*/
+type passwordResetRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk menyimpan token reset baru.
+Token lama milik pengguna yang belum dipakai dibatalkan terlebih dahulu.
+*/
+func (r *passwordResetRepository) CreateResetToken(token *model.PasswordResetTokenModel) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	invalidate := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND role = $2 AND used_at IS NULL
+	`
+	if _, err := tx.Exec(invalidate, token.UserID, token.Role); err != nil {
+		log.Printf("CreateResetToken: error invalidating old tokens for user %s: %v", token.UserID, err)
+		return err
+	}
+
+	insert := `
+		INSERT INTO password_reset_tokens (
+			user_id,
+			role,
+			token_hash,
+			expires_at
+		) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	if err := tx.QueryRow(insert, token.UserID, token.Role, token.TokenHash, token.ExpiresAt).Scan(&token.ID, &token.CreatedAt); err != nil {
+		log.Printf("CreateResetToken: error inserting token for user %s: %v", token.UserID, err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+Metode untuk memakai token reset secara atomik.
+User ID dikembalikan jika token valid, belum dipakai, dan belum kedaluwarsa.
+*/
+func (r *passwordResetRepository) ConsumeResetToken(hash, role string) (string, error) {
+	var userID string
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE token_hash = $1 AND role = $2 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id
+	`
+	err := r.db.Get(&userID, query, hash, role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		log.Printf("ConsumeResetToken: error consuming token: %v", err)
+		return "", err
+	}
+	return userID, nil
+}
+
+/*
+Metode untuk mencari pemilik token reset tanpa memakainya.
+User ID dikembalikan jika token valid, atau string kosong jika tidak.
+*/
+func (r *passwordResetRepository) FindResetTokenUser(hash, role string) (string, error) {
+	var userID string
+	query := `
+		SELECT user_id
+		FROM password_reset_tokens
+		WHERE token_hash = $1 AND role = $2 AND used_at IS NULL AND expires_at > NOW()
+	`
+	err := r.db.Get(&userID, query, hash, role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		log.Printf("FindResetTokenUser: error querying token: %v", err)
+		return "", err
+	}
+	return userID, nil
+}
+
+/*
+Antarmuka untuk repositori token reset password.
+Antarmuka ini mendefinisikan metode penyimpanan dan pemakaian token reset.
+*/
+type PasswordResetRepository interface {
+	CreateResetToken(token *model.PasswordResetTokenModel) error
+	ConsumeResetToken(hash, role string) (string, error)
+	FindResetTokenUser(hash, role string) (string, error)
+}
+
+/*
+Fungsi untuk membuat instance baru dari PasswordResetRepository.
+Instance repositori dikembalikan.
+*/
+func NewPasswordResetRepository(db *sqlx.DB) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk driver database yang mencatat query.
+Struktur ini mengembalikan hasil kosong sehingga teks query repositori dapat diperiksa tanpa database.
+*/
+type recordingDriver struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+func (d *recordingDriver) Open(name string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+func (d *recordingDriver) Connect(ctx context.Context) (driver.Conn, error) {
+	return d.Open("")
+}
+
+func (d *recordingDriver) Driver() driver.Driver {
+	return d
+}
+
+/*
+Struktur untuk koneksi driver yang mencatat query.
+Setiap query yang disiapkan dicatat dengan spasi dirapikan.
+*/
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	c.driver.mu.Lock()
+	defer c.driver.mu.Unlock()
+	c.driver.queries = append(c.driver.queries, strings.Join(strings.Fields(query), " "))
+	return recordingStmt{}, nil
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return recordingTx{}, nil
+}
+
+/*
+Struktur untuk statement dan transaksi driver pencatat.
+Eksekusi tidak mengubah baris dan query tidak mengembalikan baris.
+*/
+type recordingStmt struct{}
+
+func (recordingStmt) Close() error {
+	return nil
+}
+
+func (recordingStmt) NumInput() int {
+	return -1
+}
+
+func (recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func (recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return emptyRows{}, nil
+}
+
+type recordingTx struct{}
+
+func (recordingTx) Commit() error {
+	return nil
+}
+
+func (recordingTx) Rollback() error {
+	return nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string {
+	return []string{"user_id"}
+}
+
+func (emptyRows) Close() error {
+	return nil
+}
+
+func (emptyRows) Next(dest []driver.Value) error {
+	return io.EOF
+}
+
+func TestPasswordResetRepositoryOnlyMatchesUnusedUnexpiredTokens(t *testing.T) {
+	recorder := &recordingDriver{}
+	repo := NewPasswordResetRepository(sqlx.NewDb(sql.OpenDB(recorder), "postgres"))
+
+	if userID, err := repo.ConsumeResetToken("hash", "customer"); err != nil || userID != "" {
+		t.Fatalf("ConsumeResetToken = %q, %v; want empty", userID, err)
+	}
+	if userID, err := repo.FindResetTokenUser("hash", "customer"); err != nil || userID != "" {
+		t.Fatalf("FindResetTokenUser = %q, %v; want empty", userID, err)
+	}
+
+	want := []string{
+		"UPDATE password_reset_tokens SET used_at = NOW() WHERE token_hash = $1 AND role = $2 AND used_at IS NULL AND expires_at > NOW() RETURNING user_id",
+		"SELECT user_id FROM password_reset_tokens WHERE token_hash = $1 AND role = $2 AND used_at IS NULL AND expires_at > NOW()",
+	}
+	if len(recorder.queries) != len(want) {
+		t.Fatalf("queries = %q, want %q", recorder.queries, want)
+	}
+	for i := range want {
+		if recorder.queries[i] != want[i] {
+			t.Errorf("query %d = %q, want %q", i, recorder.queries[i], want[i])
+		}
+	}
+}
+
+func TestPasswordResetRepositoryAgainstDatabase(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer db.Close()
+	// Tabel sementara hanya terlihat di satu koneksi
+	db.SetMaxOpenConns(1)
+	ddl, err := os.ReadFile("../../migrations/ddl_password_reset.sql")
+	if err != nil {
+		t.Fatalf("read migration: %v", err)
+	}
+	if _, err := db.Exec(strings.ReplaceAll(string(ddl), "CREATE TABLE", "CREATE TEMP TABLE")); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	repo := NewPasswordResetRepository(db)
+	userID := "00000000-0000-0000-0000-000000000001"
+	create := func(hash string, expiresAt time.Time) {
+		t.Helper()
+		token := &model.PasswordResetTokenModel{UserID: userID, Role: "customer", TokenHash: hash, ExpiresAt: expiresAt}
+		if err := repo.CreateResetToken(token); err != nil {
+			t.Fatalf("CreateResetToken: %v", err)
+		}
+	}
+
+	create("old", time.Now().Add(time.Hour))
+	create("current", time.Now().Add(time.Hour))
+	if got, err := repo.FindResetTokenUser("old", "customer"); err != nil || got != "" {
+		t.Fatalf("FindResetTokenUser old token = %q, %v; want empty", got, err)
+	}
+	if got, err := repo.FindResetTokenUser("current", "hoster"); err != nil || got != "" {
+		t.Fatalf("FindResetTokenUser other role = %q, %v; want empty", got, err)
+	}
+	if got, err := repo.FindResetTokenUser("current", "customer"); err != nil || got != userID {
+		t.Fatalf("FindResetTokenUser = %q, %v; want %s", got, err, userID)
+	}
+	if got, err := repo.ConsumeResetToken("current", "customer"); err != nil || got != userID {
+		t.Fatalf("ConsumeResetToken = %q, %v; want %s", got, err, userID)
+	}
+	if got, err := repo.ConsumeResetToken("current", "customer"); err != nil || got != "" {
+		t.Fatalf("second ConsumeResetToken = %q, %v; want empty", got, err)
+	}
+
+	create("expired", time.Now().Add(-time.Minute))
+	if got, err := repo.FindResetTokenUser("expired", "customer"); err != nil || got != "" {
+		t.Fatalf("FindResetTokenUser expired token = %q, %v; want empty", got, err)
+	}
+	if got, err := repo.ConsumeResetToken("expired", "customer"); err != nil || got != "" {
+		t.Fatalf("ConsumeResetToken expired token = %q, %v; want empty", got, err)
+	}
+}
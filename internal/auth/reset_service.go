@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/mailer"
+	"lalan-be/pkg/message"
+)
+
+/*
+Variabel untuk error reset password.
+Variabel ini menandai token reset yang tidak valid, sudah dipakai, atau kedaluwarsa.
+*/
+var ErrResetTokenInvalid = errors.New(message.MsgPasswordResetTokenInvalid)
+
+/*
+Struktur untuk layanan reset password.
+Struktur ini menerbitkan token reset, mengirim email, dan memvalidasi token saat dipakai.
+*/
+type passwordResetService struct {
+	repo   PasswordResetRepository
+	mailer mailer.Mailer
+}
+
+/*
+Metode untuk menerbitkan token reset dan mengirim tautan reset ke email pengguna.
+Email berisi tautan sekali pakai dikirim jika berhasil.
+*/
+func (s *passwordResetService) SendResetLink(userID, role, email, name string) error {
+	raw, err := GenerateOpaqueToken()
+	if err != nil {
+		return err
+	}
+	ttl := config.GetPasswordResetTTL()
+	token := &model.PasswordResetTokenModel{
+		UserID:    userID,
+		Role:      role,
+		TokenHash: HashToken(raw),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.repo.CreateResetToken(token); err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/reset-password?role=%s&token=%s", config.GetFrontendURL(), url.QueryEscape(role), url.QueryEscape(raw))
+	body := fmt.Sprintf("Hi %s,\n\nWe received a request to reset your Lalan password.\nOpen the link below within %d minutes to choose a new password:\n\n%s\n\nIf you did not request this, you can ignore this email.\n",
+		name, int(ttl.Minutes()), link)
+	return s.mailer.Send(mailer.Message{
+		To:      email,
+		Subject: "Reset your Lalan password",
+		Body:    body,
+	})
+}
+
+/*
+Metode untuk memakai token reset.
+User ID pemilik token dikembalikan jika token valid.
+*/
+func (s *passwordResetService) Consume(raw, role string) (string, error) {
+	if raw == "" {
+		return "", ErrResetTokenInvalid
+	}
+	userID, err := s.repo.ConsumeResetToken(HashToken(raw), role)
+	if err != nil {
+		return "", err
+	}
+	if userID == "" {
+		return "", ErrResetTokenInvalid
+	}
+	return userID, nil
+}
+
+/*
+Metode untuk memeriksa token reset tanpa memakainya.
+User ID pemilik token dikembalikan agar password baru dapat divalidasi sebelum token dipakai.
+*/
+func (s *passwordResetService) Peek(raw, role string) (string, error) {
+	if raw == "" {
+		return "", ErrResetTokenInvalid
+	}
+	userID, err := s.repo.FindResetTokenUser(HashToken(raw), role)
+	if err != nil {
+		return "", err
+	}
+	if userID == "" {
+		return "", ErrResetTokenInvalid
+	}
+	return userID, nil
+}
+
+/*
+Antarmuka untuk layanan reset password.
+Antarmuka ini mendefinisikan metode penerbitan dan pemakaian token reset.
+*/
+type PasswordResetService interface {
+	SendResetLink(userID, role, email, name string) error
+	Consume(raw, role string) (string, error)
+	Peek(raw, role string) (string, error)
+}
+
+/*
+Fungsi untuk membuat instance baru dari PasswordResetService.
+Instance layanan dikembalikan.
+*/
+func NewPasswordResetService(repo PasswordResetRepository, m mailer.Mailer) PasswordResetService {
+	return &passwordResetService{repo: repo, mailer: m}
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"lalan-be/internal/auth/authtest"
+	"lalan-be/pkg/mailer"
+)
+
+/*
+Fungsi untuk membuat layanan reset password dengan mailer file.
+Layanan, repositori di memori, dan path file email dikembalikan.
+*/
+func newTestResetService(t *testing.T) (PasswordResetService, *authtest.ResetRepository, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mail.log")
+	repo := authtest.NewResetRepository()
+	return NewPasswordResetService(repo, mailer.NewFileMailer(path)), repo, path
+}
+
+func TestPasswordResetTokenIsSingleUse(t *testing.T) {
+	service, _, path := newTestResetService(t)
+
+	if err := service.SendResetLink("user-1", "customer", "user@example.com", "Test User"); err != nil {
+		t.Fatalf("SendResetLink: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read mail file: %v", err)
+	}
+	if !strings.Contains(string(content), "To: user@example.com") || !strings.Contains(string(content), "Subject: Reset your Lalan password") {
+		t.Fatalf("unexpected reset email:\n%s", content)
+	}
+	token := authtest.LastResetToken(t, path)
+
+	if userID, err := service.Peek(token, "customer"); err != nil || userID != "user-1" {
+		t.Fatalf("Peek = %q, %v; want user-1", userID, err)
+	}
+	if _, err := service.Consume(token, "hoster"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Fatalf("Consume with other role error = %v, want %v", err, ErrResetTokenInvalid)
+	}
+	if userID, err := service.Consume(token, "customer"); err != nil || userID != "user-1" {
+		t.Fatalf("Consume = %q, %v; want user-1", userID, err)
+	}
+	if _, err := service.Consume(token, "customer"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Fatalf("second Consume error = %v, want %v", err, ErrResetTokenInvalid)
+	}
+	if _, err := service.Peek(token, "customer"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Fatalf("Peek after use error = %v, want %v", err, ErrResetTokenInvalid)
+	}
+}
+
+func TestPasswordResetNewLinkInvalidatesOldToken(t *testing.T) {
+	service, _, path := newTestResetService(t)
+
+	if err := service.SendResetLink("user-1", "customer", "user@example.com", "Test User"); err != nil {
+		t.Fatalf("SendResetLink: %v", err)
+	}
+	first := authtest.LastResetToken(t, path)
+	if err := service.SendResetLink("user-1", "customer", "user@example.com", "Test User"); err != nil {
+		t.Fatalf("SendResetLink: %v", err)
+	}
+	second := authtest.LastResetToken(t, path)
+	if first == second {
+		t.Fatal("reset links reuse the same token")
+	}
+
+	if _, err := service.Consume(first, "customer"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Fatalf("Consume old token error = %v, want %v", err, ErrResetTokenInvalid)
+	}
+	if _, err := service.Consume(second, "customer"); err != nil {
+		t.Fatalf("Consume new token: %v", err)
+	}
+}
+
+func TestPasswordResetTokenExpires(t *testing.T) {
+	service, repo, path := newTestResetService(t)
+
+	if err := service.SendResetLink("user-1", "hoster", "owner@example.com", "Store Owner"); err != nil {
+		t.Fatalf("SendResetLink: %v", err)
+	}
+	token := authtest.LastResetToken(t, path)
+
+	repo.Elapse(31 * time.Minute)
+	if _, err := service.Peek(token, "hoster"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Fatalf("Peek expired token error = %v, want %v", err, ErrResetTokenInvalid)
+	}
+	if _, err := service.Consume(token, "hoster"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Fatalf("Consume expired token error = %v, want %v", err, ErrResetTokenInvalid)
+	}
+	if _, err := service.Consume("", "hoster"); !errors.Is(err, ErrResetTokenInvalid) {
+		t.Fatalf("Consume empty token error = %v, want %v", err, ErrResetTokenInvalid)
+	}
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+/*
+Struktur untuk repositori pencabutan token.
+Struktur ini menyediakan akses ke operasi database untuk token yang dicabut.
+*/
+type revocationRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk menyimpan jti access token yang dicabut.
+Token dicatat sebagai dicabut hingga waktu kedaluwarsanya.
+*/
+func (r *revocationRepository) RevokeToken(jti, userID, role string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_tokens (
+			jti,
+			user_id,
+			role,
+			expires_at
+		) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (jti) DO NOTHING
+	`
+	_, err := r.db.Exec(query, jti, userID, role, expiresAt)
+	if err != nil {
+		log.Printf("RevokeToken: error revoking jti %s: %v", jti, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk memeriksa apakah jti sudah dicabut.
+Nilai true dikembalikan jika jti ditemukan di daftar pencabutan.
+*/
+func (r *revocationRepository) IsTokenRevoked(jti string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)`
+	if err := r.db.Get(&exists, query, jti); err != nil {
+		log.Printf("IsTokenRevoked: error querying jti %s: %v", jti, err)
+		return false, err
+	}
+	return exists, nil
+}
+
+/*
+Metode untuk menetapkan batas pencabutan token pengguna.
+Semua token pengguna yang terbit sebelum waktu tersebut menjadi tidak berlaku.
+*/
+func (r *revocationRepository) RevokeAllForUser(userID, role string, before time.Time) error {
+	query := `
+		INSERT INTO user_token_revocations (
+			user_id,
+			role,
+			revoked_before,
+			updated_at
+		) VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id, role) DO UPDATE
+		SET
+			revoked_before = EXCLUDED.revoked_before,
+			updated_at = NOW()
+	`
+	_, err := r.db.Exec(query, userID, role, before)
+	if err != nil {
+		log.Printf("RevokeAllForUser: error revoking tokens for user %s: %v", userID, err)
+		return err
+	}
+	log.Printf("RevokeAllForUser: revoked tokens for %s %s issued before %s", role, userID, before.Format(time.RFC3339))
+	return nil
+}
+
+/*
+Metode untuk mengambil batas pencabutan token pengguna.
+Waktu batas dikembalikan atau nil jika pengguna belum pernah dicabut.
+*/
+func (r *revocationRepository) FindRevokedBefore(userID, role string) (*time.Time, error) {
+	var before time.Time
+	query := `
+		SELECT revoked_before
+		FROM user_token_revocations
+		WHERE user_id = $1 AND role = $2
+	`
+	err := r.db.Get(&before, query, userID, role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Printf("FindRevokedBefore: error querying user %s: %v", userID, err)
+		return nil, err
+	}
+	return &before, nil
+}
+
+/*
+Metode untuk menghapus catatan jti yang sudah kedaluwarsa.
+Jumlah baris yang dihapus dikembalikan.
+*/
+func (r *revocationRepository) DeleteExpiredRevokedTokens() (int64, error) {
+	res, err := r.db.Exec(`DELETE FROM revoked_tokens WHERE expires_at < NOW()`)
+	if err != nil {
+		log.Printf("DeleteExpiredRevokedTokens: error: %v", err)
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+/*
+Antarmuka untuk repositori pencabutan token.
+Antarmuka ini mendefinisikan metode penyimpanan daftar pencabutan.
+*/
+type RevocationRepository interface {
+	RevokeToken(jti, userID, role string, expiresAt time.Time) error
+	IsTokenRevoked(jti string) (bool, error)
+	RevokeAllForUser(userID, role string, before time.Time) error
+	FindRevokedBefore(userID, role string) (*time.Time, error)
+	DeleteExpiredRevokedTokens() (int64, error)
+}
+
+/*
+Fungsi untuk membuat instance baru dari RevocationRepository.
+Instance repositori dikembalikan.
+*/
+func NewRevocationRepository(db *sqlx.DB) RevocationRepository {
+	return &revocationRepository{db: db}
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"lalan-be/internal/config"
+)
+
+/*
+Struktur untuk entri cache pencabutan jti.
+Struktur ini menyimpan status pencabutan dan batas waktu cache.
+*/
+type tokenCacheEntry struct {
+	revoked bool
+	expires time.Time
+}
+
+/*
+Struktur untuk entri cache batas pencabutan pengguna.
+Struktur ini menyimpan waktu revoked_before dan batas waktu cache.
+*/
+type userCacheEntry struct {
+	revokedBefore *time.Time
+	expires       time.Time
+}
+
+/*
+Struktur untuk penyimpanan pencabutan token.
+Struktur ini menggabungkan database dengan cache memori agar middleware tidak selalu mengakses database.
+*/
+type revocationStore struct {
+	repo        RevocationRepository
+	refreshRepo RefreshTokenRepository
+	ttl         time.Duration
+	mu          sync.RWMutex
+	tokens      map[string]tokenCacheEntry
+	users       map[string]userCacheEntry
+}
+
+/*
+Metode untuk memeriksa apakah access token sudah dicabut.
+Nilai true dikembalikan jika jti dicabut atau token terbit sebelum batas pencabutan pengguna.
+*/
+func (s *revocationStore) IsRevoked(jti, userID, role string, issuedAt time.Time) (bool, error) {
+	if jti != "" {
+		revoked, err := s.isTokenRevoked(jti)
+		if err != nil {
+			return false, err
+		}
+		if revoked {
+			return true, nil
+		}
+	}
+
+	before, err := s.revokedBefore(userID, role)
+	if err != nil {
+		return false, err
+	}
+	return before != nil && issuedAt.Before(*before), nil
+}
+
+/*
+Metode untuk mencabut satu access token berdasarkan jti.
+Token dicatat di database dan cache lokal langsung diperbarui.
+*/
+func (s *revocationStore) RevokeToken(jti, userID, role string, expiresAt time.Time) error {
+	if jti == "" {
+		return nil
+	}
+	if err := s.repo.RevokeToken(jti, userID, role, expiresAt); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.tokens[jti] = tokenCacheEntry{revoked: true, expires: expiresAt}
+	s.mu.Unlock()
+	return nil
+}
+
+/*
+Metode untuk mencabut semua token milik pengguna.
+Access token yang sudah terbit dan seluruh refresh token pengguna menjadi tidak berlaku.
+*/
+func (s *revocationStore) RevokeAllForUser(userID, role string) error {
+	// iat JWT berpresisi detik, sehingga batas dibulatkan agar token baru di detik yang sama tetap berlaku
+	now := time.Now().Truncate(time.Second)
+	if err := s.repo.RevokeAllForUser(userID, role, now); err != nil {
+		return err
+	}
+	if err := s.refreshRepo.RevokeRefreshTokensByUser(userID, role); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.users[userKey(userID, role)] = userCacheEntry{revokedBefore: &now, expires: now.Add(s.ttl)}
+	s.mu.Unlock()
+	return nil
+}
+
+/*
+Metode untuk memeriksa status jti dengan bantuan cache.
+Status pencabutan jti dikembalikan.
+*/
+func (s *revocationStore) isTokenRevoked(jti string) (bool, error) {
+	now := time.Now()
+	s.mu.RLock()
+	entry, ok := s.tokens[jti]
+	s.mu.RUnlock()
+	if ok && now.Before(entry.expires) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := s.repo.IsTokenRevoked(jti)
+	if err != nil {
+		return false, err
+	}
+	expires := now.Add(s.ttl)
+	if revoked {
+		// Pencabutan bersifat permanen sehingga boleh di-cache lebih lama
+		expires = now.Add(time.Hour)
+	}
+	s.mu.Lock()
+	s.tokens[jti] = tokenCacheEntry{revoked: revoked, expires: expires}
+	s.mu.Unlock()
+	return revoked, nil
+}
+
+/*
+Metode untuk mengambil batas pencabutan pengguna dengan bantuan cache.
+Waktu batas dikembalikan atau nil jika tidak ada.
+*/
+func (s *revocationStore) revokedBefore(userID, role string) (*time.Time, error) {
+	key := userKey(userID, role)
+	now := time.Now()
+	s.mu.RLock()
+	entry, ok := s.users[key]
+	s.mu.RUnlock()
+	if ok && now.Before(entry.expires) {
+		return entry.revokedBefore, nil
+	}
+
+	before, err := s.repo.FindRevokedBefore(userID, role)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.users[key] = userCacheEntry{revokedBefore: before, expires: now.Add(s.ttl)}
+	s.mu.Unlock()
+	return before, nil
+}
+
+/*
+Metode untuk membersihkan entri cache dan database yang sudah kedaluwarsa.
+Entri yang tidak lagi relevan dihapus.
+*/
+func (s *revocationStore) Purge() {
+	now := time.Now()
+	s.mu.Lock()
+	for jti, entry := range s.tokens {
+		if now.After(entry.expires) {
+			delete(s.tokens, jti)
+		}
+	}
+	for key, entry := range s.users {
+		if now.After(entry.expires) {
+			delete(s.users, key)
+		}
+	}
+	s.mu.Unlock()
+
+	if n, err := s.repo.DeleteExpiredRevokedTokens(); err == nil && n > 0 {
+		log.Printf("RevocationStore: purged %d expired revoked tokens", n)
+	}
+}
+
+/*
+Antarmuka untuk penyimpanan pencabutan token.
+Antarmuka ini mendefinisikan metode yang digunakan middleware dan layanan logout.
+*/
+type RevocationStore interface {
+	IsRevoked(jti, userID, role string, issuedAt time.Time) (bool, error)
+	RevokeToken(jti, userID, role string, expiresAt time.Time) error
+	RevokeAllForUser(userID, role string) error
+	Purge()
+}
+
+/*
+Fungsi untuk membentuk kunci cache pengguna.
+Kunci gabungan role dan user ID dikembalikan.
+*/
+func userKey(userID, role string) string {
+	return role + ":" + userID
+}
+
+/*
+Fungsi untuk membuat instance baru dari RevocationStore.
+Instance penyimpanan dengan cache memori dikembalikan.
+*/
+func NewRevocationStore(repo RevocationRepository, refreshRepo RefreshTokenRepository) RevocationStore {
+	return &revocationStore{
+		repo:        repo,
+		refreshRepo: refreshRepo,
+		ttl:         config.GetRevocationCacheTTL(),
+		tokens:      make(map[string]tokenCacheEntry),
+		users:       make(map[string]userCacheEntry),
+	}
+}
@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"github.com/gorilla/mux"
+)
+
+/*
+Fungsi untuk mengatur rute auth bersama.
+Router dikonfigurasi dengan endpoint JWKS.
+*/
+func SetupAuthRoutes(router *mux.Router, h *AuthHandler) {
+	router.HandleFunc("/.well-known/jwks.json", h.GetJWKS).Methods("GET")
+	router.HandleFunc("/api/v1/auth/jwks.json", h.GetJWKS).Methods("GET")
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori sesi login.
+Struktur ini menyediakan akses ke operasi database untuk sesi.
+*/
+type sessionRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk menyimpan sesi baru di database.
+Timestamp sesi dikembalikan setelah penyisipan.
+*/
+func (r *sessionRepository) CreateSession(session *model.SessionModel) error {
+	query := `
+		INSERT INTO sessions (
+			id,
+			user_id,
+			role,
+			user_agent,
+			ip_address,
+			expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING last_seen_at, created_at
+	`
+	err := r.db.QueryRow(query, session.ID, session.UserID, session.Role, session.UserAgent, session.IPAddress, session.ExpiresAt).Scan(&session.LastSeenAt, &session.CreatedAt)
+	if err != nil {
+		log.Printf("CreateSession: error inserting session for user %s: %v", session.UserID, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk mencari sesi berdasarkan ID.
+Model sesi dikembalikan atau nil jika tidak ditemukan.
+*/
+func (r *sessionRepository) FindSessionByID(id string) (*model.SessionModel, error) {
+	var session model.SessionModel
+	query := `
+		SELECT
+			id,
+			user_id,
+			role,
+			COALESCE(user_agent, '') AS user_agent,
+			COALESCE(ip_address, '') AS ip_address,
+			last_seen_at,
+			expires_at,
+			revoked_at,
+			created_at
+		FROM sessions
+		WHERE id = $1
+	`
+	err := r.db.Get(&session, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindSessionByID: error querying session %s: %v", id, err)
+		return nil, err
+	}
+	return &session, nil
+}
+
+/*
+Metode untuk mengambil sesi aktif milik pengguna.
+Daftar sesi yang belum dicabut dan belum kedaluwarsa dikembalikan, terbaru lebih dulu.
+*/
+func (r *sessionRepository) FindActiveSessionsByUser(userID, role string) ([]*model.SessionModel, error) {
+	var sessions []*model.SessionModel
+	query := `
+		SELECT
+			id,
+			user_id,
+			role,
+			COALESCE(user_agent, '') AS user_agent,
+			COALESCE(ip_address, '') AS ip_address,
+			last_seen_at,
+			expires_at,
+			revoked_at,
+			created_at
+		FROM sessions
+		WHERE user_id = $1 AND role = $2 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_seen_at DESC
+	`
+	if err := r.db.Select(&sessions, query, userID, role); err != nil {
+		log.Printf("FindActiveSessionsByUser: error querying sessions for user %s: %v", userID, err)
+		return nil, err
+	}
+	return sessions, nil
+}
+
+/*
+Metode untuk memperbarui waktu aktivitas terakhir sesi.
+Alamat IP dan user agent terbaru ikut disimpan.
+*/
+func (r *sessionRepository) TouchSession(id, ipAddress, userAgent string) error {
+	query := `
+		UPDATE sessions
+		SET
+			last_seen_at = NOW(),
+			ip_address = $2,
+			user_agent = $3
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	if _, err := r.db.Exec(query, id, ipAddress, userAgent); err != nil {
+		log.Printf("TouchSession: error updating session %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk memeriksa apakah sesi masih aktif.
+Nilai true dikembalikan jika sesi ada, belum dicabut, dan belum kedaluwarsa.
+*/
+func (r *sessionRepository) IsSessionActive(id string) (bool, error) {
+	var active bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM sessions
+			WHERE id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		)
+	`
+	if err := r.db.Get(&active, query, id); err != nil {
+		log.Printf("IsSessionActive: error checking session %s: %v", id, err)
+		return false, err
+	}
+	return active, nil
+}
+
+/*
+Metode untuk menghapus sesi yang sudah lama berakhir.
+Jumlah baris yang dihapus dikembalikan.
+*/
+func (r *sessionRepository) DeleteStaleSessions() (int64, error) {
+	query := `
+		DELETE FROM sessions
+		WHERE expires_at < NOW() - INTERVAL '1 day'
+			OR revoked_at < NOW() - INTERVAL '1 day'
+	`
+	res, err := r.db.Exec(query)
+	if err != nil {
+		log.Printf("DeleteStaleSessions: error deleting sessions: %v", err)
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+/*
+Antarmuka untuk repositori sesi login.
+Antarmuka ini mendefinisikan metode untuk penyimpanan, pencarian, dan pembersihan sesi.
+*/
+type SessionRepository interface {
+	CreateSession(session *model.SessionModel) error
+	FindSessionByID(id string) (*model.SessionModel, error)
+	FindActiveSessionsByUser(userID, role string) ([]*model.SessionModel, error)
+	TouchSession(id, ipAddress, userAgent string) error
+	IsSessionActive(id string) (bool, error)
+	DeleteStaleSessions() (int64, error)
+}
+
+/*
+Fungsi untuk membuat instance baru dari SessionRepository.
+Instance repositori dikembalikan.
+*/
+func NewSessionRepository(db *sqlx.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Variabel untuk error sesi yang tidak ditemukan.
+Variabel ini dikembalikan jika sesi tidak ada atau bukan milik pengguna.
+*/
+var ErrSessionNotFound = errors.New(message.MsgSessionNotFound)
+
+/*
+Struktur untuk entri cache status sesi.
+Struktur ini menyimpan status aktif sesi dan batas waktu cache.
+*/
+type sessionCacheEntry struct {
+	active  bool
+	expires time.Time
+}
+
+/*
+Struktur untuk penyimpanan sesi login.
+Struktur ini menggabungkan database dengan cache memori agar middleware tidak selalu mengakses database.
+*/
+type sessionStore struct {
+	repo          SessionRepository
+	refreshRepo   RefreshTokenRepository
+	ttl           time.Duration
+	touchInterval time.Duration
+	mu            sync.RWMutex
+	sessions      map[string]sessionCacheEntry
+	touched       map[string]time.Time
+}
+
+/*
+Metode untuk memeriksa apakah sesi masih aktif.
+Status aktif sesi dikembalikan dengan bantuan cache.
+*/
+func (s *sessionStore) IsActive(sessionID string) (bool, error) {
+	now := time.Now()
+	s.mu.RLock()
+	entry, ok := s.sessions[sessionID]
+	s.mu.RUnlock()
+	if ok && now.Before(entry.expires) {
+		return entry.active, nil
+	}
+
+	active, err := s.repo.IsSessionActive(sessionID)
+	if err != nil {
+		return false, err
+	}
+	expires := now.Add(s.ttl)
+	if !active {
+		// Sesi yang berakhir tidak pernah aktif kembali sehingga boleh di-cache lebih lama
+		expires = now.Add(time.Hour)
+	}
+	s.mu.Lock()
+	s.sessions[sessionID] = sessionCacheEntry{active: active, expires: expires}
+	s.mu.Unlock()
+	return active, nil
+}
+
+/*
+Metode untuk mencatat aktivitas terbaru sebuah sesi.
+Database hanya diperbarui jika jeda sejak pembaruan terakhir sudah terlewati.
+*/
+func (s *sessionStore) Touch(sessionID string, client ClientInfo) {
+	now := time.Now()
+	s.mu.Lock()
+	last, ok := s.touched[sessionID]
+	if ok && now.Sub(last) < s.touchInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.touched[sessionID] = now
+	s.mu.Unlock()
+
+	if err := s.repo.TouchSession(sessionID, client.IP, client.UserAgent); err != nil {
+		log.Printf("SessionStore: failed to touch session %s: %v", sessionID, err)
+	}
+}
+
+/*
+Metode untuk mengambil sesi aktif milik pengguna.
+Sesi yang sedang dipakai permintaan ditandai sebagai current.
+*/
+func (s *sessionStore) List(userID, role, currentSessionID string) ([]*model.SessionModel, error) {
+	sessions, err := s.repo.FindActiveSessionsByUser(userID, role)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		session.Current = session.ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+/*
+Metode untuk mengakhiri satu sesi milik pengguna.
+Keluarga refresh token sesi dicabut dan access token sesi langsung ditolak.
+*/
+func (s *sessionStore) Terminate(userID, role, sessionID string) error {
+	session, err := s.repo.FindSessionByID(sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID || session.Role != role || session.RevokedAt != nil {
+		return ErrSessionNotFound
+	}
+
+	// ID sesi sama dengan ID keluarga refresh token
+	if err := s.refreshRepo.RevokeRefreshTokenFamily(sessionID); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.sessions[sessionID] = sessionCacheEntry{active: false, expires: time.Now().Add(time.Hour)}
+	s.mu.Unlock()
+	return nil
+}
+
+/*
+Metode untuk membersihkan entri cache dan sesi lama di database.
+Entri yang tidak lagi relevan dihapus.
+*/
+func (s *sessionStore) Purge() {
+	now := time.Now()
+	s.mu.Lock()
+	for id, entry := range s.sessions {
+		if now.After(entry.expires) {
+			delete(s.sessions, id)
+		}
+	}
+	for id, last := range s.touched {
+		if now.Sub(last) > s.touchInterval {
+			delete(s.touched, id)
+		}
+	}
+	s.mu.Unlock()
+
+	if n, err := s.repo.DeleteStaleSessions(); err == nil && n > 0 {
+		log.Printf("SessionStore: purged %d stale sessions", n)
+	}
+}
+
+/*
+Antarmuka untuk penyimpanan sesi login.
+Antarmuka ini mendefinisikan metode yang digunakan middleware dan layanan sesi.
+*/
+type SessionStore interface {
+	IsActive(sessionID string) (bool, error)
+	Touch(sessionID string, client ClientInfo)
+	List(userID, role, currentSessionID string) ([]*model.SessionModel, error)
+	Terminate(userID, role, sessionID string) error
+	Purge()
+}
+
+/*
+Fungsi untuk membuat instance baru dari SessionStore.
+Instance penyimpanan dengan cache memori dikembalikan.
+*/
+func NewSessionStore(repo SessionRepository, refreshRepo RefreshTokenRepository) SessionStore {
+	return &sessionStore{
+		repo:          repo,
+		refreshRepo:   refreshRepo,
+		ttl:           config.GetRevocationCacheTTL(),
+		touchInterval: config.GetSessionTouchInterval(),
+		sessions:      make(map[string]sessionCacheEntry),
+		touched:       make(map[string]time.Time),
+	}
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+/*
+Fungsi untuk menghasilkan token acak yang tidak dapat ditebak.
+Token base64 URL-safe sepanjang 32 byte acak dikembalikan.
+*/
+func GenerateOpaqueToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+/*
+Fungsi untuk menghitung hash dari token mentah.
+Hash SHA-256 dalam format hex dikembalikan untuk disimpan di database.
+*/
+func HashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/pkg/mailer"
+	"lalan-be/pkg/message"
+)
+
+/*
+Variabel untuk error verifikasi email.
+Variabel ini menandai tautan verifikasi yang rusak, salah tanda tangan, atau kedaluwarsa.
+*/
+var ErrVerificationTokenInvalid = errors.New(message.MsgEmailVerificationInvalid)
+
+/*
+Struktur untuk isi token verifikasi email.
+Struktur ini berisi pemilik, role, email yang diverifikasi, dan waktu kedaluwarsa.
+*/
+type VerificationClaims struct {
+	UserID    string `json:"uid"`
+	Role      string `json:"role"`
+	Email     string `json:"email"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+/*
+Struktur untuk layanan verifikasi email.
+Struktur ini menandatangani tautan verifikasi dengan HMAC dan mengirimkannya melalui mailer.
+*/
+type emailVerifier struct {
+	mailer mailer.Mailer
+}
+
+/*
+Metode untuk membuat token verifikasi yang ditandatangani.
+Token berisi payload base64 dan tanda tangan HMAC-SHA256 dikembalikan.
+*/
+func (v *emailVerifier) Sign(userID, role, email string) (string, error) {
+	claims := VerificationClaims{
+		UserID:    userID,
+		Role:      role,
+		Email:     strings.ToLower(email),
+		ExpiresAt: time.Now().Add(config.GetEmailVerificationTTL()).Unix(),
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + sign(encoded), nil
+}
+
+/*
+Metode untuk memverifikasi token verifikasi email.
+Claims dikembalikan jika tanda tangan cocok, role sesuai, dan token belum kedaluwarsa.
+*/
+func (v *emailVerifier) Verify(token, role string) (*VerificationClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrVerificationTokenInvalid
+	}
+	if !hmac.Equal([]byte(sign(parts[0])), []byte(parts[1])) {
+		return nil, ErrVerificationTokenInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrVerificationTokenInvalid
+	}
+	var claims VerificationClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrVerificationTokenInvalid
+	}
+	if claims.Role != role || time.Now().Unix() > claims.ExpiresAt {
+		return nil, ErrVerificationTokenInvalid
+	}
+	return &claims, nil
+}
+
+/*
+Metode untuk mengirim email berisi tautan verifikasi.
+Email verifikasi dikirim ke alamat pengguna.
+*/
+func (v *emailVerifier) SendVerification(userID, role, email, name string) error {
+	token, err := v.Sign(userID, role, email)
+	if err != nil {
+		return err
+	}
+	link := fmt.Sprintf("%s/verify-email?role=%s&token=%s", config.GetFrontendURL(), url.QueryEscape(role), url.QueryEscape(token))
+	body := fmt.Sprintf("Hi %s,\n\nWelcome to Lalan! Please confirm your email address by opening the link below:\n\n%s\n\nThe link is valid for %d hours.\n",
+		name, link, int(config.GetEmailVerificationTTL().Hours()))
+	return v.mailer.Send(mailer.Message{
+		To:      email,
+		Subject: "Verify your Lalan email address",
+		Body:    body,
+	})
+}
+
+/*
+Antarmuka untuk layanan verifikasi email.
+Antarmuka ini mendefinisikan metode penandatanganan, verifikasi, dan pengiriman tautan.
+*/
+type EmailVerifier interface {
+	Sign(userID, role, email string) (string, error)
+	Verify(token, role string) (*VerificationClaims, error)
+	SendVerification(userID, role, email, name string) error
+}
+
+/*
+Fungsi untuk menghitung tanda tangan HMAC dari payload.
+Tanda tangan base64 URL-safe dikembalikan.
+*/
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, config.GetEmailVerificationSecret())
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+/*
+Fungsi untuk membuat instance baru dari EmailVerifier.
+Instance layanan dikembalikan.
+*/
+func NewEmailVerifier(m mailer.Mailer) EmailVerifier {
+	return &emailVerifier{mailer: m}
+}
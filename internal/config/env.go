@@ -1,10 +1,16 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+
+	"lalan-be/pkg/mailer"
 )
 
 /*
@@ -13,15 +19,119 @@ Variabel ini menandai apakah environment sudah dimuat.
 */
 var envLoaded bool
 
+/*
+Konstanta untuk rahasia JWT bawaan pengembangan.
+Konstanta ini hanya boleh dipakai di luar production.
+*/
+const DefaultJWTSecret = "tesingdev"
+
 /*
 Fungsi untuk mendapatkan rahasia JWT.
 Rahasia JWT dikembalikan sebagai byte slice.
 */
 func GetJWTSecret() []byte {
-	secret := GetEnv("JWT_SECRET", "tesingdev")
+	secret := GetEnv("JWT_SECRET", DefaultJWTSecret)
 	return []byte(secret)
 }
 
+/*
+Fungsi untuk mendapatkan algoritma penandatanganan JWT.
+Algoritma dikembalikan dari JWT_ALG dengan bawaan HS256.
+*/
+func GetJWTAlgorithm() string {
+	return GetEnv("JWT_ALG", "HS256")
+}
+
+/*
+Fungsi untuk mendapatkan kid kunci penandatangan JWT aktif.
+Kid dikembalikan dari JWT_SIGNING_KID dengan bawaan "default".
+*/
+func GetJWTKeyID() string {
+	return GetEnv("JWT_SIGNING_KID", "default")
+}
+
+/*
+Fungsi untuk mendapatkan direktori kunci PEM JWT.
+Direktori dikembalikan dari JWT_KEYS_DIR.
+*/
+func GetJWTKeysDir() string {
+	return GetEnv("JWT_KEYS_DIR", "")
+}
+
+/*
+Fungsi untuk mendapatkan rahasia HMAC lama yang masih diterima.
+Peta kid ke rahasia dikembalikan dari JWT_PREVIOUS_SECRETS berformat "kid:secret,kid:secret".
+*/
+func GetJWTPreviousSecrets() map[string]string {
+	secrets := make(map[string]string)
+	for _, pair := range strings.Split(GetEnv("JWT_PREVIOUS_SECRETS", ""), ",") {
+		kid, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || kid == "" || secret == "" {
+			continue
+		}
+		secrets[kid] = secret
+	}
+	return secrets
+}
+
+/*
+Fungsi untuk mendapatkan masa berlaku access token.
+Durasi dikembalikan dari ACCESS_TOKEN_TTL_MINUTES dengan bawaan 60 menit.
+*/
+func GetAccessTokenTTL() time.Duration {
+	minutes, err := strconv.Atoi(GetEnv("ACCESS_TOKEN_TTL_MINUTES", "60"))
+	if err != nil || minutes <= 0 {
+		minutes = 60
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+/*
+Fungsi untuk mengetahui apakah aplikasi berjalan di production.
+Nilai true dikembalikan jika APP_ENV bernilai production atau prod.
+*/
+func IsProduction() bool {
+	env := strings.ToLower(strings.TrimSpace(os.Getenv("APP_ENV")))
+	return env == "production" || env == "prod"
+}
+
+/*
+Fungsi untuk memvalidasi konfigurasi rahasia sebelum server berjalan.
+Error dikembalikan jika production memakai rahasia bawaan atau terlalu pendek.
+*/
+func ValidateSecrets() error {
+	usesHMAC := strings.EqualFold(GetJWTAlgorithm(), "HS256")
+	weak := func(secret string) bool {
+		return secret == "" || secret == DefaultJWTSecret || len(secret) < 32
+	}
+
+	if !IsProduction() {
+		if usesHMAC && string(GetJWTSecret()) == DefaultJWTSecret {
+			log.Printf("Config: WARNING using default JWT secret, set JWT_SECRET before deploying")
+		}
+		return nil
+	}
+
+	if usesHMAC && weak(os.Getenv("JWT_SECRET")) {
+		return fmt.Errorf("JWT_SECRET must be set to a non-default value of at least 32 bytes in production")
+	}
+	verification := os.Getenv("EMAIL_VERIFICATION_SECRET")
+	if verification == "" && usesHMAC {
+		verification = os.Getenv("JWT_SECRET")
+	}
+	if weak(verification) {
+		return fmt.Errorf("EMAIL_VERIFICATION_SECRET must be set to a non-default value of at least 32 bytes in production")
+	}
+	mfaKey := os.Getenv("MFA_ENCRYPTION_KEY")
+	if mfaKey == "" && usesHMAC {
+		mfaKey = os.Getenv("JWT_SECRET")
+	}
+	if weak(mfaKey) {
+		return fmt.Errorf("MFA_ENCRYPTION_KEY must be set to a non-default value of at least 32 bytes in production")
+	}
+	return nil
+}
+
 /*
 Fungsi untuk memuat environment.
 Environment dimuat dari file jika belum dimuat.
@@ -60,3 +170,302 @@ func MustGetEnv(key string) string {
 	}
 	return v
 }
+
+/*
+Fungsi untuk mendapatkan masa berlaku refresh token.
+Durasi dikembalikan dari REFRESH_TOKEN_TTL_HOURS dengan bawaan 30 hari.
+*/
+func GetRefreshTokenTTL() time.Duration {
+	hours, err := strconv.Atoi(GetEnv("REFRESH_TOKEN_TTL_HOURS", "720"))
+	if err != nil || hours <= 0 {
+		hours = 720
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+/*
+Fungsi untuk mendapatkan masa berlaku cache pencabutan token.
+Durasi dikembalikan dari REVOCATION_CACHE_TTL_SECONDS dengan bawaan 30 detik.
+*/
+func GetRevocationCacheTTL() time.Duration {
+	seconds, err := strconv.Atoi(GetEnv("REVOCATION_CACHE_TTL_SECONDS", "30"))
+	if err != nil || seconds < 0 {
+		seconds = 30
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+/*
+Fungsi untuk mendapatkan jeda minimum pembaruan aktivitas sesi.
+Durasi dikembalikan dari SESSION_TOUCH_INTERVAL_SECONDS dengan bawaan 60 detik.
+*/
+func GetSessionTouchInterval() time.Duration {
+	seconds, err := strconv.Atoi(GetEnv("SESSION_TOUCH_INTERVAL_SECONDS", "60"))
+	if err != nil || seconds < 0 {
+		seconds = 60
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+/*
+Fungsi untuk mendapatkan konfigurasi mailer.
+Konfigurasi driver dan koneksi email dikembalikan dari environment.
+*/
+func GetMailConfig() mailer.Config {
+	return mailer.Config{
+		Driver:   GetEnv("MAIL_DRIVER", "log"),
+		From:     GetEnv("MAIL_FROM", "no-reply@lalan.id"),
+		Host:     GetEnv("SMTP_HOST", ""),
+		Port:     GetEnv("SMTP_PORT", "587"),
+		Username: GetEnv("SMTP_USERNAME", ""),
+		Password: GetEnv("SMTP_PASSWORD", ""),
+		FilePath: GetEnv("MAIL_FILE_PATH", "tmp/mail.log"),
+	}
+}
+
+/*
+Fungsi untuk mendapatkan URL frontend aplikasi.
+URL dasar untuk tautan di email dikembalikan.
+*/
+func GetFrontendURL() string {
+	return strings.TrimRight(GetEnv("FRONTEND_URL", "http://localhost:3000"), "/")
+}
+
+/*
+Fungsi untuk mendapatkan masa berlaku token reset password.
+Durasi dikembalikan dari PASSWORD_RESET_TTL_MINUTES dengan bawaan 30 menit.
+*/
+func GetPasswordResetTTL() time.Duration {
+	minutes, err := strconv.Atoi(GetEnv("PASSWORD_RESET_TTL_MINUTES", "30"))
+	if err != nil || minutes <= 0 {
+		minutes = 30
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+/*
+Fungsi untuk mendapatkan rahasia penandatanganan tautan verifikasi email.
+Rahasia dari EMAIL_VERIFICATION_SECRET atau rahasia JWT dikembalikan.
+*/
+func GetEmailVerificationSecret() []byte {
+	if secret := GetEnv("EMAIL_VERIFICATION_SECRET", ""); secret != "" {
+		return []byte(secret)
+	}
+	return GetJWTSecret()
+}
+
+/*
+Fungsi untuk mendapatkan masa berlaku tautan verifikasi email.
+Durasi dikembalikan dari EMAIL_VERIFICATION_TTL_HOURS dengan bawaan 48 jam.
+*/
+func GetEmailVerificationTTL() time.Duration {
+	hours, err := strconv.Atoi(GetEnv("EMAIL_VERIFICATION_TTL_HOURS", "48"))
+	if err != nil || hours <= 0 {
+		hours = 48
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+/*
+Fungsi untuk mengetahui apakah hoster wajib terverifikasi sebelum membuat item.
+Nilai dikembalikan dari HOSTER_REQUIRE_VERIFIED_EMAIL dengan bawaan true.
+*/
+func HosterRequiresVerifiedEmail() bool {
+	v, err := strconv.ParseBool(GetEnv("HOSTER_REQUIRE_VERIFIED_EMAIL", "true"))
+	if err != nil {
+		return true
+	}
+	return v
+}
+
+/*
+Fungsi untuk mendapatkan masa berlaku undangan admin.
+Durasi dikembalikan dari ADMIN_INVITATION_TTL_HOURS dengan bawaan 72 jam.
+*/
+func GetAdminInvitationTTL() time.Duration {
+	hours, err := strconv.Atoi(GetEnv("ADMIN_INVITATION_TTL_HOURS", "72"))
+	if err != nil || hours <= 0 {
+		hours = 72
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+/*
+Fungsi untuk mendapatkan masa berlaku undangan staf toko hoster.
+Durasi dikembalikan dari STAFF_INVITATION_TTL_HOURS dengan bawaan 72 jam.
+*/
+func GetStaffInvitationTTL() time.Duration {
+	hours, err := strconv.Atoi(GetEnv("STAFF_INVITATION_TTL_HOURS", "72"))
+	if err != nil || hours <= 0 {
+		hours = 72
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+/*
+Struktur untuk konfigurasi perlindungan login.
+Struktur ini berisi ambang backoff dan lockout untuk percobaan login yang gagal.
+*/
+type LoginGuardConfig struct {
+	FreeAttempts      int
+	BackoffBase       time.Duration
+	BackoffMax        time.Duration
+	IPFreeAttempts    int
+	LockoutThreshold  int
+	LockoutDuration   time.Duration
+	AttemptWindow     time.Duration
+	TrustProxyHeaders bool
+}
+
+/*
+Fungsi untuk mendapatkan konfigurasi perlindungan login.
+Konfigurasi dikembalikan dari environment LOGIN_* dengan nilai bawaan yang aman.
+*/
+func GetLoginGuardConfig() LoginGuardConfig {
+	trustProxy, err := strconv.ParseBool(GetEnv("TRUST_PROXY_HEADERS", "false"))
+	if err != nil {
+		trustProxy = false
+	}
+	return LoginGuardConfig{
+		FreeAttempts:      getEnvInt("LOGIN_FREE_ATTEMPTS", 3),
+		BackoffBase:       time.Duration(getEnvInt("LOGIN_BACKOFF_BASE_SECONDS", 1)) * time.Second,
+		BackoffMax:        time.Duration(getEnvInt("LOGIN_BACKOFF_MAX_SECONDS", 300)) * time.Second,
+		IPFreeAttempts:    getEnvInt("LOGIN_IP_FREE_ATTEMPTS", 20),
+		LockoutThreshold:  getEnvInt("LOGIN_LOCKOUT_THRESHOLD", 10),
+		LockoutDuration:   time.Duration(getEnvInt("LOGIN_LOCKOUT_MINUTES", 15)) * time.Minute,
+		AttemptWindow:     time.Duration(getEnvInt("LOGIN_ATTEMPT_WINDOW_MINUTES", 60)) * time.Minute,
+		TrustProxyHeaders: trustProxy,
+	}
+}
+
+/*
+Fungsi untuk membaca nilai environment bilangan bulat positif.
+Nilai environment atau fallback dikembalikan jika tidak valid.
+*/
+func getEnvInt(key string, fallback int) int {
+	v, err := strconv.Atoi(GetEnv(key, strconv.Itoa(fallback)))
+	if err != nil || v <= 0 {
+		return fallback
+	}
+	return v
+}
+
+/*
+Fungsi untuk mendapatkan kunci enkripsi secret MFA.
+Kunci dari MFA_ENCRYPTION_KEY atau rahasia JWT dikembalikan.
+*/
+func GetMFAEncryptionKey() []byte {
+	if key := GetEnv("MFA_ENCRYPTION_KEY", ""); key != "" {
+		return []byte(key)
+	}
+	return GetJWTSecret()
+}
+
+/*
+Fungsi untuk mendapatkan nama penerbit TOTP.
+Nama yang tampil di aplikasi authenticator dikembalikan dari MFA_ISSUER.
+*/
+func GetMFAIssuer() string {
+	return GetEnv("MFA_ISSUER", "Lalan")
+}
+
+/*
+Fungsi untuk mendapatkan masa berlaku token tantangan MFA.
+Durasi dikembalikan dari MFA_CHALLENGE_TTL_MINUTES dengan bawaan 5 menit.
+*/
+func GetMFAChallengeTTL() time.Duration {
+	return time.Duration(getEnvInt("MFA_CHALLENGE_TTL_MINUTES", 5)) * time.Minute
+}
+
+/*
+Fungsi untuk mengetahui apakah admin wajib mengaktifkan MFA.
+Nilai dikembalikan dari ADMIN_REQUIRE_MFA dengan bawaan false.
+*/
+func AdminRequiresMFA() bool {
+	v, err := strconv.ParseBool(GetEnv("ADMIN_REQUIRE_MFA", "false"))
+	if err != nil {
+		return false
+	}
+	return v
+}
+
+/*
+Fungsi untuk mendapatkan masa berlaku cache permission.
+Durasi dikembalikan dari PERMISSION_CACHE_TTL_SECONDS dengan bawaan 60 detik.
+*/
+func GetPermissionCacheTTL() time.Duration {
+	return time.Duration(getEnvInt("PERMISSION_CACHE_TTL_SECONDS", 60)) * time.Second
+}
+
+/*
+Fungsi untuk mendapatkan panjang minimum password.
+Nilai dikembalikan dari PASSWORD_MIN_LENGTH dengan bawaan 10 karakter.
+*/
+func GetPasswordMinLength() int {
+	return getEnvInt("PASSWORD_MIN_LENGTH", 10)
+}
+
+/*
+Fungsi untuk mendapatkan estimasi entropi minimum password.
+Nilai bit dikembalikan dari PASSWORD_MIN_ENTROPY_BITS dengan bawaan 45.
+*/
+func GetPasswordMinEntropy() float64 {
+	return float64(getEnvInt("PASSWORD_MIN_ENTROPY_BITS", 45))
+}
+
+/*
+Fungsi untuk mendapatkan lokasi daftar hash password yang bocor.
+Path file atau direktori rentang SHA-1 dikembalikan dari PASSWORD_BREACHED_LIST_PATH, kosong berarti pemeriksaan dimatikan.
+*/
+func GetBreachedPasswordListPath() string {
+	return GetEnv("PASSWORD_BREACHED_LIST_PATH", "")
+}
+
+/*
+Fungsi untuk mendapatkan masa tenggang penghapusan akun.
+Durasi dikembalikan dari ACCOUNT_DELETION_GRACE_DAYS dengan bawaan 30 hari.
+*/
+func GetAccountDeletionGracePeriod() time.Duration {
+	return time.Duration(getEnvInt("ACCOUNT_DELETION_GRACE_DAYS", 30)) * 24 * time.Hour
+}
+
+/*
+Fungsi untuk mendapatkan masa berlaku token impersonasi hoster.
+Durasi dikembalikan dari IMPERSONATION_TTL_MINUTES dengan bawaan 15 menit.
+*/
+func GetImpersonationTTL() time.Duration {
+	return time.Duration(getEnvInt("IMPERSONATION_TTL_MINUTES", 15)) * time.Minute
+}
+
+/*
+Fungsi untuk mendapatkan aturan batas hari sewa.
+Nilai "inclusive" (bawaan) menghitung tanggal mulai dan selesai sebagai hari sewa, sedangkan "nights" menghitung jumlah malam dengan minimal satu hari.
+*/
+func GetRentalDayRule() string {
+	if strings.EqualFold(GetEnv("RENTAL_DAY_RULE", "inclusive"), "nights") {
+		return "nights"
+	}
+	return "inclusive"
+}
+
+/*
+Struktur untuk konfigurasi hold stok.
+Struktur ini berisi masa berlaku hold dan batas hold aktif setiap customer.
+*/
+type StockHoldConfig struct {
+	TTL             time.Duration
+	MaxPerCustomer  int
+	MaxUnitsPerItem int
+}
+
+/*
+Fungsi untuk mendapatkan konfigurasi hold stok saat checkout.
+Konfigurasi dikembalikan dari STOCK_HOLD_TTL_MINUTES (bawaan 15 menit), STOCK_HOLD_MAX_PER_CUSTOMER (bawaan 10 hold aktif), dan STOCK_HOLD_MAX_UNITS_PER_ITEM (bawaan 5 unit per item).
+*/
+func GetStockHoldConfig() StockHoldConfig {
+	return StockHoldConfig{
+		TTL:             time.Duration(getEnvInt("STOCK_HOLD_TTL_MINUTES", 15)) * time.Minute,
+		MaxPerCustomer:  getEnvInt("STOCK_HOLD_MAX_PER_CUSTOMER", 10),
+		MaxUnitsPerItem: getEnvInt("STOCK_HOLD_MAX_UNITS_PER_ITEM", 5),
+	}
+}
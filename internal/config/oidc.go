@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+/*
+Struktur untuk konfigurasi penyedia OpenID Connect.
+Struktur ini berisi issuer, kredensial klien, redirect URI, dan scope yang diminta.
+*/
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+/*
+Fungsi untuk mendapatkan konfigurasi penyedia OpenID Connect.
+Konfigurasi dikembalikan dari OIDC_ISSUER, OIDC_CLIENT_ID, OIDC_CLIENT_SECRET, OIDC_REDIRECT_URL, dan OIDC_SCOPES.
+*/
+func GetOIDCConfig() OIDCConfig {
+	return OIDCConfig{
+		Issuer:       strings.TrimRight(GetEnv("OIDC_ISSUER", ""), "/"),
+		ClientID:     GetEnv("OIDC_CLIENT_ID", ""),
+		ClientSecret: GetEnv("OIDC_CLIENT_SECRET", ""),
+		RedirectURL:  GetEnv("OIDC_REDIRECT_URL", GetFrontendURL()+"/auth/oidc/callback"),
+		Scopes:       strings.Fields(GetEnv("OIDC_SCOPES", "openid email profile")),
+	}
+}
+
+/*
+Fungsi untuk mendapatkan masa berlaku state login OpenID Connect.
+Durasi dikembalikan dari OIDC_STATE_TTL_MINUTES dengan bawaan 10 menit.
+*/
+func GetOIDCStateTTL() time.Duration {
+	return time.Duration(getEnvInt("OIDC_STATE_TTL_MINUTES", 10)) * time.Minute
+}
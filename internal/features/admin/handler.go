@@ -2,11 +2,13 @@ package admin
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"regexp"
 	"strings"
 
+	"lalan-be/internal/auth"
 	"lalan-be/internal/model"
 	"lalan-be/internal/response"
 	"lalan-be/pkg/message"
@@ -21,15 +23,32 @@ type AdminHandler struct {
 }
 
 /*
-Struktur untuk permintaan pembuatan admin.
-Struktur ini berisi data yang diperlukan untuk membuat admin baru.
+Struktur untuk permintaan undangan admin.
+Struktur ini berisi email calon admin yang diundang.
 */
-type AdminRequest struct {
+type InviteRequest struct {
+	Email string `json:"email"`
+}
+
+/*
+Struktur untuk permintaan penerimaan undangan admin.
+Struktur ini berisi token undangan dan data akun admin baru.
+*/
+type AcceptInvitationRequest struct {
+	Token    string `json:"token"`
 	FullName string `json:"full_name"`
-	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
+/*
+Struktur untuk permintaan impersonasi hoster.
+Struktur ini berisi ID pemilik toko dan alasan impersonasi untuk jejak audit.
+*/
+type ImpersonationRequest struct {
+	HosterID string `json:"hoster_id"`
+	Reason   string `json:"reason"`
+}
+
 /*
 Struktur untuk permintaan login admin.
 Struktur ini berisi kredensial untuk autentikasi admin.
@@ -39,6 +58,87 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+/*
+Struktur untuk permintaan verifikasi MFA saat login.
+Struktur ini berisi token tantangan dan kode OTP atau kode pemulihan.
+*/
+type MFAVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+/*
+Struktur untuk permintaan yang berisi kode MFA.
+Struktur ini dipakai untuk konfirmasi, penonaktifan, dan pembuatan ulang kode pemulihan.
+*/
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+/*
+Struktur untuk permintaan refresh token admin.
+Struktur ini berisi refresh token yang akan dirotasi atau dicabut.
+*/
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+/*
+Struktur untuk permintaan lupa password admin.
+Struktur ini berisi email akun yang ingin dipulihkan.
+*/
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+/*
+Struktur untuk permintaan reset password admin.
+Struktur ini berisi token reset dan password baru.
+*/
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+/*
+Struktur untuk permintaan ganti password admin.
+Struktur ini berisi password saat ini dan password baru.
+*/
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+/*
+Struktur untuk permintaan pencabutan token pengguna.
+Struktur ini berisi ID dan role pengguna yang tokennya dicabut.
+*/
+type RevokeTokensRequest struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+/*
+Struktur untuk permintaan role.
+Struktur ini berisi nama, jenis akun, deskripsi, dan permission role.
+*/
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	UserType    string   `json:"user_type"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+/*
+Struktur untuk permintaan penugasan role.
+Struktur ini berisi pengguna dan role yang diberikan atau dicabut.
+*/
+type RoleAssignmentRequest struct {
+	UserID   string `json:"user_id"`
+	UserType string `json:"user_type"`
+	RoleID   string `json:"role_id"`
+}
+
 /*
 Struktur untuk permintaan kategori.
 Struktur ini berisi data untuk operasi kategori.
@@ -49,108 +149,342 @@ type CategoryRequest struct {
 }
 
 /*
-Metode untuk membuat admin baru.
-Metode ini memvalidasi input dan membuat admin melalui layanan.
+Metode untuk login admin.
+Metode ini memvalidasi kredensial dan mengembalikan token autentikasi.
 */
-func (h *AdminHandler) CreateAdmin(w http.ResponseWriter, r *http.Request) {
-	log.Printf("CreateAdmin: received request")
+func (h *AdminHandler) LoginAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("LoginAdmin: received request")
 	// Cek method POST
 	if r.Method != http.MethodPost {
 		response.BadRequest(w, message.MsgNotAllowed)
 		return
 	}
-	var req AdminRequest
+
+	var req LoginRequest
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	// Decode JSON
 	if err := decoder.Decode(&req); err != nil {
-		log.Printf("CreateAdmin: invalid JSON: %v", err)
+		log.Printf("LoginAdmin: invalid JSON: %v", err)
 		response.BadRequest(w, message.MsgBadRequest)
 		return
 	}
 
-	// Validasi full name
-	if strings.TrimSpace(req.FullName) == "" {
-		log.Printf("CreateAdmin: full name required")
-		response.BadRequest(w, "Full name is required")
+	// Validasi email dan password
+	if req.Email == "" || req.Password == "" {
+		log.Printf("LoginAdmin: email or password empty")
+		response.Error(w, http.StatusBadRequest, "Email and password are required")
 		return
 	}
 
-	// Validasi email
-	if strings.TrimSpace(req.Email) == "" {
-		log.Printf("CreateAdmin: email required")
-		response.BadRequest(w, "Email is required")
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	// Validasi format email
+	if !emailRegex.MatchString(req.Email) {
+		log.Printf("LoginAdmin: invalid email format: %s", req.Email)
+		response.Error(w, http.StatusBadRequest, "Invalid email format")
 		return
 	}
 
-	// Validasi password
-	if strings.TrimSpace(req.Password) == "" {
-		log.Printf("CreateAdmin: password required")
-		response.BadRequest(w, "Password is required")
+	resp, err := h.service.LoginAdmin(req.Email, req.Password, auth.ClientInfoFromRequest(r))
+	if err != nil {
+		log.Printf("LoginAdmin: login failed: %v", err)
+		var blocked *auth.LoginBlockedError
+		if errors.As(err, &blocked) {
+			if blocked.Locked {
+				response.Locked(w, blocked.Error(), blocked.RetryAfter)
+				return
+			}
+			response.TooManyRequests(w, blocked.Error(), blocked.RetryAfter)
+			return
+		}
+		response.Error(w, http.StatusUnauthorized, "Invalid credentials")
+		return
+	}
+
+	// Login dengan MFA aktif menunggu kode OTP
+	if resp.MFARequired {
+		log.Printf("LoginAdmin: MFA challenge issued for email %s", req.Email)
+		response.OK(w, map[string]interface{}{
+			"id":              resp.ID,
+			"mfa_required":    true,
+			"challenge_token": resp.ChallengeToken,
+			"expires_in":      resp.ExpiresIn,
+		}, message.MsgMFARequired)
 		return
 	}
+	log.Printf("LoginAdmin: login successful for email %s", req.Email)
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+
+	userData := map[string]interface{}{
+		"id":            resp.ID,
+		"access_token":  resp.AccessToken,
+		"refresh_token": resp.RefreshToken,
+		"token_type":    resp.TokenType,
+		"expires_in":    resp.ExpiresIn,
+	}
+
+	response.Success(w, 200, userData, "Login successful")
+}
 
-	input := &model.AdminModel{
-		FullName:     req.FullName,
-		Email:        req.Email,
-		PasswordHash: req.Password,
+/*
+Metode untuk menyelesaikan login admin dengan kode MFA.
+Metode ini menukar token tantangan dan kode valid dengan pasangan token.
+*/
+func (h *AdminHandler) VerifyMFAAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("VerifyMFAAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req MFAVerifyRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("VerifyMFAAdmin: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.ChallengeToken) == "" {
+		response.BadRequest(w, message.MsgMFAChallengeRequired)
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, message.MsgMFACodeRequired)
+		return
 	}
 
-	err := h.service.CreateAdmin(input)
+	resp, err := h.service.VerifyMFAAdmin(req.ChallengeToken, req.Code, auth.ClientInfoFromRequest(r))
 	if err != nil {
-		log.Printf("CreateAdmin: error creating admin: %v", err)
-		response.BadRequest(w, err.Error())
+		log.Printf("VerifyMFAAdmin: verification failed: %v", err)
+		var blocked *auth.LoginBlockedError
+		if errors.As(err, &blocked) {
+			if blocked.Locked {
+				response.Locked(w, blocked.Error(), blocked.RetryAfter)
+				return
+			}
+			response.TooManyRequests(w, blocked.Error(), blocked.RetryAfter)
+			return
+		}
+		if errors.Is(err, auth.ErrMFAChallengeInvalid) || errors.Is(err, auth.ErrMFACodeInvalid) || errors.Is(err, auth.ErrMFANotEnabled) {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
 		return
 	}
 
-	response.Created(w, input, message.MsgSuccess)
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+	userData := map[string]interface{}{
+		"id":            resp.ID,
+		"access_token":  resp.AccessToken,
+		"refresh_token": resp.RefreshToken,
+		"token_type":    resp.TokenType,
+		"expires_in":    resp.ExpiresIn,
+	}
+	response.Success(w, 200, userData, "Login successful")
 }
 
 /*
-Metode untuk login admin.
-Metode ini memvalidasi kredensial dan mengembalikan token autentikasi.
+Metode untuk memulai pendaftaran MFA admin.
+Metode ini mengembalikan secret dan URI provisioning untuk kode QR.
 */
-func (h *AdminHandler) LoginAdmin(w http.ResponseWriter, r *http.Request) {
-	log.Printf("LoginAdmin: received request")
-	// Cek method POST
+func (h *AdminHandler) EnrollMFAAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("EnrollMFAAdmin: received request")
 	if r.Method != http.MethodPost {
 		response.BadRequest(w, message.MsgNotAllowed)
 		return
 	}
 
-	var req LoginRequest
+	enrollment, err := h.service.EnrollMFAAdmin(r.Context())
+	if err != nil {
+		log.Printf("EnrollMFAAdmin: error: %v", err)
+		if errors.Is(err, auth.ErrMFAAlreadyEnabled) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, enrollment, message.MsgMFAEnrollmentStarted)
+}
+
+/*
+Metode untuk mengonfirmasi pendaftaran MFA admin.
+Metode ini mengaktifkan MFA dan mengembalikan kode pemulihan beserta token baru.
+*/
+func (h *AdminHandler) ConfirmMFAAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ConfirmMFAAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req MFACodeRequest
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
-	// Decode JSON
 	if err := decoder.Decode(&req); err != nil {
-		log.Printf("LoginAdmin: invalid JSON: %v", err)
+		log.Printf("ConfirmMFAAdmin: invalid JSON: %v", err)
 		response.BadRequest(w, message.MsgBadRequest)
 		return
 	}
+	if strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, message.MsgMFACodeRequired)
+		return
+	}
 
-	// Validasi email dan password
-	if req.Email == "" || req.Password == "" {
-		log.Printf("LoginAdmin: email or password empty")
-		response.Error(w, http.StatusBadRequest, "Email and password are required")
+	codes, resp, err := h.service.ConfirmMFAAdmin(r.Context(), req.Code, auth.ClientInfoFromRequest(r))
+	if err != nil {
+		log.Printf("ConfirmMFAAdmin: error: %v", err)
+		if errors.Is(err, auth.ErrMFACodeInvalid) || errors.Is(err, auth.ErrMFANotPending) || errors.Is(err, auth.ErrMFAAlreadyEnabled) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
 		return
 	}
 
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	// Validasi format email
-	if !emailRegex.MatchString(req.Email) {
-		log.Printf("LoginAdmin: invalid email format: %s", req.Email)
-		response.Error(w, http.StatusBadRequest, "Invalid email format")
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+	data := map[string]interface{}{
+		"recovery_codes": codes,
+		"id":             resp.ID,
+		"access_token":   resp.AccessToken,
+		"refresh_token":  resp.RefreshToken,
+		"token_type":     resp.TokenType,
+		"expires_in":     resp.ExpiresIn,
+	}
+	response.OK(w, data, message.MsgMFAEnabled)
+}
+
+/*
+Metode untuk menonaktifkan MFA admin.
+Metode ini memerlukan kode OTP atau kode pemulihan yang valid.
+*/
+func (h *AdminHandler) DisableMFAAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("DisableMFAAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req MFACodeRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("DisableMFAAdmin: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, message.MsgMFACodeRequired)
+		return
+	}
+
+	if err := h.service.DisableMFAAdmin(r.Context(), req.Code); err != nil {
+		log.Printf("DisableMFAAdmin: error: %v", err)
+		if errors.Is(err, auth.ErrMFACodeInvalid) || errors.Is(err, auth.ErrMFANotEnabled) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgMFARequiredByPolicy {
+			response.Forbidden(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, nil, message.MsgMFADisabled)
+}
+
+/*
+Metode untuk membuat ulang kode pemulihan MFA admin.
+Metode ini mengembalikan kode pemulihan baru jika kode OTP valid.
+*/
+func (h *AdminHandler) RegenerateRecoveryCodesAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RegenerateRecoveryCodesAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req MFACodeRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("RegenerateRecoveryCodesAdmin: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, message.MsgMFACodeRequired)
 		return
 	}
 
-	resp, err := h.service.LoginAdmin(req.Email, req.Password)
+	codes, err := h.service.RegenerateRecoveryCodesAdmin(r.Context(), req.Code)
 	if err != nil {
-		log.Printf("LoginAdmin: login failed: %v", err)
-		response.Error(w, http.StatusUnauthorized, "Invalid credentials")
+		log.Printf("RegenerateRecoveryCodesAdmin: error: %v", err)
+		if errors.Is(err, auth.ErrMFACodeInvalid) || errors.Is(err, auth.ErrMFANotEnabled) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
 		return
 	}
 
-	log.Printf("LoginAdmin: login successful for email %s", req.Email)
+	response.OK(w, map[string]interface{}{"recovery_codes": codes}, message.MsgMFARecoveryCodes)
+}
+
+/*
+Metode untuk merotasi refresh token admin.
+Metode ini mengembalikan pasangan token baru jika refresh token valid.
+*/
+func (h *AdminHandler) RefreshTokenAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RefreshTokenAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req RefreshRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("RefreshTokenAdmin: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		response.BadRequest(w, message.MsgRefreshTokenRequired)
+		return
+	}
+	resp, err := h.service.RefreshTokenAdmin(req.RefreshToken)
+	if err != nil {
+		log.Printf("RefreshTokenAdmin: refresh failed: %v", err)
+		if errors.Is(err, auth.ErrRefreshTokenInvalid) || errors.Is(err, auth.ErrRefreshTokenExpired) || errors.Is(err, auth.ErrRefreshTokenReused) {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
 	http.SetCookie(w, &http.Cookie{
 		Name:     "auth_token",
 		Value:    resp.AccessToken,
@@ -159,7 +493,6 @@ func (h *AdminHandler) LoginAdmin(w http.ResponseWriter, r *http.Request) {
 		Path:     "/",
 		MaxAge:   3600,
 	})
-
 	userData := map[string]interface{}{
 		"id":            resp.ID,
 		"access_token":  resp.AccessToken,
@@ -167,8 +500,828 @@ func (h *AdminHandler) LoginAdmin(w http.ResponseWriter, r *http.Request) {
 		"token_type":    resp.TokenType,
 		"expires_in":    resp.ExpiresIn,
 	}
+	response.OK(w, userData, message.MsgTokenRefreshed)
+}
 
-	response.Success(w, 200, userData, "Login successful")
+/*
+Metode untuk logout admin.
+Metode ini mencabut refresh token dan menghapus cookie autentikasi.
+*/
+func (h *AdminHandler) LogoutAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("LogoutAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req RefreshRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("LogoutAdmin: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		response.BadRequest(w, message.MsgRefreshTokenRequired)
+		return
+	}
+	if err := h.service.LogoutAdmin(r.Context(), req.RefreshToken); err != nil {
+		log.Printf("LogoutAdmin: logout failed: %v", err)
+		if errors.Is(err, auth.ErrRefreshTokenInvalid) || errors.Is(err, auth.ErrRefreshTokenExpired) || errors.Is(err, auth.ErrRefreshTokenReused) {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+	response.OK(w, nil, message.MsgLogoutSuccess)
+}
+
+/*
+Metode untuk logout admin dari semua perangkat.
+Metode ini mencabut seluruh token milik admin yang sedang login.
+*/
+func (h *AdminHandler) LogoutAllAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("LogoutAllAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	if err := h.service.LogoutAllAdmin(r.Context()); err != nil {
+		log.Printf("LogoutAllAdmin: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+	response.OK(w, nil, message.MsgLogoutAllSuccess)
+}
+
+/*
+Metode untuk mengganti password admin yang sedang login.
+Metode ini memverifikasi password lama dan mengeluarkan admin dari semua perangkat setelah berhasil.
+*/
+func (h *AdminHandler) ChangePasswordAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ChangePasswordAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ChangePasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ChangePasswordAdmin: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if req.CurrentPassword == "" {
+		response.BadRequest(w, message.MsgPasswordCurrentRequired)
+		return
+	}
+	if strings.TrimSpace(req.NewPassword) == "" {
+		response.BadRequest(w, message.MsgPasswordRequired)
+		return
+	}
+	if err := h.service.ChangePasswordAdmin(r.Context(), req.CurrentPassword, req.NewPassword); err != nil {
+		log.Printf("ChangePasswordAdmin: error: %v", err)
+		var weak *auth.PasswordPolicyError
+		if errors.As(err, &weak) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgPasswordCurrentRequired || err.Error() == message.MsgPasswordCurrentInvalid || err.Error() == message.MsgPasswordUnchanged {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgUnauthorized {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+	response.OK(w, nil, message.MsgPasswordChanged)
+}
+
+/*
+Metode untuk mengambil daftar sesi login admin.
+Sesi aktif beserta perangkat dan waktu terakhir digunakan dikembalikan.
+*/
+func (h *AdminHandler) GetSessionsAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetSessionsAdmin: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	sessions, err := h.service.GetSessionsAdmin(r.Context())
+	if err != nil {
+		log.Printf("GetSessionsAdmin: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, sessions, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengakhiri sesi login admin tertentu.
+Sesi dicabut sehingga perangkat terkait harus login ulang.
+*/
+func (h *AdminHandler) TerminateSessionAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("TerminateSessionAdmin: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgSessionIDRequired)
+		return
+	}
+
+	if err := h.service.TerminateSessionAdmin(r.Context(), id); err != nil {
+		log.Printf("TerminateSessionAdmin: error: %v", err)
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, nil, message.MsgSessionTerminated)
+}
+
+/*
+Metode untuk meminta tautan reset password admin.
+Metode ini selalu mengembalikan respons yang sama agar email terdaftar tidak bocor.
+*/
+func (h *AdminHandler) ForgotPasswordAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ForgotPasswordAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ForgotPasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ForgotPasswordAdmin: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		response.BadRequest(w, message.MsgEmailRequired)
+		return
+	}
+	if err := h.service.ForgotPasswordAdmin(req.Email); err != nil {
+		log.Printf("ForgotPasswordAdmin: error: %v", err)
+	}
+	response.OK(w, nil, message.MsgPasswordResetRequested)
+}
+
+/*
+Metode untuk mengatur ulang password admin.
+Metode ini memvalidasi token reset dan menyimpan password baru.
+*/
+func (h *AdminHandler) ResetPasswordAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ResetPasswordAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ResetPasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ResetPasswordAdmin: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Token) == "" {
+		response.BadRequest(w, message.MsgPasswordResetTokenMissing)
+		return
+	}
+	if strings.TrimSpace(req.Password) == "" {
+		response.BadRequest(w, message.MsgPasswordRequired)
+		return
+	}
+	if err := h.service.ResetPasswordAdmin(req.Token, req.Password); err != nil {
+		log.Printf("ResetPasswordAdmin: error: %v", err)
+		var weak *auth.PasswordPolicyError
+		if errors.Is(err, auth.ErrResetTokenInvalid) || errors.As(err, &weak) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	response.OK(w, nil, message.MsgPasswordResetSuccess)
+}
+
+/*
+Metode untuk mencabut semua token milik pengguna.
+Metode ini mengeluarkan pengguna dari semua perangkat, misalnya saat akun ditangguhkan.
+*/
+func (h *AdminHandler) RevokeUserTokens(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RevokeUserTokens: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req RevokeTokensRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("RevokeUserTokens: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if err := h.service.RevokeUserTokens(req.UserID, req.Role); err != nil {
+		log.Printf("RevokeUserTokens: error: %v", err)
+		response.BadRequest(w, err.Error())
+		return
+	}
+	log.Printf("RevokeUserTokens: revoked tokens for %s %s", req.Role, req.UserID)
+	response.OK(w, nil, message.MsgUserTokensRevoked)
+}
+
+/*
+Metode untuk mengambil daftar blokir login yang masih aktif.
+Daftar backoff dan lockout dikembalikan.
+*/
+func (h *AdminHandler) GetLoginBlocks(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetLoginBlocks: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	blocks, err := h.service.GetLoginBlocks()
+	if err != nil {
+		log.Printf("GetLoginBlocks: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, blocks, message.MsgSuccess)
+}
+
+/*
+Metode untuk menghapus blokir login.
+Metode ini menghapus backoff atau lockout berdasarkan ID.
+*/
+func (h *AdminHandler) ClearLoginBlock(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ClearLoginBlock: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	// Validasi ID
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgLoginBlockIDRequired)
+		return
+	}
+
+	if err := h.service.ClearLoginBlock(id); err != nil {
+		log.Printf("ClearLoginBlock: error: %v", err)
+		if errors.Is(err, auth.ErrLoginBlockNotFound) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	log.Printf("ClearLoginBlock: cleared lockout %s", id)
+	response.OK(w, nil, message.MsgLoginBlockCleared)
+}
+
+/*
+Metode untuk mengundang admin baru.
+Metode ini memvalidasi email dan mengirim tautan undangan sekali pakai.
+*/
+func (h *AdminHandler) InviteAdmin(w http.ResponseWriter, r *http.Request) {
+	log.Printf("InviteAdmin: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req InviteRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("InviteAdmin: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	// Validasi email
+	if strings.TrimSpace(req.Email) == "" {
+		response.BadRequest(w, message.MsgEmailRequired)
+		return
+	}
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	if !emailRegex.MatchString(strings.TrimSpace(req.Email)) {
+		response.BadRequest(w, "Invalid email format")
+		return
+	}
+
+	invitation, err := h.service.InviteAdmin(r.Context(), req.Email)
+	if err != nil {
+		log.Printf("InviteAdmin: error: %v", err)
+		if err.Error() == message.MsgAdminEmailExists {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	log.Printf("InviteAdmin: invitation %s sent to %s", invitation.ID, invitation.Email)
+	response.Created(w, invitation, message.MsgAdminInvitationSent)
+}
+
+/*
+Metode untuk menerima undangan admin.
+Metode ini membuat akun admin baru dari token undangan yang valid.
+*/
+func (h *AdminHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	log.Printf("AcceptInvitation: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req AcceptInvitationRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("AcceptInvitation: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	// Validasi input
+	if strings.TrimSpace(req.Token) == "" {
+		response.BadRequest(w, message.MsgAdminInvitationMissing)
+		return
+	}
+	if strings.TrimSpace(req.FullName) == "" {
+		response.BadRequest(w, message.MsgAdminFullNameRequired)
+		return
+	}
+	if strings.TrimSpace(req.Password) == "" {
+		response.BadRequest(w, message.MsgPasswordRequired)
+		return
+	}
+
+	admin, err := h.service.AcceptInvitation(req.Token, req.FullName, req.Password)
+	if err != nil {
+		log.Printf("AcceptInvitation: error: %v", err)
+		var weak *auth.PasswordPolicyError
+		if err.Error() == message.MsgAdminInvitationInvalid || err.Error() == message.MsgAdminEmailExists || errors.As(err, &weak) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.Created(w, admin, message.MsgAdminInvitationAccepted)
+}
+
+/*
+Metode untuk mengambil undangan admin yang masih menunggu.
+Daftar undangan dikembalikan.
+*/
+func (h *AdminHandler) GetPendingInvitations(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetPendingInvitations: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	invitations, err := h.service.GetPendingInvitations()
+	if err != nil {
+		log.Printf("GetPendingInvitations: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, invitations, message.MsgSuccess)
+}
+
+/*
+Metode untuk membatalkan undangan admin.
+Metode ini membatalkan undangan berdasarkan ID.
+*/
+func (h *AdminHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RevokeInvitation: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	// Validasi ID
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgAdminInvitationIDRequired)
+		return
+	}
+
+	if err := h.service.RevokeInvitation(id); err != nil {
+		log.Printf("RevokeInvitation: error: %v", err)
+		if err.Error() == message.MsgAdminInvitationNotFound {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, nil, message.MsgAdminInvitationRevoked)
+}
+
+/*
+Metode untuk memulai impersonasi hoster.
+Token impersonasi singkat tanpa refresh token dikembalikan.
+*/
+func (h *AdminHandler) StartImpersonation(w http.ResponseWriter, r *http.Request) {
+	log.Printf("StartImpersonation: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ImpersonationRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("StartImpersonation: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	// Validasi input
+	if strings.TrimSpace(req.HosterID) == "" {
+		response.BadRequest(w, message.MsgImpersonationHosterRequired)
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		response.BadRequest(w, message.MsgImpersonationReasonRequired)
+		return
+	}
+
+	grant, err := h.service.StartImpersonation(r.Context(), req.HosterID, req.Reason)
+	if err != nil {
+		log.Printf("StartImpersonation: error: %v", err)
+		if err.Error() == message.MsgHosterNotFound {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.Created(w, grant, message.MsgImpersonationStarted)
+}
+
+/*
+Metode untuk mengambil daftar impersonasi hoster terbaru.
+Daftar sesi impersonasi dikembalikan, dapat difilter dengan hoster_id.
+*/
+func (h *AdminHandler) GetImpersonations(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetImpersonations: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	impersonations, err := h.service.GetImpersonations(strings.TrimSpace(r.URL.Query().Get("hoster_id")))
+	if err != nil {
+		log.Printf("GetImpersonations: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, impersonations, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengakhiri impersonasi hoster.
+Token impersonasi dicabut sebelum masa berlakunya habis.
+*/
+func (h *AdminHandler) EndImpersonation(w http.ResponseWriter, r *http.Request) {
+	log.Printf("EndImpersonation: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	// Validasi ID
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgImpersonationIDRequired)
+		return
+	}
+
+	if err := h.service.EndImpersonation(r.Context(), id); err != nil {
+		log.Printf("EndImpersonation: error: %v", err)
+		if errors.Is(err, auth.ErrImpersonationNotFound) {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, nil, message.MsgImpersonationEnded)
+}
+
+/*
+Metode untuk mengambil jejak audit sebuah impersonasi.
+Setiap permintaan yang dilakukan selama impersonasi dikembalikan.
+*/
+func (h *AdminHandler) GetImpersonationLogs(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetImpersonationLogs: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	// Validasi ID
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgImpersonationIDRequired)
+		return
+	}
+
+	logs, err := h.service.GetImpersonationLogs(id)
+	if err != nil {
+		log.Printf("GetImpersonationLogs: error: %v", err)
+		if errors.Is(err, auth.ErrImpersonationNotFound) {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, logs, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengambil semua role otorisasi.
+Daftar role beserta permission-nya dikembalikan.
+*/
+func (h *AdminHandler) GetAllRoles(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetAllRoles: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	roles, err := h.service.GetAllRoles()
+	if err != nil {
+		log.Printf("GetAllRoles: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, roles, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengambil katalog permission.
+Daftar permission yang dapat diberikan ke role dikembalikan.
+*/
+func (h *AdminHandler) GetPermissionCatalog(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetPermissionCatalog: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	response.OK(w, h.service.GetPermissionCatalog(), message.MsgSuccess)
+}
+
+/*
+Metode untuk membuat role baru.
+Metode ini memvalidasi input dan membuat role melalui layanan.
+*/
+func (h *AdminHandler) CreateRole(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CreateRole: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	var req RoleRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("CreateRole: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	role := &model.RoleModel{
+		Name:        req.Name,
+		UserType:    req.UserType,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+	if err := h.service.CreateRole(role); err != nil {
+		log.Printf("CreateRole: error: %v", err)
+		switch err.Error() {
+		case message.MsgRoleNameRequired, message.MsgRoleInvalid, message.MsgRolePermissionInvalid, message.MsgRoleNameExists:
+			response.BadRequest(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	log.Printf("CreateRole: role created with ID %s", role.ID)
+	response.Created(w, role, message.MsgRoleCreated)
+}
+
+/*
+Metode untuk memperbarui role.
+Metode ini mengganti nama, deskripsi, dan permission role berdasarkan ID.
+*/
+func (h *AdminHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	log.Printf("UpdateRole: received request")
+	if r.Method != http.MethodPut {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgRoleIDRequired)
+		return
+	}
+
+	var req RoleRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("UpdateRole: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	role := &model.RoleModel{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+	if err := h.service.UpdateRole(role); err != nil {
+		log.Printf("UpdateRole: error: %v", err)
+		switch err.Error() {
+		case message.MsgRoleNotFound:
+			response.Error(w, http.StatusNotFound, err.Error())
+		case message.MsgRoleIDRequired, message.MsgRoleNameRequired, message.MsgRolePermissionInvalid, message.MsgRoleNameExists:
+			response.BadRequest(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	response.OK(w, role, message.MsgRoleUpdated)
+}
+
+/*
+Metode untuk menghapus role.
+Metode ini menolak penghapusan role bawaan sistem.
+*/
+func (h *AdminHandler) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	log.Printf("DeleteRole: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgRoleIDRequired)
+		return
+	}
+
+	if err := h.service.DeleteRole(id); err != nil {
+		log.Printf("DeleteRole: error: %v", err)
+		switch err.Error() {
+		case message.MsgRoleNotFound:
+			response.Error(w, http.StatusNotFound, err.Error())
+		case message.MsgRoleSystemImmutable:
+			response.BadRequest(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	response.OK(w, nil, message.MsgRoleDeleted)
+}
+
+/*
+Metode untuk memberikan role kepada pengguna.
+Metode ini memvalidasi input dan menyimpan penugasan role melalui layanan.
+*/
+func (h *AdminHandler) AssignRole(w http.ResponseWriter, r *http.Request) {
+	log.Printf("AssignRole: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	var req RoleAssignmentRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("AssignRole: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	if err := h.service.AssignRole(req.UserID, req.UserType, req.RoleID); err != nil {
+		log.Printf("AssignRole: error: %v", err)
+		switch err.Error() {
+		case message.MsgUserIDRequired, message.MsgRoleIDRequired, message.MsgRoleInvalid, message.MsgRoleUserTypeMismatch:
+			response.BadRequest(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	log.Printf("AssignRole: assigned role %s to %s %s", req.RoleID, req.UserType, req.UserID)
+	response.OK(w, nil, message.MsgRoleAssigned)
+}
+
+/*
+Metode untuk mencabut role dari pengguna.
+Pengguna tanpa role eksplisit kembali memakai role bawaan jenis akunnya.
+*/
+func (h *AdminHandler) UnassignRole(w http.ResponseWriter, r *http.Request) {
+	log.Printf("UnassignRole: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	var req RoleAssignmentRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("UnassignRole: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	if err := h.service.UnassignRole(req.UserID, req.UserType, req.RoleID); err != nil {
+		log.Printf("UnassignRole: error: %v", err)
+		switch err.Error() {
+		case message.MsgRoleAssignmentNotFound:
+			response.Error(w, http.StatusNotFound, err.Error())
+		case message.MsgUserIDRequired, message.MsgRoleIDRequired:
+			response.BadRequest(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	response.OK(w, nil, message.MsgRoleUnassigned)
 }
 
 /*
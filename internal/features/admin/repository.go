@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"lalan-be/internal/model"
 )
@@ -67,6 +68,35 @@ func (r *adminRepository) FindByEmailAdminForLogin(email string) (*model.AdminMo
 	return &admin, nil
 }
 
+/*
+Metode untuk mengambil admin berdasarkan ID.
+Model admin dikembalikan jika ditemukan.
+*/
+func (r *adminRepository) GetDetailAdmin(id string) (*model.AdminModel, error) {
+	var admin model.AdminModel
+	query := `
+		SELECT
+			id,
+			email,
+			password_hash,
+			full_name,
+			created_at,
+			updated_at
+		FROM admin
+		WHERE id = $1
+	`
+	err := r.db.Get(&admin, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Printf("GetDetailAdmin: no admin found for id %s", id)
+			return nil, nil
+		}
+		log.Printf("GetDetailAdmin: error for id %s: %v", id, err)
+		return nil, err
+	}
+	return &admin, nil
+}
+
 /*
 Metode untuk membuat kategori baru di database.
 ID dan timestamp kategori dikembalikan setelah penyisipan.
@@ -173,13 +203,480 @@ func (r *adminRepository) FindCategoryByNameExceptID(name string, id string) (*m
 	return &category, nil
 }
 
+/*
+Metode untuk memperbarui hash password admin di database.
+Password diperbarui berdasarkan ID.
+*/
+func (r *adminRepository) UpdatePasswordAdmin(id string, passwordHash string) error {
+	query := `
+		UPDATE admin
+		SET
+			password_hash = $1,
+			updated_at = NOW()
+		WHERE id = $2
+	`
+	_, err := r.db.Exec(query, passwordHash, id)
+	if err != nil {
+		log.Printf("UpdatePasswordAdmin: error updating password for %s: %v", id, err)
+		return err
+	}
+	log.Printf("UpdatePasswordAdmin: updated password for ID %s", id)
+	return nil
+}
+
+/*
+Metode untuk mencari pemilik toko aktif berdasarkan ID.
+Data dasar hoster dikembalikan, atau nil jika hoster tidak ada atau sudah dihapus.
+*/
+func (r *adminRepository) FindHosterByID(id string) (*model.HosterModel, error) {
+	var hoster model.HosterModel
+	query := `
+		SELECT
+			id,
+			full_name,
+			store_name,
+			email,
+			created_at,
+			updated_at
+		FROM hoster
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	err := r.db.Get(&hoster, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindHosterByID: error querying hoster %s: %v", id, err)
+		return nil, err
+	}
+	return &hoster, nil
+}
+
+/*
+Metode untuk menghitung jumlah admin yang terdaftar.
+Jumlah admin dikembalikan.
+*/
+func (r *adminRepository) CountAdmins() (int, error) {
+	var count int
+	if err := r.db.Get(&count, `SELECT COUNT(*) FROM admin`); err != nil {
+		log.Printf("CountAdmins: error counting admins: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+/*
+Metode untuk menyimpan undangan admin baru.
+Undangan lama untuk email yang sama yang belum dipakai dibatalkan terlebih dahulu.
+*/
+func (r *adminRepository) CreateInvitation(invitation *model.AdminInvitationModel) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	revoke := `
+		UPDATE admin_invitations
+		SET revoked_at = NOW()
+		WHERE LOWER(email) = LOWER($1) AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+	if _, err := tx.Exec(revoke, invitation.Email); err != nil {
+		log.Printf("CreateInvitation: error revoking old invitations for %s: %v", invitation.Email, err)
+		return err
+	}
+
+	insert := `
+		INSERT INTO admin_invitations (
+			email,
+			token_hash,
+			invited_by,
+			expires_at
+		) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	if err := tx.QueryRow(insert, invitation.Email, invitation.TokenHash, invitation.InvitedBy, invitation.ExpiresAt).Scan(&invitation.ID, &invitation.CreatedAt); err != nil {
+		log.Printf("CreateInvitation: error inserting invitation for %s: %v", invitation.Email, err)
+		return err
+	}
+
+	log.Printf("CreateInvitation: created invitation %s for %s", invitation.ID, invitation.Email)
+	return tx.Commit()
+}
+
+/*
+Metode untuk menerima undangan dan membuat admin dalam satu transaksi.
+Nilai false dikembalikan jika undangan tidak valid, sudah dipakai, atau kedaluwarsa.
+*/
+func (r *adminRepository) AcceptInvitation(hash string, admin *model.AdminModel) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	consume := `
+		UPDATE admin_invitations
+		SET accepted_at = NOW()
+		WHERE token_hash = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > NOW()
+		RETURNING email
+	`
+	if err := tx.Get(&admin.Email, consume, hash); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		log.Printf("AcceptInvitation: error consuming invitation: %v", err)
+		return false, err
+	}
+
+	insert := `
+		INSERT INTO admin (
+			email,
+			password_hash,
+			full_name,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	if err := tx.QueryRow(insert, admin.Email, admin.PasswordHash, admin.FullName, admin.CreatedAt, admin.UpdatedAt).Scan(&admin.ID, &admin.CreatedAt, &admin.UpdatedAt); err != nil {
+		log.Printf("AcceptInvitation: error inserting admin %s: %v", admin.Email, err)
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	log.Printf("AcceptInvitation: created admin %s from invitation", admin.ID)
+	return true, nil
+}
+
+/*
+Metode untuk mengambil undangan admin yang masih menunggu.
+Daftar undangan yang belum dipakai, dibatalkan, atau kedaluwarsa dikembalikan.
+*/
+func (r *adminRepository) GetPendingInvitations() ([]*model.AdminInvitationModel, error) {
+	var invitations []*model.AdminInvitationModel
+	query := `
+		SELECT
+			id,
+			email,
+			invited_by,
+			expires_at,
+			accepted_at,
+			revoked_at,
+			created_at
+		FROM admin_invitations
+		WHERE accepted_at IS NULL AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC
+	`
+	if err := r.db.Select(&invitations, query); err != nil {
+		log.Printf("GetPendingInvitations: error querying invitations: %v", err)
+		return nil, err
+	}
+	return invitations, nil
+}
+
+/*
+Metode untuk mencari undangan admin yang masih berlaku berdasarkan hash token.
+Undangan dikembalikan tanpa dipakai, atau nil jika tidak valid.
+*/
+func (r *adminRepository) FindPendingInvitationByHash(hash string) (*model.AdminInvitationModel, error) {
+	var invitation model.AdminInvitationModel
+	query := `
+		SELECT
+			id,
+			email,
+			invited_by,
+			expires_at,
+			accepted_at,
+			revoked_at,
+			created_at
+		FROM admin_invitations
+		WHERE token_hash = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > NOW()
+	`
+	err := r.db.Get(&invitation, query, hash)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindPendingInvitationByHash: error querying invitation: %v", err)
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+/*
+Metode untuk membatalkan undangan admin yang belum dipakai.
+Nilai true dikembalikan jika undangan berhasil dibatalkan.
+*/
+func (r *adminRepository) RevokeInvitation(id string) (bool, error) {
+	query := `
+		UPDATE admin_invitations
+		SET revoked_at = NOW()
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+	`
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		log.Printf("RevokeInvitation: error revoking invitation %s: %v", id, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+/*
+Metode untuk mengambil semua role beserta permission-nya.
+Daftar role diurutkan berdasarkan jenis akun dan nama dikembalikan.
+*/
+func (r *adminRepository) GetAllRoles() ([]*model.RoleModel, error) {
+	var roles []*model.RoleModel
+	query := `
+		SELECT
+			r.id,
+			r.name,
+			r.user_type,
+			COALESCE(r.description, '') AS description,
+			r.is_system,
+			COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}') AS permissions,
+			r.created_at,
+			r.updated_at
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		GROUP BY r.id
+		ORDER BY r.user_type, r.name
+	`
+	if err := r.db.Select(&roles, query); err != nil {
+		log.Printf("GetAllRoles: error querying roles: %v", err)
+		return nil, err
+	}
+	return roles, nil
+}
+
+/*
+Metode untuk mencari role berdasarkan ID.
+Role beserta permission dikembalikan atau nil jika tidak ditemukan.
+*/
+func (r *adminRepository) FindRoleByID(id string) (*model.RoleModel, error) {
+	var role model.RoleModel
+	query := `
+		SELECT
+			r.id,
+			r.name,
+			r.user_type,
+			COALESCE(r.description, '') AS description,
+			r.is_system,
+			COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}') AS permissions,
+			r.created_at,
+			r.updated_at
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		WHERE r.id = $1
+		GROUP BY r.id
+	`
+	err := r.db.Get(&role, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindRoleByID: error querying role %s: %v", id, err)
+		return nil, err
+	}
+	return &role, nil
+}
+
+/*
+Metode untuk mencari role berdasarkan nama.
+Role dikembalikan atau nil jika tidak ditemukan.
+*/
+func (r *adminRepository) FindRoleByName(name string) (*model.RoleModel, error) {
+	var role model.RoleModel
+	query := `
+		SELECT
+			id,
+			name,
+			user_type,
+			COALESCE(description, '') AS description,
+			is_system,
+			created_at,
+			updated_at
+		FROM roles
+		WHERE LOWER(name) = LOWER($1)
+		LIMIT 1
+	`
+	err := r.db.Get(&role, query, name)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindRoleByName: error querying role %s: %v", name, err)
+		return nil, err
+	}
+	return &role, nil
+}
+
+/*
+Metode untuk membuat role baru beserta permission-nya.
+ID dan timestamp role dikembalikan setelah penyisipan.
+*/
+func (r *adminRepository) CreateRole(role *model.RoleModel) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insert := `
+		INSERT INTO roles (
+			name,
+			user_type,
+			description
+		) VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at
+	`
+	if err := tx.QueryRow(insert, role.Name, role.UserType, role.Description).Scan(&role.ID, &role.CreatedAt, &role.UpdatedAt); err != nil {
+		log.Printf("CreateRole: error inserting role %s: %v", role.Name, err)
+		return err
+	}
+
+	if err := replaceRolePermissions(tx, role.ID, role.Permissions); err != nil {
+		log.Printf("CreateRole: error inserting permissions for role %s: %v", role.ID, err)
+		return err
+	}
+
+	log.Printf("CreateRole: created role %s", role.ID)
+	return tx.Commit()
+}
+
+/*
+Metode untuk memperbarui role dan mengganti seluruh permission-nya.
+Nilai false dikembalikan jika role tidak ditemukan.
+*/
+func (r *adminRepository) UpdateRole(role *model.RoleModel) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	update := `
+		UPDATE roles
+		SET name = $1, description = $2
+		WHERE id = $3
+		RETURNING user_type, is_system, created_at, updated_at
+	`
+	err = tx.QueryRow(update, role.Name, role.Description, role.ID).Scan(&role.UserType, &role.IsSystem, &role.CreatedAt, &role.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		log.Printf("UpdateRole: error updating role %s: %v", role.ID, err)
+		return false, err
+	}
+
+	if err := replaceRolePermissions(tx, role.ID, role.Permissions); err != nil {
+		log.Printf("UpdateRole: error replacing permissions for role %s: %v", role.ID, err)
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	log.Printf("UpdateRole: updated role %s", role.ID)
+	return true, nil
+}
+
+/*
+Metode untuk menghapus role yang bukan bawaan sistem.
+Nilai true dikembalikan jika role berhasil dihapus.
+*/
+func (r *adminRepository) DeleteRole(id string) (bool, error) {
+	res, err := r.db.Exec(`DELETE FROM roles WHERE id = $1 AND is_system = FALSE`, id)
+	if err != nil {
+		log.Printf("DeleteRole: error deleting role %s: %v", id, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+/*
+Metode untuk memberikan role kepada pengguna.
+Nilai false dikembalikan jika role tidak berlaku untuk jenis akun pengguna.
+*/
+func (r *adminRepository) AssignRole(userID, userType, roleID string) (bool, error) {
+	query := `
+		INSERT INTO user_roles (user_id, user_type, role_id)
+		SELECT $1, $2, id FROM roles WHERE id = $3 AND user_type = $2
+		ON CONFLICT DO NOTHING
+	`
+	res, err := r.db.Exec(query, userID, userType, roleID)
+	if err != nil {
+		log.Printf("AssignRole: error assigning role %s to %s %s: %v", roleID, userType, userID, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		// Baris bisa saja sudah ada; cek apakah role memang cocok dengan jenis akun
+		var exists bool
+		if err := r.db.Get(&exists, `SELECT EXISTS (SELECT 1 FROM roles WHERE id = $1 AND user_type = $2)`, roleID, userType); err != nil {
+			return false, err
+		}
+		return exists, nil
+	}
+	return true, nil
+}
+
+/*
+Metode untuk mencabut role dari pengguna.
+Nilai true dikembalikan jika role berhasil dicabut.
+*/
+func (r *adminRepository) UnassignRole(userID, userType, roleID string) (bool, error) {
+	res, err := r.db.Exec(`DELETE FROM user_roles WHERE user_id = $1 AND user_type = $2 AND role_id = $3`, userID, userType, roleID)
+	if err != nil {
+		log.Printf("UnassignRole: error removing role %s from %s %s: %v", roleID, userType, userID, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
 /*
 Antarmuka untuk repositori admin.
-Antarmuka ini mendefinisikan metode untuk CRUD admin dan kategori.
+Antarmuka ini mendefinisikan metode untuk CRUD admin, kategori, dan role.
 */
 type AdminRepository interface {
 	CreateAdmin(admin *model.AdminModel) error
 	FindByEmailAdminForLogin(email string) (*model.AdminModel, error)
+	GetDetailAdmin(id string) (*model.AdminModel, error)
+	UpdatePasswordAdmin(id string, passwordHash string) error
+	CountAdmins() (int, error)
+	FindHosterByID(id string) (*model.HosterModel, error)
+	CreateInvitation(invitation *model.AdminInvitationModel) error
+	AcceptInvitation(hash string, admin *model.AdminModel) (bool, error)
+	FindPendingInvitationByHash(hash string) (*model.AdminInvitationModel, error)
+	GetPendingInvitations() ([]*model.AdminInvitationModel, error)
+	RevokeInvitation(id string) (bool, error)
+	GetAllRoles() ([]*model.RoleModel, error)
+	FindRoleByID(id string) (*model.RoleModel, error)
+	FindRoleByName(name string) (*model.RoleModel, error)
+	CreateRole(role *model.RoleModel) error
+	UpdateRole(role *model.RoleModel) (bool, error)
+	DeleteRole(id string) (bool, error)
+	AssignRole(userID, userType, roleID string) (bool, error)
+	UnassignRole(userID, userType, roleID string) (bool, error)
 	CreateCategory(category *model.CategoryModel) error
 	UpdateCategory(category *model.CategoryModel) error
 	DeleteCategory(id string) error
@@ -187,6 +684,23 @@ type AdminRepository interface {
 	FindCategoryByNameExceptID(name string, id string) (*model.CategoryModel, error)
 }
 
+/*
+Fungsi untuk mengganti seluruh permission sebuah role di dalam transaksi.
+Permission lama dihapus lalu permission baru disisipkan.
+*/
+func replaceRolePermissions(tx *sqlx.Tx, roleID string, permissions []string) error {
+	if _, err := tx.Exec(`DELETE FROM role_permissions WHERE role_id = $1`, roleID); err != nil {
+		return err
+	}
+	insert := `
+		INSERT INTO role_permissions (role_id, permission)
+		SELECT $1, UNNEST($2::text[])
+		ON CONFLICT DO NOTHING
+	`
+	_, err := tx.Exec(insert, roleID, pq.StringArray(permissions))
+	return err
+}
+
 /*
 Fungsi untuk membuat instance baru dari AdminRepository.
 Instance repositori dikembalikan.
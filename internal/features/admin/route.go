@@ -3,6 +3,7 @@ package admin
 import (
 	"github.com/gorilla/mux"
 
+	"lalan-be/internal/auth"
 	"lalan-be/internal/middleware"
 )
 
@@ -15,8 +16,17 @@ func SetupAdminRoutes(router *mux.Router, h *AdminHandler) {
 	admin := router.PathPrefix("/api/v1/admin").Subrouter()
 
 	// Setup public routes
-	admin.HandleFunc("/register", h.CreateAdmin).Methods("POST")
+	admin.HandleFunc("/invitations/accept", h.AcceptInvitation).Methods("POST")
 	admin.HandleFunc("/login", h.LoginAdmin).Methods("POST")
+	admin.HandleFunc("/auth/refresh", h.RefreshTokenAdmin).Methods("POST")
+	admin.HandleFunc("/auth/forgot-password", h.ForgotPasswordAdmin).Methods("POST")
+	admin.HandleFunc("/auth/reset-password", h.ResetPasswordAdmin).Methods("POST")
+	admin.HandleFunc("/auth/mfa/verify", h.VerifyMFAAdmin).Methods("POST")
+
+	// Setup optional auth routes
+	optional := admin.PathPrefix("").Subrouter()
+	optional.Use(middleware.OptionalJWTMiddleware)
+	optional.HandleFunc("/auth/logout", h.LogoutAdmin).Methods("POST")
 
 	// Setup protected routes
 	protected := admin.PathPrefix("").Subrouter()
@@ -27,8 +37,41 @@ func SetupAdminRoutes(router *mux.Router, h *AdminHandler) {
 	// Middleware admin only
 	protected.Use(middleware.Admin)
 
+	// Endpoint protected yang tetap bisa diakses sebelum MFA aktif
+	protected.HandleFunc("/auth/logout-all", h.LogoutAllAdmin).Methods("POST")
+	protected.HandleFunc("/auth/sessions", h.GetSessionsAdmin).Methods("GET")
+	protected.HandleFunc("/auth/sessions", h.TerminateSessionAdmin).Methods("DELETE")
+	protected.HandleFunc("/auth/mfa/enroll", h.EnrollMFAAdmin).Methods("POST")
+	protected.HandleFunc("/auth/mfa/confirm", h.ConfirmMFAAdmin).Methods("POST")
+
+	// Middleware MFA wajib sesuai ADMIN_REQUIRE_MFA
+	secured := protected.PathPrefix("").Subrouter()
+	secured.Use(middleware.RequireAdminMFA)
+
 	// Endpoint protected
-	protected.HandleFunc("/category/create", h.CreateCategory).Methods("POST")
-	protected.HandleFunc("/category/update", h.UpdateCategory).Methods("PUT")
-	protected.HandleFunc("/category/delete", h.DeleteCategory).Methods("DELETE")
+	secured.HandleFunc("/auth/change-password", h.ChangePasswordAdmin).Methods("POST")
+	secured.HandleFunc("/auth/mfa/disable", h.DisableMFAAdmin).Methods("POST")
+	secured.HandleFunc("/auth/mfa/recovery-codes", h.RegenerateRecoveryCodesAdmin).Methods("POST")
+
+	// Endpoint protected dengan pemeriksaan permission
+	secured.Handle("/users/revoke-tokens", middleware.RequireFunc(h.RevokeUserTokens, auth.PermUserRevoke)).Methods("POST")
+	secured.Handle("/security/lockouts", middleware.RequireFunc(h.GetLoginBlocks, auth.PermSecurityRead)).Methods("GET")
+	secured.Handle("/security/lockouts", middleware.RequireFunc(h.ClearLoginBlock, auth.PermSecurityWrite)).Methods("DELETE")
+	secured.Handle("/invitations", middleware.RequireFunc(h.InviteAdmin, auth.PermAdminInvite)).Methods("POST")
+	secured.Handle("/invitations", middleware.RequireFunc(h.GetPendingInvitations, auth.PermAdminRead)).Methods("GET")
+	secured.Handle("/invitations", middleware.RequireFunc(h.RevokeInvitation, auth.PermAdminInvite)).Methods("DELETE")
+	secured.Handle("/impersonations", middleware.RequireFunc(h.StartImpersonation, auth.PermImpersonate)).Methods("POST")
+	secured.Handle("/impersonations", middleware.RequireFunc(h.GetImpersonations, auth.PermAuditRead)).Methods("GET")
+	secured.Handle("/impersonations", middleware.RequireFunc(h.EndImpersonation, auth.PermImpersonate)).Methods("DELETE")
+	secured.Handle("/impersonations/logs", middleware.RequireFunc(h.GetImpersonationLogs, auth.PermAuditRead)).Methods("GET")
+	secured.Handle("/permissions", middleware.RequireFunc(h.GetPermissionCatalog, auth.PermRoleRead)).Methods("GET")
+	secured.Handle("/roles", middleware.RequireFunc(h.GetAllRoles, auth.PermRoleRead)).Methods("GET")
+	secured.Handle("/roles", middleware.RequireFunc(h.CreateRole, auth.PermRoleWrite)).Methods("POST")
+	secured.Handle("/roles", middleware.RequireFunc(h.UpdateRole, auth.PermRoleWrite)).Methods("PUT")
+	secured.Handle("/roles", middleware.RequireFunc(h.DeleteRole, auth.PermRoleWrite)).Methods("DELETE")
+	secured.Handle("/roles/assign", middleware.RequireFunc(h.AssignRole, auth.PermRoleWrite)).Methods("POST")
+	secured.Handle("/roles/unassign", middleware.RequireFunc(h.UnassignRole, auth.PermRoleWrite)).Methods("POST")
+	secured.Handle("/category/create", middleware.RequireFunc(h.CreateCategory, auth.PermCategoryWrite)).Methods("POST")
+	secured.Handle("/category/update", middleware.RequireFunc(h.UpdateCategory, auth.PermCategoryWrite)).Methods("PUT")
+	secured.Handle("/category/delete", middleware.RequireFunc(h.DeleteCategory, auth.PermCategoryWrite)).Methods("DELETE")
 }
@@ -1,17 +1,20 @@
 package admin
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
+	"lalan-be/internal/auth"
 	"lalan-be/internal/config"
 	"lalan-be/internal/middleware"
 	"lalan-be/internal/model"
+	"lalan-be/pkg/mailer"
 	"lalan-be/pkg/message"
 )
 
@@ -25,6 +28,9 @@ type AdminResponse struct {
 	RefreshToken string `json:"refresh_token"`
 	TokenType    string `json:"token_type"`
 	ExpiresIn    int    `json:"expires_in"`
+	// Terisi jika login masih menunggu kode MFA
+	MFARequired    bool   `json:"mfa_required,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
 }
 
 /*
@@ -32,81 +38,675 @@ Struktur untuk layanan admin.
 Struktur ini menyediakan logika bisnis untuk operasi admin.
 */
 type adminService struct {
-	repo AdminRepository
+	repo          AdminRepository
+	tokens        auth.TokenIssuer
+	revocation    auth.RevocationStore
+	sessions      auth.SessionStore
+	reset         auth.PasswordResetService
+	passwords     auth.PasswordPolicy
+	guard         auth.LoginGuard
+	mfa           auth.MFAService
+	permissions   auth.PermissionStore
+	impersonation auth.ImpersonationService
+	mailer        mailer.Mailer
 }
 
 /*
-Metode untuk menghasilkan token JWT untuk admin.
-Respons token dikembalikan jika berhasil.
+Metode untuk menghasilkan access token JWT untuk admin.
+Respons token yang terikat ke sesi tanpa refresh token dikembalikan jika berhasil.
 */
-func (s *adminService) generateTokenAdmin(userID string) (*AdminResponse, error) {
-	exp := time.Now().Add(1 * time.Hour)
-
-	claims := middleware.Claims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   userID,
-			ExpiresAt: jwt.NewNumericDate(exp),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-		Role: "admin",
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	accessToken, err := token.SignedString(config.GetJWTSecret())
+func (s *adminService) generateAccessTokenAdmin(userID, sessionID string, mfa bool) (*AdminResponse, error) {
+	claims := auth.NewClaims(userID, "admin")
+	claims.MFA = mfa
+	claims.SessionID = sessionID
+	accessToken, expiresIn, err := s.tokens.IssueAccessToken(claims)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AdminResponse{
-		ID:           userID,
-		AccessToken:  accessToken,
-		RefreshToken: uuid.New().String(),
-		TokenType:    "Bearer",
-		ExpiresIn:    3600,
+		ID:          userID,
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   expiresIn,
 	}, nil
 }
 
+/*
+Metode untuk menghasilkan pasangan token untuk admin dalam sesi baru.
+Respons access token dan refresh token tersimpan dikembalikan jika berhasil.
+*/
+func (s *adminService) generateTokenAdmin(userID string, mfa bool, client auth.ClientInfo) (*AdminResponse, error) {
+	refreshToken, sessionID, err := s.tokens.IssueRefreshToken(userID, "admin", client)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.generateAccessTokenAdmin(userID, sessionID, mfa)
+	if err != nil {
+		return nil, err
+	}
+	resp.RefreshToken = refreshToken
+	return resp, nil
+}
+
 /*
 Metode untuk mengautentikasi admin dengan email dan password.
 Respons token dikembalikan jika berhasil.
 */
-func (s *adminService) LoginAdmin(email, password string) (*AdminResponse, error) {
+func (s *adminService) LoginAdmin(email, password string, client auth.ClientInfo) (*AdminResponse, error) {
+	// Tolak lebih awal jika email atau IP sedang diblokir
+	if err := s.guard.Check("admin", email, client); err != nil {
+		return nil, err
+	}
+
 	admin, err := s.repo.FindByEmailAdminForLogin(email)
-	if err != nil || admin == nil {
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil || bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(password)) != nil {
+		s.guard.RecordFailure("admin", email, client)
 		return nil, errors.New("invalid credentials")
 	}
 
-	if bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(password)) != nil {
-		return nil, errors.New("invalid credentials")
+	// Login dengan MFA aktif dilanjutkan melalui token tantangan
+	enabled, err := s.mfa.IsEnabled(admin.ID, "admin")
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		challenge, expiresIn, err := s.mfa.IssueChallenge(admin.ID, "admin", admin.Email)
+		if err != nil {
+			return nil, err
+		}
+		return &AdminResponse{ID: admin.ID, MFARequired: true, ChallengeToken: challenge, ExpiresIn: expiresIn}, nil
 	}
 
-	return s.generateTokenAdmin(admin.ID)
+	s.guard.RecordSuccess("admin", email)
+	return s.generateTokenAdmin(admin.ID, false, client)
 }
 
 /*
-Metode untuk membuat admin baru dengan hashing password.
-Admin berhasil dibuat atau error dikembalikan.
+Metode untuk menyelesaikan login admin dengan kode MFA.
+Pasangan token dikembalikan jika token tantangan dan kode OTP atau kode pemulihan valid.
 */
-func (s *adminService) CreateAdmin(admin *model.AdminModel) error {
-	hash, err := bcrypt.GenerateFromPassword([]byte(admin.PasswordHash), bcrypt.DefaultCost)
+func (s *adminService) VerifyMFAAdmin(challengeToken, code string, client auth.ClientInfo) (*AdminResponse, error) {
+	challenge, err := s.mfa.ParseChallenge(challengeToken, "admin")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.Check("admin", challenge.Email, client); err != nil {
+		return nil, err
+	}
+
+	if err := s.mfa.Verify(challenge.UserID, "admin", code); err != nil {
+		if errors.Is(err, auth.ErrMFACodeInvalid) {
+			s.guard.RecordFailure("admin", challenge.Email, client)
+		}
+		return nil, err
+	}
+
+	s.guard.RecordSuccess("admin", challenge.Email)
+	return s.generateTokenAdmin(challenge.UserID, true, client)
+}
+
+/*
+Metode untuk merotasi refresh token admin.
+Pasangan token baru dikembalikan jika refresh token valid.
+*/
+func (s *adminService) RefreshTokenAdmin(refreshToken string) (*AdminResponse, error) {
+	next, raw, err := s.tokens.RotateRefreshToken(refreshToken, "admin")
+	if err != nil {
+		return nil, err
+	}
+
+	// Status MFA mengikuti pengguna karena semua sesi dicabut saat MFA diaktifkan
+	mfa, err := s.mfa.IsEnabled(next.UserID, "admin")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.generateAccessTokenAdmin(next.UserID, next.FamilyID, mfa)
 	if err != nil {
+		return nil, err
+	}
+	resp.RefreshToken = raw
+	return resp, nil
+}
+
+/*
+Metode untuk logout admin.
+Refresh token beserta keluarganya dicabut.
+*/
+func (s *adminService) LogoutAdmin(ctx context.Context, refreshToken string) error {
+	if _, err := s.tokens.RevokeRefreshToken(refreshToken, "admin"); err != nil {
 		return err
 	}
-	admin.PasswordHash = string(hash)
-	admin.CreatedAt = time.Now()
-	admin.UpdatedAt = time.Now()
 
-	err = s.repo.CreateAdmin(admin)
+	// Cabut access token yang sedang dipakai jika disertakan
+	claims := middleware.GetClaims(ctx)
+	if claims != nil && claims.Role == "admin" && claims.ExpiresAt != nil {
+		return s.revocation.RevokeToken(claims.ID, claims.Subject, claims.Role, claims.ExpiresAt.Time)
+	}
+	return nil
+}
+
+/*
+Metode untuk meminta tautan reset password admin.
+Tautan dikirim jika email terdaftar tanpa membocorkan keberadaan akun.
+*/
+func (s *adminService) ForgotPasswordAdmin(email string) error {
+	admin, err := s.repo.FindByEmailAdminForLogin(strings.TrimSpace(email))
+	if err != nil {
+		return err
+	}
+	if admin == nil {
+		return nil
+	}
+	return s.reset.SendResetLink(admin.ID, "admin", admin.Email, admin.FullName)
+}
+
+/*
+Metode untuk mengatur ulang password admin dengan token reset.
+Password diperbarui dan semua sesi admin dicabut jika token valid.
+*/
+func (s *adminService) ResetPasswordAdmin(token, password string) error {
+	userID, err := s.reset.Peek(token, "admin")
+	if err != nil {
+		return err
+	}
+	admin, err := s.repo.GetDetailAdmin(userID)
+	if err != nil {
+		return err
+	}
+	if admin == nil {
+		return auth.ErrResetTokenInvalid
+	}
+	if err := s.passwords.Validate(password, admin.Email, admin.FullName); err != nil {
+		return err
+	}
+	if _, err := s.reset.Consume(token, "admin"); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New(message.MsgFailedToHashPassword)
+	}
+	if err := s.repo.UpdatePasswordAdmin(userID, string(hash)); err != nil {
+		return err
+	}
+
+	return s.revocation.RevokeAllForUser(userID, "admin")
+}
+
+/*
+Metode untuk memulai pendaftaran MFA admin.
+Secret TOTP dan URI provisioning untuk kode QR dikembalikan.
+*/
+func (s *adminService) EnrollMFAAdmin(ctx context.Context) (*auth.MFAEnrollment, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	admin, err := s.repo.GetDetailAdmin(userID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, errors.New(message.MsgUnauthorized)
+	}
+	return s.mfa.BeginEnrollment(userID, "admin", admin.Email)
+}
+
+/*
+Metode untuk mengonfirmasi pendaftaran MFA admin.
+Kode pemulihan dan pasangan token baru dikembalikan setelah semua sesi lama dicabut.
+*/
+func (s *adminService) ConfirmMFAAdmin(ctx context.Context, code string, client auth.ClientInfo) ([]string, *AdminResponse, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, nil, errors.New("invalid token claims")
+	}
+	codes, err := s.mfa.ConfirmEnrollment(userID, "admin", code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sesi yang dibuat hanya dengan password tidak boleh naik level menjadi sesi MFA
+	if err := s.revocation.RevokeAllForUser(userID, "admin"); err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.generateTokenAdmin(userID, true, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return codes, resp, nil
+}
+
+/*
+Metode untuk menonaktifkan MFA admin.
+MFA dinonaktifkan jika kode OTP atau kode pemulihan valid.
+*/
+func (s *adminService) DisableMFAAdmin(ctx context.Context, code string) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	if config.AdminRequiresMFA() {
+		return errors.New(message.MsgMFARequiredByPolicy)
+	}
+	return s.mfa.Disable(userID, "admin", code)
+}
+
+/*
+Metode untuk membuat ulang kode pemulihan MFA admin.
+Kode pemulihan baru dikembalikan jika kode OTP valid.
+*/
+func (s *adminService) RegenerateRecoveryCodesAdmin(ctx context.Context, code string) ([]string, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	return s.mfa.RegenerateRecoveryCodes(userID, "admin", code)
+}
+
+/*
+Metode untuk logout admin dari semua perangkat.
+Semua access token dan refresh token milik admin dicabut.
+*/
+func (s *adminService) LogoutAllAdmin(ctx context.Context) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	return s.revocation.RevokeAllForUser(userID, "admin")
+}
+
+/*
+Metode untuk mengganti password admin yang sedang login.
+Password lama diverifikasi, password baru divalidasi terhadap kebijakan, lalu semua sesi dicabut.
+*/
+func (s *adminService) ChangePasswordAdmin(ctx context.Context, currentPassword, newPassword string) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	if currentPassword == "" {
+		return errors.New(message.MsgPasswordCurrentRequired)
+	}
+	admin, err := s.repo.GetDetailAdmin(userID)
+	if err != nil {
+		return err
+	}
+	if admin == nil {
+		return errors.New(message.MsgUnauthorized)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(currentPassword)) != nil {
+		return errors.New(message.MsgPasswordCurrentInvalid)
+	}
+	if currentPassword == newPassword {
+		return errors.New(message.MsgPasswordUnchanged)
+	}
+	if err := s.passwords.Validate(newPassword, admin.Email, admin.FullName); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New(message.MsgFailedToHashPassword)
+	}
+	if err := s.repo.UpdatePasswordAdmin(userID, string(hash)); err != nil {
+		return err
+	}
+
+	return s.revocation.RevokeAllForUser(userID, "admin")
+}
+
+/*
+Metode untuk mengambil sesi login aktif milik admin.
+Daftar sesi dikembalikan dengan sesi permintaan saat ini ditandai.
+*/
+func (s *adminService) GetSessionsAdmin(ctx context.Context) ([]*model.SessionModel, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	var currentSessionID string
+	if claims := middleware.GetClaims(ctx); claims != nil {
+		currentSessionID = claims.SessionID
+	}
+	return s.sessions.List(userID, "admin", currentSessionID)
+}
+
+/*
+Metode untuk mengakhiri satu sesi login milik admin dari jarak jauh.
+Refresh token sesi dicabut dan access token sesi tersebut ditolak.
+*/
+func (s *adminService) TerminateSessionAdmin(ctx context.Context, sessionID string) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	return s.sessions.Terminate(userID, "admin", sessionID)
+}
+
+/*
+Metode untuk mengundang admin baru melalui email.
+Undangan sekali pakai disimpan dan tautannya dikirim ke email tujuan.
+*/
+func (s *adminService) InviteAdmin(ctx context.Context, email string) (*model.AdminInvitationModel, error) {
+	invitedBy, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || invitedBy == "" {
+		return nil, errors.New("invalid token claims")
+	}
+
+	email = strings.TrimSpace(email)
+	existing, err := s.repo.FindByEmailAdminForLogin(email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New(message.MsgAdminEmailExists)
+	}
+
+	raw, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	ttl := config.GetAdminInvitationTTL()
+	invitation := &model.AdminInvitationModel{
+		Email:     email,
+		TokenHash: auth.HashToken(raw),
+		InvitedBy: invitedBy,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.repo.CreateInvitation(invitation); err != nil {
+		return nil, err
+	}
+
+	link := fmt.Sprintf("%s/admin/accept-invitation?token=%s", config.GetFrontendURL(), url.QueryEscape(raw))
+	body := fmt.Sprintf("Hi,\n\nYou have been invited to become an administrator on Lalan.\nOpen the link below within %d hours to set up your account:\n\n%s\n\nIf you did not expect this invitation, you can ignore this email.\n",
+		int(ttl.Hours()), link)
+	if err := s.mailer.Send(mailer.Message{
+		To:      email,
+		Subject: "You are invited to Lalan admin",
+		Body:    body,
+	}); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+/*
+Metode untuk menerima undangan admin.
+Akun admin dibuat dengan email dari undangan jika token valid.
+*/
+func (s *adminService) AcceptInvitation(token, fullName, password string) (*model.AdminModel, error) {
+	if strings.TrimSpace(token) == "" {
+		return nil, errors.New(message.MsgAdminInvitationInvalid)
+	}
+	invitation, err := s.repo.FindPendingInvitationByHash(auth.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if invitation == nil {
+		return nil, errors.New(message.MsgAdminInvitationInvalid)
+	}
+	if err := s.passwords.Validate(password, invitation.Email, fullName); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.New(message.MsgFailedToHashPassword)
+	}
+	admin := &model.AdminModel{
+		FullName:     strings.TrimSpace(fullName),
+		PasswordHash: string(hash),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	accepted, err := s.repo.AcceptInvitation(auth.HashToken(token), admin)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate") {
-			return errors.New(message.MsgHosterEmailExists)
+			return nil, errors.New(message.MsgAdminEmailExists)
 		}
+		return nil, err
+	}
+	if !accepted {
+		return nil, errors.New(message.MsgAdminInvitationInvalid)
+	}
+
+	return admin, nil
+}
+
+/*
+Metode untuk mengambil undangan admin yang masih menunggu.
+Daftar undangan dikembalikan.
+*/
+func (s *adminService) GetPendingInvitations() ([]*model.AdminInvitationModel, error) {
+	return s.repo.GetPendingInvitations()
+}
+
+/*
+Metode untuk membatalkan undangan admin.
+Undangan dibatalkan atau error dikembalikan jika tidak ditemukan.
+*/
+func (s *adminService) RevokeInvitation(id string) error {
+	revoked, err := s.repo.RevokeInvitation(id)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New(message.MsgAdminInvitationNotFound)
+	}
+	return nil
+}
+
+/*
+Metode untuk mencabut semua token milik pengguna tertentu.
+Pengguna dengan role dan ID tersebut keluar dari semua perangkat.
+*/
+func (s *adminService) RevokeUserTokens(userID, role string) error {
+	if strings.TrimSpace(userID) == "" {
+		return errors.New(message.MsgUserIDRequired)
+	}
+	if role != "admin" && role != "hoster" && role != "customer" {
+		return errors.New(message.MsgRoleInvalid)
+	}
+	return s.revocation.RevokeAllForUser(userID, role)
+}
+
+/*
+Metode untuk mengambil daftar blokir login yang masih aktif.
+Daftar backoff dan lockout per email atau IP dikembalikan.
+*/
+func (s *adminService) GetLoginBlocks() ([]*model.LoginAttemptModel, error) {
+	return s.guard.GetActiveBlocks()
+}
+
+/*
+Metode untuk menghapus blokir login.
+Email atau IP yang diblokir dapat mencoba login kembali.
+*/
+func (s *adminService) ClearLoginBlock(id string) error {
+	return s.guard.ClearBlock(id)
+}
+
+/*
+Metode untuk mengambil semua role otorisasi.
+Daftar role beserta permission-nya dikembalikan.
+*/
+func (s *adminService) GetAllRoles() ([]*model.RoleModel, error) {
+	return s.repo.GetAllRoles()
+}
+
+/*
+Metode untuk mengambil katalog permission yang dikenal.
+Daftar permission yang dapat diberikan ke role dikembalikan.
+*/
+func (s *adminService) GetPermissionCatalog() []string {
+	return auth.KnownPermissions
+}
+
+/*
+Metode untuk membuat role baru.
+Role dibuat atau error dikembalikan jika nama, jenis akun, atau permission tidak valid.
+*/
+func (s *adminService) CreateRole(role *model.RoleModel) error {
+	role.Name = strings.TrimSpace(role.Name)
+	if role.Name == "" {
+		return errors.New(message.MsgRoleNameRequired)
+	}
+	if role.UserType != "admin" && role.UserType != "hoster" && role.UserType != "customer" {
+		return errors.New(message.MsgRoleInvalid)
+	}
+	if err := validatePermissions(role.Permissions); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindRoleByName(role.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.New(message.MsgRoleNameExists)
+	}
+
+	return s.repo.CreateRole(role)
+}
+
+/*
+Metode untuk memperbarui nama, deskripsi, dan permission role.
+Cache permission dikosongkan agar perubahan langsung berlaku.
+*/
+func (s *adminService) UpdateRole(role *model.RoleModel) error {
+	role.Name = strings.TrimSpace(role.Name)
+	if role.ID == "" {
+		return errors.New(message.MsgRoleIDRequired)
+	}
+	if role.Name == "" {
+		return errors.New(message.MsgRoleNameRequired)
+	}
+	if err := validatePermissions(role.Permissions); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindRoleByName(role.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != role.ID {
+		return errors.New(message.MsgRoleNameExists)
+	}
+
+	updated, err := s.repo.UpdateRole(role)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New(message.MsgRoleNotFound)
+	}
+
+	s.invalidatePermissions("", "")
+	return nil
+}
+
+/*
+Metode untuk menghapus role yang bukan bawaan sistem.
+Pengguna yang memakai role tersebut kehilangan permission-nya.
+*/
+func (s *adminService) DeleteRole(id string) error {
+	if id == "" {
+		return errors.New(message.MsgRoleIDRequired)
+	}
+	role, err := s.repo.FindRoleByID(id)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return errors.New(message.MsgRoleNotFound)
+	}
+	if role.IsSystem {
+		return errors.New(message.MsgRoleSystemImmutable)
+	}
+
+	if _, err := s.repo.DeleteRole(id); err != nil {
+		return err
+	}
+
+	s.invalidatePermissions("", "")
+	return nil
+}
+
+/*
+Metode untuk memberikan role kepada pengguna.
+Role diberikan atau error dikembalikan jika tidak cocok dengan jenis akun.
+*/
+func (s *adminService) AssignRole(userID, userType, roleID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return errors.New(message.MsgUserIDRequired)
+	}
+	if roleID == "" {
+		return errors.New(message.MsgRoleIDRequired)
+	}
+	if userType != "admin" && userType != "hoster" && userType != "customer" {
+		return errors.New(message.MsgRoleInvalid)
+	}
+
+	assigned, err := s.repo.AssignRole(userID, userType, roleID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		return errors.New(message.MsgRoleUserTypeMismatch)
+	}
+
+	s.invalidatePermissions(userID, userType)
+	return nil
+}
+
+/*
+Metode untuk mencabut role dari pengguna.
+Role dicabut atau error dikembalikan jika pengguna tidak memilikinya.
+*/
+func (s *adminService) UnassignRole(userID, userType, roleID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return errors.New(message.MsgUserIDRequired)
+	}
+	if roleID == "" {
+		return errors.New(message.MsgRoleIDRequired)
+	}
+
+	removed, err := s.repo.UnassignRole(userID, userType, roleID)
+	if err != nil {
 		return err
 	}
+	if !removed {
+		return errors.New(message.MsgRoleAssignmentNotFound)
+	}
 
+	s.invalidatePermissions(userID, userType)
 	return nil
 }
 
+/*
+Metode untuk mengosongkan cache permission setelah perubahan role.
+Cache satu pengguna atau seluruh cache dikosongkan jika userID kosong.
+*/
+func (s *adminService) invalidatePermissions(userID, userType string) {
+	if s.permissions == nil {
+		return
+	}
+	if userID == "" {
+		s.permissions.InvalidateAll()
+		return
+	}
+	s.permissions.Invalidate(userID, userType)
+}
+
 /*
 Metode untuk membuat kategori baru.
 Kategori berhasil dibuat atau error dikembalikan.
@@ -143,22 +743,147 @@ func (s *adminService) DeleteCategory(id string) error {
 	return s.repo.DeleteCategory(id)
 }
 
+/*
+Metode untuk memulai impersonasi pemilik toko oleh admin yang sedang login.
+Token impersonasi singkat dikembalikan dan setiap permintaan dengan token tersebut dicatat ke jejak audit.
+*/
+func (s *adminService) StartImpersonation(ctx context.Context, hosterID, reason string) (*auth.ImpersonationGrant, error) {
+	adminID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || adminID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	hoster, err := s.repo.FindHosterByID(strings.TrimSpace(hosterID))
+	if err != nil {
+		return nil, err
+	}
+	if hoster == nil {
+		return nil, errors.New(message.MsgHosterNotFound)
+	}
+	return s.impersonation.Start(adminID, hoster.ID, hoster.ID, strings.TrimSpace(reason))
+}
+
+/*
+Metode untuk mengakhiri impersonasi sebelum masa berlakunya habis.
+Token impersonasi dicabut dan admin yang mengakhiri dicatat.
+*/
+func (s *adminService) EndImpersonation(ctx context.Context, id string) error {
+	adminID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || adminID == "" {
+		return errors.New("invalid token claims")
+	}
+	return s.impersonation.End(id, adminID)
+}
+
+/*
+Metode untuk mengambil sesi impersonasi terbaru.
+Daftar sesi dikembalikan, dapat difilter berdasarkan hoster.
+*/
+func (s *adminService) GetImpersonations(hosterID string) ([]*model.ImpersonationModel, error) {
+	return s.impersonation.List(hosterID)
+}
+
+/*
+Metode untuk mengambil jejak audit sebuah sesi impersonasi.
+Daftar permintaan yang dilakukan selama impersonasi dikembalikan.
+*/
+func (s *adminService) GetImpersonationLogs(id string) ([]*model.ImpersonationLogModel, error) {
+	return s.impersonation.Logs(id)
+}
+
 /*
 Antarmuka untuk layanan admin.
 Antarmuka ini mendefinisikan metode untuk operasi admin.
 */
 type AdminService interface {
-	CreateAdmin(*model.AdminModel) error
-	LoginAdmin(email, password string) (*AdminResponse, error)
+	InviteAdmin(ctx context.Context, email string) (*model.AdminInvitationModel, error)
+	AcceptInvitation(token, fullName, password string) (*model.AdminModel, error)
+	GetPendingInvitations() ([]*model.AdminInvitationModel, error)
+	RevokeInvitation(id string) error
+	LoginAdmin(email, password string, client auth.ClientInfo) (*AdminResponse, error)
+	RefreshTokenAdmin(refreshToken string) (*AdminResponse, error)
+	VerifyMFAAdmin(challengeToken, code string, client auth.ClientInfo) (*AdminResponse, error)
+	EnrollMFAAdmin(ctx context.Context) (*auth.MFAEnrollment, error)
+	ConfirmMFAAdmin(ctx context.Context, code string, client auth.ClientInfo) ([]string, *AdminResponse, error)
+	DisableMFAAdmin(ctx context.Context, code string) error
+	RegenerateRecoveryCodesAdmin(ctx context.Context, code string) ([]string, error)
+	LogoutAdmin(ctx context.Context, refreshToken string) error
+	LogoutAllAdmin(ctx context.Context) error
+	ChangePasswordAdmin(ctx context.Context, currentPassword, newPassword string) error
+	GetSessionsAdmin(ctx context.Context) ([]*model.SessionModel, error)
+	TerminateSessionAdmin(ctx context.Context, sessionID string) error
+	ForgotPasswordAdmin(email string) error
+	ResetPasswordAdmin(token, password string) error
+	RevokeUserTokens(userID, role string) error
+	GetLoginBlocks() ([]*model.LoginAttemptModel, error)
+	ClearLoginBlock(id string) error
+	GetAllRoles() ([]*model.RoleModel, error)
+	GetPermissionCatalog() []string
+	CreateRole(role *model.RoleModel) error
+	UpdateRole(role *model.RoleModel) error
+	DeleteRole(id string) error
+	AssignRole(userID, userType, roleID string) error
+	UnassignRole(userID, userType, roleID string) error
 	CreateCategory(*model.CategoryModel) error
 	UpdateCategory(*model.CategoryModel) error
 	DeleteCategory(id string) error
+	StartImpersonation(ctx context.Context, hosterID, reason string) (*auth.ImpersonationGrant, error)
+	EndImpersonation(ctx context.Context, id string) error
+	GetImpersonations(hosterID string) ([]*model.ImpersonationModel, error)
+	GetImpersonationLogs(id string) ([]*model.ImpersonationLogModel, error)
+}
+
+/*
+Fungsi untuk memvalidasi daftar permission sebuah role.
+Error dikembalikan jika ada permission yang tidak dikenal.
+*/
+func validatePermissions(permissions []string) error {
+	for _, permission := range permissions {
+		if !auth.IsKnownPermission(permission) {
+			return errors.New(message.MsgRolePermissionInvalid)
+		}
+	}
+	return nil
+}
+
+/*
+Fungsi untuk membuat admin pertama dengan hashing password.
+Fungsi ini hanya membutuhkan repositori admin dan kebijakan password sehingga dapat dipanggil dari CLI tanpa layanan autentikasi lain; admin dibuat hanya jika belum ada admin sama sekali, selain itu error dikembalikan.
+*/
+func BootstrapAdmin(repo AdminRepository, passwords auth.PasswordPolicy, admin *model.AdminModel) error {
+	count, err := repo.CountAdmins()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New(message.MsgAdminAlreadyBootstrapped)
+	}
+	if err := passwords.Validate(admin.PasswordHash, admin.Email, admin.FullName); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(admin.PasswordHash), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New(message.MsgFailedToHashPassword)
+	}
+	admin.PasswordHash = string(hash)
+	admin.CreatedAt = time.Now()
+	admin.UpdatedAt = time.Now()
+
+	err = repo.CreateAdmin(admin)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return errors.New(message.MsgAdminEmailExists)
+		}
+		return err
+	}
+
+	return nil
 }
 
 /*
 Fungsi untuk membuat instance baru dari AdminService.
 Instance layanan dikembalikan.
 */
-func NewAdminService(repo AdminRepository) AdminService {
-	return &adminService{repo: repo}
+func NewAdminService(repo AdminRepository, tokens auth.TokenIssuer, revocation auth.RevocationStore, sessions auth.SessionStore, reset auth.PasswordResetService, passwords auth.PasswordPolicy, guard auth.LoginGuard, mfa auth.MFAService, permissions auth.PermissionStore, impersonation auth.ImpersonationService, m mailer.Mailer) AdminService {
+	return &adminService{repo: repo, tokens: tokens, revocation: revocation, sessions: sessions, reset: reset, passwords: passwords, guard: guard, mfa: mfa, permissions: permissions, impersonation: impersonation, mailer: m}
 }
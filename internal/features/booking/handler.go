@@ -0,0 +1,699 @@
+package booking
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/model"
+	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk format tanggal booking.
+Konstanta ini digunakan untuk membaca tanggal mulai dan selesai sewa.
+*/
+const dateLayout = "2006-01-02"
+
+/*
+Struktur untuk handler booking.
+Struktur ini menangani permintaan terkait booking customer dan hoster.
+*/
+type BookingHandler struct {
+	service BookingService
+}
+
+/*
+Struktur untuk permintaan pembuatan booking.
+Struktur ini berisi item, jumlah unit, dan rentang tanggal sewa dalam format YYYY-MM-DD.
+*/
+type BookingRequest struct {
+	ItemID    string `json:"item_id"`
+	Quantity  int    `json:"quantity"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Notes     string `json:"notes"`
+}
+
+/*
+Struktur untuk permintaan hold stok.
+Struktur ini berisi item, jumlah unit, dan rentang tanggal sewa yang ditahan dalam format YYYY-MM-DD.
+*/
+type StockHoldRequest struct {
+	ItemID    string `json:"item_id"`
+	Quantity  int    `json:"quantity"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+/*
+Struktur untuk permintaan booking dari hold stok.
+Struktur ini berisi catatan yang disalin ke booking.
+*/
+type HoldBookingRequest struct {
+	Notes string `json:"notes"`
+}
+
+/*
+Struktur untuk permintaan pembuatan blackout item.
+Struktur ini berisi rentang tanggal, jumlah unit yang diblokir (kosong berarti seluruh stok), dan alasannya.
+*/
+type BlackoutRequest struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Quantity  *int   `json:"quantity"`
+	Reason    string `json:"reason"`
+}
+
+/*
+Metode untuk membuat booking baru oleh customer.
+Booking yang dibuat dikembalikan dengan status pending.
+*/
+func (h *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CreateBooking: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req BookingRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("CreateBooking: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	// Validasi format tanggal
+	start, err := time.Parse(dateLayout, strings.TrimSpace(req.StartDate))
+	if err != nil {
+		response.BadRequest(w, message.MsgBookingDateInvalid)
+		return
+	}
+	end, err := time.Parse(dateLayout, strings.TrimSpace(req.EndDate))
+	if err != nil {
+		response.BadRequest(w, message.MsgBookingDateInvalid)
+		return
+	}
+
+	input := &model.BookingModel{
+		ItemID:    req.ItemID,
+		Quantity:  req.Quantity,
+		StartDate: start,
+		EndDate:   end,
+		Notes:     req.Notes,
+	}
+	booking, err := h.service.CreateBooking(r.Context(), input)
+	if err != nil {
+		log.Printf("CreateBooking: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.Created(w, booking, message.MsgBookingCreated)
+}
+
+/*
+Metode untuk mengambil booking milik customer.
+Daftar booking customer dikembalikan.
+*/
+func (h *BookingHandler) GetCustomerBookings(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetCustomerBookings: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	bookings, err := h.service.GetCustomerBookings(r.Context())
+	if err != nil {
+		log.Printf("GetCustomerBookings: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, bookings, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengambil detail booking milik customer.
+Detail booking dikembalikan.
+*/
+func (h *BookingHandler) GetCustomerBooking(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetCustomerBooking: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgBookingIDRequired)
+		return
+	}
+
+	booking, err := h.service.GetCustomerBooking(r.Context(), id)
+	if err != nil {
+		log.Printf("GetCustomerBooking: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, booking, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengambil order milik customer.
+Daftar order beserta baris booking-nya dikembalikan.
+*/
+func (h *BookingHandler) GetCustomerOrders(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetCustomerOrders: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	orders, err := h.service.GetCustomerOrders(r.Context())
+	if err != nil {
+		log.Printf("GetCustomerOrders: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, orders, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengambil detail order milik customer.
+Detail order beserta baris booking-nya dikembalikan.
+*/
+func (h *BookingHandler) GetCustomerOrder(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetCustomerOrder: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgOrderIDRequired)
+		return
+	}
+
+	order, err := h.service.GetCustomerOrder(r.Context(), id)
+	if err != nil {
+		log.Printf("GetCustomerOrder: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, order, message.MsgSuccess)
+}
+
+/*
+Metode untuk menahan stok item sementara oleh customer.
+Hold yang dibuat dikembalikan beserta penawaran harga dan waktu kedaluwarsanya.
+*/
+func (h *BookingHandler) CreateHold(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CreateHold: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req StockHoldRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("CreateHold: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	// Validasi format tanggal
+	start, err := time.Parse(dateLayout, strings.TrimSpace(req.StartDate))
+	if err != nil {
+		response.BadRequest(w, message.MsgBookingDateInvalid)
+		return
+	}
+	end, err := time.Parse(dateLayout, strings.TrimSpace(req.EndDate))
+	if err != nil {
+		response.BadRequest(w, message.MsgBookingDateInvalid)
+		return
+	}
+
+	line := &model.BookingModel{
+		ItemID:    req.ItemID,
+		Quantity:  req.Quantity,
+		StartDate: start,
+		EndDate:   end,
+	}
+	holds, err := h.service.CreateHolds(r.Context(), []*model.BookingModel{line})
+	if err != nil {
+		log.Printf("CreateHold: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.Created(w, holds[0], message.MsgStockHoldCreated)
+}
+
+/*
+Metode untuk mengambil hold stok aktif milik customer.
+Daftar hold yang belum kedaluwarsa dikembalikan.
+*/
+func (h *BookingHandler) GetCustomerHolds(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetCustomerHolds: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	holds, err := h.service.GetCustomerHolds(r.Context())
+	if err != nil {
+		log.Printf("GetCustomerHolds: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, holds, message.MsgSuccess)
+}
+
+/*
+Metode untuk melepas hold stok oleh customer.
+Respons sukses dikembalikan jika hold dilepas.
+*/
+func (h *BookingHandler) ReleaseHold(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ReleaseHold: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	if err := h.service.ReleaseHold(r.Context(), mux.Vars(r)["id"]); err != nil {
+		log.Printf("ReleaseHold: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgStockHoldReleased)
+}
+
+/*
+Metode untuk mengubah hold stok menjadi booking saat pembayaran.
+Booking yang dibuat dikembalikan dengan status pending.
+*/
+func (h *BookingHandler) BookHold(w http.ResponseWriter, r *http.Request) {
+	log.Printf("BookHold: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req HoldBookingRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("BookHold: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	booking, err := h.service.BookHold(r.Context(), mux.Vars(r)["id"], req.Notes)
+	if err != nil {
+		log.Printf("BookHold: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.Created(w, booking, message.MsgBookingCreated)
+}
+
+/*
+Metode untuk membatalkan booking oleh customer.
+Booking dengan status cancelled dikembalikan.
+*/
+func (h *BookingHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CancelBooking: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgBookingIDRequired)
+		return
+	}
+
+	booking, err := h.service.CancelBooking(r.Context(), id)
+	if err != nil {
+		log.Printf("CancelBooking: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, booking, message.MsgBookingCancelled)
+}
+
+/*
+Metode untuk mengambil booking item milik toko.
+Daftar booking toko dikembalikan, dapat difilter dengan parameter status.
+*/
+func (h *BookingHandler) GetStoreBookings(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetStoreBookings: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	bookings, err := h.service.GetStoreBookings(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		log.Printf("GetStoreBookings: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, bookings, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengambil detail booking item milik toko.
+Detail booking dikembalikan.
+*/
+func (h *BookingHandler) GetStoreBooking(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetStoreBooking: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgBookingIDRequired)
+		return
+	}
+
+	booking, err := h.service.GetStoreBooking(r.Context(), id)
+	if err != nil {
+		log.Printf("GetStoreBooking: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, booking, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengonfirmasi booking oleh hoster.
+Booking dengan status confirmed dikembalikan.
+*/
+func (h *BookingHandler) ConfirmBooking(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ConfirmBooking: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgBookingIDRequired)
+		return
+	}
+
+	booking, err := h.service.ConfirmBooking(r.Context(), id)
+	if err != nil {
+		log.Printf("ConfirmBooking: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, booking, message.MsgBookingConfirmed)
+}
+
+/*
+Metode untuk menolak booking oleh hoster.
+Booking dengan status rejected dikembalikan.
+*/
+func (h *BookingHandler) RejectBooking(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RejectBooking: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgBookingIDRequired)
+		return
+	}
+
+	booking, err := h.service.RejectBooking(r.Context(), id)
+	if err != nil {
+		log.Printf("RejectBooking: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, booking, message.MsgBookingRejected)
+}
+
+/*
+Metode untuk menandai booking sudah diambil customer oleh hoster.
+Booking dengan status picked_up dikembalikan.
+*/
+func (h *BookingHandler) PickUpBooking(w http.ResponseWriter, r *http.Request) {
+	log.Printf("PickUpBooking: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgBookingIDRequired)
+		return
+	}
+
+	booking, err := h.service.PickUpBooking(r.Context(), id)
+	if err != nil {
+		log.Printf("PickUpBooking: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, booking, message.MsgBookingPickedUp)
+}
+
+/*
+Metode untuk menandai booking sudah dikembalikan customer oleh hoster.
+Booking dengan status returned dikembalikan.
+*/
+func (h *BookingHandler) ReturnBooking(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ReturnBooking: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgBookingIDRequired)
+		return
+	}
+
+	booking, err := h.service.ReturnBooking(r.Context(), id)
+	if err != nil {
+		log.Printf("ReturnBooking: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, booking, message.MsgBookingReturned)
+}
+
+/*
+Metode untuk menyelesaikan booking oleh hoster.
+Booking dengan status completed dikembalikan.
+*/
+func (h *BookingHandler) CompleteBooking(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CompleteBooking: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgBookingIDRequired)
+		return
+	}
+
+	booking, err := h.service.CompleteBooking(r.Context(), id)
+	if err != nil {
+		log.Printf("CompleteBooking: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, booking, message.MsgBookingCompleted)
+}
+
+/*
+Metode untuk mengambil kalender ketersediaan item.
+Sisa unit per hari dikembalikan untuk rentang from dan to, default 90 hari mulai hari ini.
+*/
+func (h *BookingHandler) GetItemAvailability(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetItemAvailability: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	// Validasi format tanggal opsional
+	var from, to time.Time
+	var err error
+	if raw := strings.TrimSpace(r.URL.Query().Get("from")); raw != "" {
+		if from, err = time.Parse(dateLayout, raw); err != nil {
+			response.BadRequest(w, message.MsgBookingDateInvalid)
+			return
+		}
+	}
+	if raw := strings.TrimSpace(r.URL.Query().Get("to")); raw != "" {
+		if to, err = time.Parse(dateLayout, raw); err != nil {
+			response.BadRequest(w, message.MsgBookingDateInvalid)
+			return
+		}
+	}
+
+	availability, err := h.service.GetAvailability(mux.Vars(r)["id"], from, to)
+	if err != nil {
+		log.Printf("GetItemAvailability: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, availability, message.MsgSuccess)
+}
+
+/*
+Metode untuk membuat periode blackout item oleh hoster.
+Blackout yang dibuat dikembalikan.
+*/
+func (h *BookingHandler) CreateBlackout(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CreateBlackout: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req BlackoutRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("CreateBlackout: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	// Validasi format tanggal
+	start, err := time.Parse(dateLayout, strings.TrimSpace(req.StartDate))
+	if err != nil {
+		response.BadRequest(w, message.MsgBookingDateInvalid)
+		return
+	}
+	end, err := time.Parse(dateLayout, strings.TrimSpace(req.EndDate))
+	if err != nil {
+		response.BadRequest(w, message.MsgBookingDateInvalid)
+		return
+	}
+
+	input := &model.ItemBlackoutModel{
+		ItemID:    mux.Vars(r)["id"],
+		StartDate: start,
+		EndDate:   end,
+		Quantity:  req.Quantity,
+		Reason:    req.Reason,
+	}
+	blackout, err := h.service.CreateBlackout(r.Context(), input)
+	if err != nil {
+		log.Printf("CreateBlackout: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.Created(w, blackout, message.MsgBlackoutCreated)
+}
+
+/*
+Metode untuk mengambil periode blackout item oleh hoster.
+Daftar blackout yang belum berakhir dikembalikan.
+*/
+func (h *BookingHandler) GetBlackouts(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetBlackouts: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	blackouts, err := h.service.GetBlackouts(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetBlackouts: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, blackouts, message.MsgSuccess)
+}
+
+/*
+Metode untuk menghapus periode blackout item oleh hoster.
+Unit yang diblokir kembali tersedia.
+*/
+func (h *BookingHandler) DeleteBlackout(w http.ResponseWriter, r *http.Request) {
+	log.Printf("DeleteBlackout: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	vars := mux.Vars(r)
+	id := strings.TrimSpace(vars["blackout_id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgBlackoutIDRequired)
+		return
+	}
+
+	if err := h.service.DeleteBlackout(r.Context(), vars["id"], id); err != nil {
+		log.Printf("DeleteBlackout: error: %v", err)
+		writeBookingError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgBlackoutDeleted)
+}
+
+/*
+Fungsi untuk mengirim respons error booking.
+Error validasi dipetakan ke 400, item toko lain ke 403, data tidak ditemukan ke 404, dan konflik stok, status, hold kedaluwarsa, atau batas hold ke 409.
+*/
+func writeBookingError(w http.ResponseWriter, err error) {
+	var transition *TransitionError
+	if errors.As(err, &transition) {
+		response.Conflict(w, err.Error())
+		return
+	}
+	switch err.Error() {
+	case message.MsgItemIDRequired, message.MsgBookingQuantityInvalid, message.MsgBookingDateRangeInvalid, message.MsgBookingDateInPast, message.MsgBookingStatusFilterInvalid, message.MsgBookingPeriodTooLong,
+		message.MsgAvailabilityRangeInvalid, message.MsgBlackoutQuantityInvalid, message.MsgCartEmpty, message.MsgStockHoldIDRequired, message.MsgStockHoldMismatch:
+		response.BadRequest(w, err.Error())
+	case message.MsgStoreAccessDenied:
+		response.Forbidden(w, err.Error())
+	case message.MsgItemNotFound, message.MsgCustomerNotFound, message.MsgBookingNotFound, message.MsgBlackoutNotFound, message.MsgOrderNotFound, message.MsgStockHoldNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case message.MsgBookingStockUnavailable, message.MsgBlackoutConflict, message.MsgStockHoldExpired, message.MsgStockHoldLimit, message.MsgStockHoldItemLimit:
+		response.Conflict(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+	}
+}
+
+/*
+Fungsi untuk membuat instance baru dari BookingHandler.
+Instance handler dikembalikan.
+*/
+func NewBookingHandler(s BookingService) *BookingHandler {
+	return &BookingHandler{service: s}
+}
@@ -0,0 +1,945 @@
+package booking
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/ledger"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk kolom booking yang dipilih.
+Konstanta ini menyertakan nama item dan nama customer agar daftar booking dapat langsung ditampilkan.
+*/
+const bookingColumns = `
+	b.id,
+	b.item_id,
+	i.name AS item_name,
+	b.customer_id,
+	COALESCE(c.full_name, '') AS customer_name,
+	b.store_id,
+	b.order_id,
+	b.quantity,
+	b.start_date,
+	b.end_date,
+	b.days,
+	b.price_per_day,
+	b.discount,
+	b.delivery_fee,
+	b.total_price,
+	b.deposit,
+	b.status,
+	b.notes,
+	b.cancellation_policy_id,
+	b.cancellation_rules,
+	b.refund_percent,
+	b.refund_amount,
+	b.created_at,
+	b.updated_at,
+	b.confirmed_at,
+	b.rejected_at,
+	b.picked_up_at,
+	b.returned_at,
+	b.completed_at,
+	b.cancelled_at
+`
+
+/*
+Konstanta untuk kolom order yang dipilih.
+Konstanta ini menyertakan nama toko agar daftar order dapat langsung ditampilkan.
+*/
+const orderColumns = `
+	o.id,
+	o.customer_id,
+	o.store_id,
+	h.store_name,
+	o.start_date,
+	o.end_date,
+	o.total_price,
+	o.deposit,
+	o.notes,
+	o.created_at
+`
+
+/*
+Konstanta untuk kolom hold stok yang dipilih.
+Konstanta ini menyertakan nama item agar daftar hold dapat langsung ditampilkan.
+*/
+const stockHoldColumns = `
+	s.id,
+	s.customer_id,
+	s.item_id,
+	i.name AS item_name,
+	s.store_id,
+	s.quantity,
+	s.start_date,
+	s.end_date,
+	s.status,
+	s.booking_id,
+	s.expires_at,
+	s.created_at
+`
+
+/*
+Variabel untuk kolom waktu setiap status booking.
+Variabel ini memetakan status tujuan ke kolom yang mencatat kapan perpindahan terjadi.
+*/
+var statusTimestampColumns = map[string]string{
+	model.BookingStatusConfirmed: "confirmed_at",
+	model.BookingStatusRejected:  "rejected_at",
+	model.BookingStatusPickedUp:  "picked_up_at",
+	model.BookingStatusReturned:  "returned_at",
+	model.BookingStatusCompleted: "completed_at",
+	model.BookingStatusCancelled: "cancelled_at",
+}
+
+/*
+Struktur untuk repositori booking.
+Struktur ini menyediakan akses ke operasi database untuk booking.
+*/
+type bookingRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk membuat booking baru tanpa melebihi stok item.
+Baris customer lalu baris item dikunci selama transaksi sehingga booking bersamaan untuk item yang sama diproses bergantian, stok tidak pernah terjual lebih, dan booking tidak lolos dari penghapusan akun customer.
+*/
+func (r *bookingRepository) CreateBooking(booking *model.BookingModel) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockCustomer(tx, booking.CustomerID); err != nil {
+		return err
+	}
+	if err := reserve(tx, booking); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Printf("CreateBooking: booking %s created for item %s, quantity %d", booking.ID, booking.ItemID, booking.Quantity)
+	return nil
+}
+
+/*
+Metode untuk membuat beberapa order beserta baris booking-nya.
+Semua order dibuat dalam satu transaksi; baris customer lalu item dikunci berurutan lebih dulu agar checkout bersamaan tidak saling mengunci dan tidak lolos dari penghapusan akun customer, dan jika stok satu baris tidak cukup tidak ada order yang dibuat.
+*/
+func (r *bookingRepository) CreateOrders(orders []*model.OrderModel) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if len(orders) > 0 {
+		if err := lockCustomer(tx, orders[0].CustomerID); err != nil {
+			return err
+		}
+	}
+	itemIDs := []string{}
+	for _, order := range orders {
+		for _, booking := range order.Bookings {
+			itemIDs = append(itemIDs, booking.ItemID)
+		}
+	}
+	lock := `SELECT id FROM item WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+	if _, err := tx.Exec(lock, pq.StringArray(itemIDs)); err != nil {
+		return err
+	}
+
+	insert := `
+		INSERT INTO orders (
+			customer_id,
+			store_id,
+			start_date,
+			end_date,
+			total_price,
+			deposit,
+			notes
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	for _, order := range orders {
+		err := tx.QueryRow(insert, order.CustomerID, order.StoreID, order.StartDate, order.EndDate, order.TotalPrice, order.Deposit, order.Notes).Scan(&order.ID, &order.CreatedAt)
+		if err != nil {
+			return err
+		}
+		for _, booking := range order.Bookings {
+			booking.OrderID = &order.ID
+			if err := reserve(tx, booking); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Printf("CreateOrders: %d orders created with %d bookings", len(orders), len(itemIDs))
+	return nil
+}
+
+/*
+Metode untuk mengambil order milik customer.
+Daftar order beserta baris booking-nya dikembalikan dari yang terbaru.
+*/
+func (r *bookingRepository) GetOrdersByCustomer(customerID string) ([]*model.OrderModel, error) {
+	orders := []*model.OrderModel{}
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders o
+		JOIN hoster h ON h.id = o.store_id
+		WHERE o.customer_id = $1
+		ORDER BY o.created_at DESC
+	`
+	if err := r.db.Select(&orders, query, customerID); err != nil {
+		log.Printf("GetOrdersByCustomer: error querying customer %s: %v", customerID, err)
+		return nil, err
+	}
+	if err := r.attachBookings(orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+/*
+Metode untuk mencari order berdasarkan ID.
+Model order beserta baris booking-nya dikembalikan jika ditemukan.
+*/
+func (r *bookingRepository) FindOrderByID(id string) (*model.OrderModel, error) {
+	var order model.OrderModel
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders o
+		JOIN hoster h ON h.id = o.store_id
+		WHERE o.id = $1
+	`
+	err := r.db.Get(&order, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindOrderByID: error querying order %s: %v", id, err)
+		return nil, err
+	}
+	if err := r.attachBookings([]*model.OrderModel{&order}); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+/*
+Metode untuk melengkapi order dengan baris booking-nya.
+Booking semua order diambil dalam satu query lalu dikelompokkan per order.
+*/
+func (r *bookingRepository) attachBookings(orders []*model.OrderModel) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	byID := make(map[string]*model.OrderModel, len(orders))
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		order.Bookings = []*model.BookingModel{}
+		order.AmountDue = order.TotalPrice + order.Deposit
+		byID[order.ID] = order
+		ids = append(ids, order.ID)
+	}
+
+	bookings := []*model.BookingModel{}
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings b
+		JOIN item i ON i.id = b.item_id
+		LEFT JOIN customers c ON c.id = b.customer_id
+		WHERE b.order_id = ANY($1)
+		ORDER BY b.created_at, b.id
+	`
+	if err := r.db.Select(&bookings, query, pq.StringArray(ids)); err != nil {
+		log.Printf("attachBookings: error querying orders: %v", err)
+		return err
+	}
+	for _, booking := range bookings {
+		order := byID[*booking.OrderID]
+		order.Bookings = append(order.Bookings, booking)
+	}
+	return nil
+}
+
+/*
+Metode untuk mencari booking berdasarkan ID.
+Model booking dikembalikan jika ditemukan.
+*/
+func (r *bookingRepository) FindBookingByID(id string) (*model.BookingModel, error) {
+	var booking model.BookingModel
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings b
+		JOIN item i ON i.id = b.item_id
+		LEFT JOIN customers c ON c.id = b.customer_id
+		WHERE b.id = $1
+	`
+	err := r.db.Get(&booking, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindBookingByID: error querying booking %s: %v", id, err)
+		return nil, err
+	}
+	return &booking, nil
+}
+
+/*
+Metode untuk mengambil booking milik customer.
+Daftar booking dikembalikan dari yang terbaru.
+*/
+func (r *bookingRepository) GetBookingsByCustomer(customerID string) ([]*model.BookingModel, error) {
+	bookings := []*model.BookingModel{}
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings b
+		JOIN item i ON i.id = b.item_id
+		LEFT JOIN customers c ON c.id = b.customer_id
+		WHERE b.customer_id = $1
+		ORDER BY b.created_at DESC
+	`
+	if err := r.db.Select(&bookings, query, customerID); err != nil {
+		log.Printf("GetBookingsByCustomer: error querying customer %s: %v", customerID, err)
+		return nil, err
+	}
+	return bookings, nil
+}
+
+/*
+Metode untuk mengambil booking item milik toko.
+Daftar booking dikembalikan dari tanggal mulai terdekat, dapat difilter dengan status.
+*/
+func (r *bookingRepository) GetBookingsByStore(storeID, status string) ([]*model.BookingModel, error) {
+	bookings := []*model.BookingModel{}
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings b
+		JOIN item i ON i.id = b.item_id
+		LEFT JOIN customers c ON c.id = b.customer_id
+		WHERE b.store_id = $1 AND ($2 = '' OR b.status = $2)
+		ORDER BY b.start_date, b.created_at
+	`
+	if err := r.db.Select(&bookings, query, storeID, status); err != nil {
+		log.Printf("GetBookingsByStore: error querying store %s: %v", storeID, err)
+		return nil, err
+	}
+	return bookings, nil
+}
+
+/*
+Metode untuk mengubah status booking dan mencatat waktunya.
+Status hanya diubah jika status saat ini sama dengan status asal, sehingga perubahan bersamaan tidak saling menimpa; booking yang ditolak sekaligus dikembalikan deposit-nya dalam transaksi yang sama. Booking terbaru dikembalikan atau nil jika status sudah berubah.
+*/
+func (r *bookingRepository) UpdateBookingStatus(id, from, to string) (*model.BookingModel, error) {
+	column, ok := statusTimestampColumns[to]
+	if !ok {
+		return nil, fmt.Errorf("unknown booking status %q", to)
+	}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE bookings
+		SET status = $3, ` + column + ` = NOW()
+		WHERE id = $1 AND status = $2
+	`
+	res, err := tx.Exec(query, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+	if to == model.BookingStatusRejected {
+		if err := ledger.ReleaseDeposit(tx, id, "Booking rejected"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	log.Printf("UpdateBookingStatus: booking %s moved from %s to %s", id, from, to)
+	return r.FindBookingByID(id)
+}
+
+/*
+Metode untuk membatalkan booking dan mencatat refund-nya.
+Pembatalan hanya disimpan jika status saat ini sama dengan status asal, dan deposit dikembalikan dalam transaksi yang sama; booking terbaru dikembalikan atau nil jika status sudah berubah.
+*/
+func (r *bookingRepository) CancelBooking(id, from string, refundPercent, refundAmount int) (*model.BookingModel, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE bookings
+		SET status = $3, cancelled_at = NOW(), refund_percent = $4, refund_amount = $5
+		WHERE id = $1 AND status = $2
+	`
+	res, err := tx.Exec(query, id, from, model.BookingStatusCancelled, refundPercent, refundAmount)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+	if err := ledger.ReleaseDeposit(tx, id, "Booking cancelled"); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	log.Printf("CancelBooking: booking %s cancelled from %s with %d%% refund", id, from, refundPercent)
+	return r.FindBookingByID(id)
+}
+
+/*
+Metode untuk mengambil pemakaian stok harian item.
+Jumlah unit yang dibooking dan diblokir per tanggal dikembalikan dalam satu query.
+*/
+func (r *bookingRepository) GetDailyUsage(itemID string, stock int, from, to time.Time) ([]*model.AvailabilityDayModel, error) {
+	return dailyUsage(r.db, itemID, stock, from, to)
+}
+
+/*
+Metode untuk membuat periode blackout item.
+Baris item dikunci dan blackout hanya disimpan jika unit yang sudah dibooking tetap tertampung pada setiap hari.
+*/
+func (r *bookingRepository) CreateBlackout(blackout *model.ItemBlackoutModel) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stock, err := lockItem(tx, blackout.ItemID)
+	if err != nil {
+		return err
+	}
+
+	blocked := stock
+	if blackout.Quantity != nil {
+		blocked = *blackout.Quantity
+	}
+	days, err := dailyUsage(tx, blackout.ItemID, stock, blackout.StartDate, blackout.EndDate)
+	if err != nil {
+		return err
+	}
+	if peak := peakUsage(days); peak+blocked > stock {
+		log.Printf("CreateBlackout: item %s has %d of %d in use, blackout needs %d", blackout.ItemID, peak, stock, blocked)
+		return errors.New(message.MsgBlackoutConflict)
+	}
+
+	insert := `
+		INSERT INTO item_blackouts (
+			item_id,
+			store_id,
+			start_date,
+			end_date,
+			quantity,
+			reason,
+			created_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(insert, blackout.ItemID, blackout.StoreID, blackout.StartDate, blackout.EndDate, blackout.Quantity, blackout.Reason, blackout.CreatedBy).Scan(&blackout.ID, &blackout.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+Metode untuk mengambil periode blackout item.
+Daftar blackout yang belum berakhir dikembalikan dari tanggal mulai terdekat.
+*/
+func (r *bookingRepository) GetBlackoutsByItem(itemID string) ([]*model.ItemBlackoutModel, error) {
+	blackouts := []*model.ItemBlackoutModel{}
+	query := `
+		SELECT
+			id,
+			item_id,
+			store_id,
+			start_date,
+			end_date,
+			quantity,
+			reason,
+			created_by,
+			created_at
+		FROM item_blackouts
+		WHERE item_id = $1 AND end_date >= CURRENT_DATE
+		ORDER BY start_date
+	`
+	if err := r.db.Select(&blackouts, query, itemID); err != nil {
+		log.Printf("GetBlackoutsByItem: error querying item %s: %v", itemID, err)
+		return nil, err
+	}
+	return blackouts, nil
+}
+
+/*
+Metode untuk menghapus periode blackout item milik toko.
+Nilai true dikembalikan jika blackout ditemukan dan dihapus.
+*/
+func (r *bookingRepository) DeleteBlackout(storeID, itemID, id string) (bool, error) {
+	query := `
+		DELETE FROM item_blackouts
+		WHERE id = $1 AND item_id = $2 AND store_id = $3
+	`
+	res, err := r.db.Exec(query, id, itemID, storeID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+/*
+Metode untuk menahan stok beberapa item sementara.
+Semua hold dibuat dalam satu transaksi; baris customer dan item dikunci berurutan lebih dulu agar batas hold tidak terlewati oleh permintaan bersamaan, dan jika stok atau batas satu item tidak cukup tidak ada hold yang dibuat.
+*/
+func (r *bookingRepository) CreateHolds(holds []*model.StockHoldModel, cfg config.StockHoldConfig) error {
+	if len(holds) == 0 {
+		return nil
+	}
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockCustomer(tx, holds[0].CustomerID); err != nil {
+		return err
+	}
+
+	itemIDs := make([]string, 0, len(holds))
+	for _, stockHold := range holds {
+		itemIDs = append(itemIDs, stockHold.ItemID)
+	}
+	lock := `SELECT id FROM item WHERE id = ANY($1) ORDER BY id FOR UPDATE`
+	if _, err := tx.Exec(lock, pq.StringArray(itemIDs)); err != nil {
+		return err
+	}
+
+	for _, stockHold := range holds {
+		if err := holdStock(tx, stockHold, cfg); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Printf("CreateHolds: %d stock holds created, expiring in %s", len(holds), cfg.TTL)
+	return nil
+}
+
+/*
+Metode untuk mengambil hold stok aktif milik customer.
+Daftar hold yang belum kedaluwarsa dikembalikan dari yang paling cepat berakhir.
+*/
+func (r *bookingRepository) GetHoldsByCustomer(customerID string) ([]*model.StockHoldModel, error) {
+	holds := []*model.StockHoldModel{}
+	query := `
+		SELECT ` + stockHoldColumns + `
+		FROM stock_holds s
+		JOIN item i ON i.id = s.item_id
+		WHERE s.customer_id = $1 AND s.status = $2 AND s.expires_at > NOW()
+		ORDER BY s.expires_at, s.id
+	`
+	if err := r.db.Select(&holds, query, customerID, model.StockHoldStatusActive); err != nil {
+		log.Printf("GetHoldsByCustomer: error querying customer %s: %v", customerID, err)
+		return nil, err
+	}
+	return holds, nil
+}
+
+/*
+Metode untuk mencari hold stok berdasarkan ID.
+Model hold dikembalikan jika ditemukan.
+*/
+func (r *bookingRepository) FindHoldByID(id string) (*model.StockHoldModel, error) {
+	var stockHold model.StockHoldModel
+	query := `
+		SELECT ` + stockHoldColumns + `
+		FROM stock_holds s
+		JOIN item i ON i.id = s.item_id
+		WHERE s.id = $1
+	`
+	err := r.db.Get(&stockHold, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindHoldByID: error querying hold %s: %v", id, err)
+		return nil, err
+	}
+	return &stockHold, nil
+}
+
+/*
+Metode untuk melepas hold stok milik customer.
+Nilai true dikembalikan jika hold masih aktif dan dilepas.
+*/
+func (r *bookingRepository) ReleaseHold(customerID, id string) (bool, error) {
+	query := `
+		UPDATE stock_holds
+		SET status = $3
+		WHERE id = $1 AND customer_id = $2 AND status = $4 AND expires_at > NOW()
+	`
+	res, err := r.db.Exec(query, id, customerID, model.StockHoldStatusReleased, model.StockHoldStatusActive)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+/*
+Metode untuk menandai hold stok yang sudah melewati masa berlakunya.
+Jumlah hold yang ditandai kedaluwarsa dikembalikan.
+*/
+func (r *bookingRepository) ExpireHolds() (int64, error) {
+	query := `
+		UPDATE stock_holds
+		SET status = $1
+		WHERE status = $2 AND expires_at <= NOW()
+	`
+	res, err := r.db.Exec(query, model.StockHoldStatusExpired, model.StockHoldStatusActive)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+/*
+Interface untuk operasi repositori booking.
+Interface ini mendefinisikan metode untuk mengelola booking.
+*/
+type BookingRepository interface {
+	CreateBooking(booking *model.BookingModel) error
+	CreateOrders(orders []*model.OrderModel) error
+	GetOrdersByCustomer(customerID string) ([]*model.OrderModel, error)
+	FindOrderByID(id string) (*model.OrderModel, error)
+	FindBookingByID(id string) (*model.BookingModel, error)
+	GetBookingsByCustomer(customerID string) ([]*model.BookingModel, error)
+	GetBookingsByStore(storeID, status string) ([]*model.BookingModel, error)
+	UpdateBookingStatus(id, from, to string) (*model.BookingModel, error)
+	CancelBooking(id, from string, refundPercent, refundAmount int) (*model.BookingModel, error)
+	GetDailyUsage(itemID string, stock int, from, to time.Time) ([]*model.AvailabilityDayModel, error)
+	CreateBlackout(blackout *model.ItemBlackoutModel) error
+	GetBlackoutsByItem(itemID string) ([]*model.ItemBlackoutModel, error)
+	DeleteBlackout(storeID, itemID, id string) (bool, error)
+	CreateHolds(holds []*model.StockHoldModel, cfg config.StockHoldConfig) error
+	GetHoldsByCustomer(customerID string) ([]*model.StockHoldModel, error)
+	FindHoldByID(id string) (*model.StockHoldModel, error)
+	ReleaseHold(customerID, id string) (bool, error)
+	ExpireHolds() (int64, error)
+}
+
+/*
+Fungsi untuk memesan stok dan menyimpan booking dalam transaksi.
+Baris item dikunci, hold stok yang dirujuk booking dikonversi, stok dicek pada setiap hari di rentang tanggal booking, lalu booking dan penahanan deposit-nya disimpan.
+*/
+func reserve(tx *sqlx.Tx, booking *model.BookingModel) error {
+	stock, err := lockItem(tx, booking.ItemID)
+	if err != nil {
+		return err
+	}
+
+	// Hold yang dikonversi tidak dihitung lagi saat stoknya dicek
+	if booking.HoldID != nil {
+		var holdID string
+		convert := `
+			UPDATE stock_holds
+			SET status = $7
+			WHERE id = $1
+				AND customer_id = $2
+				AND item_id = $3
+				AND quantity = $4
+				AND start_date = $5
+				AND end_date = $6
+				AND status = $8
+				AND expires_at > NOW()
+			RETURNING id
+		`
+		err := tx.Get(&holdID, convert, *booking.HoldID, booking.CustomerID, booking.ItemID, booking.Quantity, booking.StartDate, booking.EndDate, model.StockHoldStatusConverted, model.StockHoldStatusActive)
+		if err == sql.ErrNoRows {
+			return errors.New(message.MsgStockHoldExpired)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := checkStock(tx, booking.ItemID, stock, booking.Quantity, booking.StartDate, booking.EndDate); err != nil {
+		return err
+	}
+
+	insert := `
+		INSERT INTO bookings (
+			item_id,
+			customer_id,
+			store_id,
+			order_id,
+			quantity,
+			start_date,
+			end_date,
+			days,
+			price_per_day,
+			discount,
+			delivery_fee,
+			total_price,
+			deposit,
+			status,
+			notes,
+			cancellation_policy_id,
+			cancellation_rules
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING id, created_at, updated_at
+	`
+	err = tx.QueryRow(insert, booking.ItemID, booking.CustomerID, booking.StoreID, booking.OrderID, booking.Quantity, booking.StartDate, booking.EndDate, booking.Days, booking.PricePerDay, booking.Discount, booking.DeliveryFee, booking.TotalPrice, booking.Deposit, booking.Status, booking.Notes, booking.CancellationPolicyID, booking.CancellationRules).Scan(&booking.ID, &booking.CreatedAt, &booking.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	// Deposit dicatat sebagai ditahan pada buku besar deposit booking
+	if booking.Deposit > 0 {
+		hold := `
+			INSERT INTO deposit_entries (booking_id, entry_type, amount, reason, created_by)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+		if _, err := tx.Exec(hold, booking.ID, model.DepositEntryHold, booking.Deposit, "Deposit held at booking", booking.CustomerID); err != nil {
+			return err
+		}
+	}
+
+	if booking.HoldID != nil {
+		link := `UPDATE stock_holds SET booking_id = $1 WHERE id = $2`
+		if _, err := tx.Exec(link, booking.ID, *booking.HoldID); err != nil {
+			return err
+		}
+		log.Printf("reserve: stock hold %s converted into booking %s", *booking.HoldID, booking.ID)
+	}
+	return nil
+}
+
+/*
+Fungsi untuk menahan stok item sementara dalam transaksi.
+Baris item dikunci, hold aktif customer sebelumnya untuk item dan tanggal yang sama dilepas, batas hold customer dan unit per item dicek, stok dicek pada setiap hari, lalu hold disimpan dengan masa berlaku dari konfigurasi.
+*/
+func holdStock(tx *sqlx.Tx, stockHold *model.StockHoldModel, cfg config.StockHoldConfig) error {
+	stock, err := lockItem(tx, stockHold.ItemID)
+	if err != nil {
+		return err
+	}
+
+	release := `
+		UPDATE stock_holds
+		SET status = $5
+		WHERE customer_id = $1
+			AND item_id = $2
+			AND start_date = $3
+			AND end_date = $4
+			AND status = $6
+	`
+	if _, err := tx.Exec(release, stockHold.CustomerID, stockHold.ItemID, stockHold.StartDate, stockHold.EndDate, model.StockHoldStatusReleased, model.StockHoldStatusActive); err != nil {
+		return err
+	}
+
+	var held struct {
+		Holds     int `db:"holds"`
+		ItemUnits int `db:"item_units"`
+	}
+	count := `
+		SELECT
+			COUNT(*) AS holds,
+			COALESCE(SUM(quantity) FILTER (WHERE item_id = $2), 0) AS item_units
+		FROM stock_holds
+		WHERE customer_id = $1 AND status = $3 AND expires_at > NOW()
+	`
+	if err := tx.Get(&held, count, stockHold.CustomerID, stockHold.ItemID, model.StockHoldStatusActive); err != nil {
+		return err
+	}
+	if held.Holds >= cfg.MaxPerCustomer {
+		return errors.New(message.MsgStockHoldLimit)
+	}
+	if held.ItemUnits+stockHold.Quantity > cfg.MaxUnitsPerItem {
+		return errors.New(message.MsgStockHoldItemLimit)
+	}
+
+	if err := checkStock(tx, stockHold.ItemID, stock, stockHold.Quantity, stockHold.StartDate, stockHold.EndDate); err != nil {
+		return err
+	}
+
+	insert := `
+		INSERT INTO stock_holds (
+			customer_id,
+			item_id,
+			store_id,
+			quantity,
+			start_date,
+			end_date,
+			status,
+			expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW() + $8 * INTERVAL '1 second')
+		RETURNING id, expires_at, created_at
+	`
+	stockHold.Status = model.StockHoldStatusActive
+	return tx.QueryRow(insert, stockHold.CustomerID, stockHold.ItemID, stockHold.StoreID, stockHold.Quantity, stockHold.StartDate, stockHold.EndDate, stockHold.Status, int(cfg.TTL.Seconds())).Scan(&stockHold.ID, &stockHold.ExpiresAt, &stockHold.CreatedAt)
+}
+
+/*
+Fungsi untuk mengunci baris customer dalam transaksi.
+Error customer tidak ditemukan dikembalikan jika customer tidak ada atau akunnya sudah dihapus.
+*/
+func lockCustomer(tx *sqlx.Tx, customerID string) error {
+	var id string
+	lock := `SELECT id FROM customers WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	if err := tx.Get(&id, lock, customerID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New(message.MsgCustomerNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+/*
+Fungsi untuk mengunci baris item dalam transaksi.
+Stok item dikembalikan, atau error jika item tidak ditemukan.
+*/
+func lockItem(tx *sqlx.Tx, itemID string) (int, error) {
+	var stock int
+	lock := `SELECT stock FROM item WHERE id = $1 FOR UPDATE`
+	if err := tx.Get(&stock, lock, itemID); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New(message.MsgItemNotFound)
+		}
+		return 0, err
+	}
+	return stock, nil
+}
+
+/*
+Fungsi untuk memastikan stok item cukup pada rentang tanggal.
+Error stok tidak tersedia dikembalikan jika pemakaian pada salah satu hari ditambah jumlah yang diminta melebihi stok.
+*/
+func checkStock(tx *sqlx.Tx, itemID string, stock, quantity int, from, to time.Time) error {
+	days, err := dailyUsage(tx, itemID, stock, from, to)
+	if err != nil {
+		return err
+	}
+	if peak := peakUsage(days); peak+quantity > stock {
+		log.Printf("checkStock: item %s has %d of %d in use, requested %d", itemID, peak, stock, quantity)
+		return errors.New(message.MsgBookingStockUnavailable)
+	}
+	return nil
+}
+
+/*
+Fungsi untuk menghitung pemakaian stok harian item.
+Setiap tanggal pada rentang diisi jumlah unit dari booking aktif, blackout, dan hold stok yang belum kedaluwarsa, dengan blackout tanpa jumlah dihitung sebagai seluruh stok.
+*/
+func dailyUsage(q sqlx.Queryer, itemID string, stock int, from, to time.Time) ([]*model.AvailabilityDayModel, error) {
+	days := []*model.AvailabilityDayModel{}
+	query := `
+		SELECT
+			to_char(d.day, 'YYYY-MM-DD') AS date,
+			COALESCE((
+				SELECT SUM(b.quantity)
+				FROM bookings b
+				WHERE b.item_id = $1
+					AND b.status = ANY($4)
+					AND b.start_date <= d.day
+					AND b.end_date >= d.day
+			), 0) AS reserved,
+			COALESCE((
+				SELECT SUM(COALESCE(x.quantity, $5))
+				FROM item_blackouts x
+				WHERE x.item_id = $1
+					AND x.start_date <= d.day
+					AND x.end_date >= d.day
+			), 0) AS blocked,
+			COALESCE((
+				SELECT SUM(s.quantity)
+				FROM stock_holds s
+				WHERE s.item_id = $1
+					AND s.status = $6
+					AND s.expires_at > NOW()
+					AND s.start_date <= d.day
+					AND s.end_date >= d.day
+			), 0) AS held
+		FROM generate_series($2::date, $3::date, INTERVAL '1 day') AS d(day)
+		ORDER BY d.day
+	`
+	if err := sqlx.Select(q, &days, query, itemID, from, to, pq.StringArray(model.BookingActiveStatuses), stock, model.StockHoldStatusActive); err != nil {
+		log.Printf("dailyUsage: error querying item %s: %v", itemID, err)
+		return nil, err
+	}
+	return days, nil
+}
+
+/*
+Fungsi untuk mencari pemakaian stok tertinggi dalam satu hari.
+Jumlah unit dibooking, diblokir, dan ditahan pada hari tersibuk dikembalikan.
+*/
+func peakUsage(days []*model.AvailabilityDayModel) int {
+	peak := 0
+	for _, day := range days {
+		if used := day.Reserved + day.Blocked + day.Held; used > peak {
+			peak = used
+		}
+	}
+	return peak
+}
+
+/*
+Fungsi untuk membuat instance baru dari BookingRepository.
+Instance repositori dikembalikan.
+*/
+func NewBookingRepository(db *sqlx.DB) BookingRepository {
+	return &bookingRepository{db: db}
+}
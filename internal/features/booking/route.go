@@ -0,0 +1,62 @@
+package booking
+
+import (
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/middleware"
+)
+
+/*
+Fungsi untuk mengatur rute fitur booking.
+Router dikonfigurasi dengan rute ketersediaan publik, booking, order, dan hold stok customer, pemrosesan booking hoster, dan blackout item.
+*/
+func SetupBookingRoutes(router *mux.Router, h *BookingHandler) {
+	// Setup public routes
+	router.HandleFunc("/api/v1/public/item/{id}/availability", h.GetItemAvailability).Methods("GET")
+
+	// Setup group booking customer
+	customer := router.PathPrefix("/api/v1/customer/bookings").Subrouter()
+	customer.Use(middleware.JWTMiddleware)
+	customer.Use(middleware.Customer)
+	customer.Handle("", middleware.RequireFunc(h.CreateBooking, auth.PermBookingWrite)).Methods("POST")
+	customer.Handle("", middleware.RequireFunc(h.GetCustomerBookings, auth.PermBookingRead)).Methods("GET")
+	customer.Handle("/{id}", middleware.RequireFunc(h.GetCustomerBooking, auth.PermBookingRead)).Methods("GET")
+	customer.Handle("/{id}/cancel", middleware.RequireFunc(h.CancelBooking, auth.PermBookingWrite)).Methods("POST")
+
+	// Setup group order customer
+	orders := router.PathPrefix("/api/v1/customer/orders").Subrouter()
+	orders.Use(middleware.JWTMiddleware)
+	orders.Use(middleware.Customer)
+	orders.Handle("", middleware.RequireFunc(h.GetCustomerOrders, auth.PermBookingRead)).Methods("GET")
+	orders.Handle("/{id}", middleware.RequireFunc(h.GetCustomerOrder, auth.PermBookingRead)).Methods("GET")
+
+	// Setup group hold stok customer
+	holds := router.PathPrefix("/api/v1/customer/holds").Subrouter()
+	holds.Use(middleware.JWTMiddleware)
+	holds.Use(middleware.Customer)
+	holds.Handle("", middleware.RequireFunc(h.CreateHold, auth.PermBookingWrite)).Methods("POST")
+	holds.Handle("", middleware.RequireFunc(h.GetCustomerHolds, auth.PermBookingRead)).Methods("GET")
+	holds.Handle("/{id}", middleware.RequireFunc(h.ReleaseHold, auth.PermBookingWrite)).Methods("DELETE")
+	holds.Handle("/{id}/book", middleware.RequireFunc(h.BookHold, auth.PermBookingWrite)).Methods("POST")
+
+	// Setup group booking hoster
+	hoster := router.PathPrefix("/api/v1/hoster/bookings").Subrouter()
+	hoster.Use(middleware.JWTMiddleware)
+	hoster.Use(middleware.Hoster)
+	hoster.Handle("", middleware.RequireFunc(h.GetStoreBookings, auth.PermBookingRead)).Methods("GET")
+	hoster.Handle("/{id}", middleware.RequireFunc(h.GetStoreBooking, auth.PermBookingRead)).Methods("GET")
+	hoster.Handle("/{id}/confirm", middleware.RequireFunc(h.ConfirmBooking, auth.PermBookingWrite)).Methods("POST")
+	hoster.Handle("/{id}/reject", middleware.RequireFunc(h.RejectBooking, auth.PermBookingWrite)).Methods("POST")
+	hoster.Handle("/{id}/pickup", middleware.RequireFunc(h.PickUpBooking, auth.PermBookingWrite)).Methods("POST")
+	hoster.Handle("/{id}/return", middleware.RequireFunc(h.ReturnBooking, auth.PermBookingWrite)).Methods("POST")
+	hoster.Handle("/{id}/complete", middleware.RequireFunc(h.CompleteBooking, auth.PermBookingWrite)).Methods("POST")
+
+	// Setup group blackout item hoster
+	blackouts := router.PathPrefix("/api/v1/hoster/items/{id}/blackouts").Subrouter()
+	blackouts.Use(middleware.JWTMiddleware)
+	blackouts.Use(middleware.Hoster)
+	blackouts.Handle("", middleware.RequireFunc(h.CreateBlackout, auth.PermItemWrite)).Methods("POST")
+	blackouts.Handle("", middleware.RequireFunc(h.GetBlackouts, auth.PermItemRead)).Methods("GET")
+	blackouts.Handle("/{blackout_id}", middleware.RequireFunc(h.DeleteBlackout, auth.PermItemWrite)).Methods("DELETE")
+}
@@ -0,0 +1,822 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"lalan-be/internal/config"
+	"lalan-be/internal/features/cancellation"
+	"lalan-be/internal/features/pricing"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk rentang kalender ketersediaan.
+Konstanta ini menentukan jumlah hari bawaan dan batas maksimum satu permintaan kalender.
+*/
+const (
+	defaultAvailabilityDays = 90
+	maxAvailabilityDays     = 366
+)
+
+/*
+Variabel untuk tabel transisi status booking.
+Variabel ini memetakan setiap status ke status tujuan yang diizinkan; status yang tidak ada di tabel adalah status akhir.
+*/
+var bookingTransitions = map[string][]string{
+	model.BookingStatusPending:   {model.BookingStatusConfirmed, model.BookingStatusRejected, model.BookingStatusCancelled},
+	model.BookingStatusConfirmed: {model.BookingStatusPickedUp, model.BookingStatusCancelled},
+	model.BookingStatusPickedUp:  {model.BookingStatusReturned},
+	model.BookingStatusReturned:  {model.BookingStatusCompleted},
+}
+
+/*
+Struktur untuk error perpindahan status booking yang tidak diizinkan.
+Struktur ini berisi status saat ini dan status tujuan agar klien tahu mengapa permintaan ditolak.
+*/
+type TransitionError struct {
+	From string
+	To   string
+}
+
+/*
+Metode untuk menghasilkan pesan error perpindahan status.
+Pesan berisi status asal dan tujuan dikembalikan.
+*/
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("Booking cannot move from %s to %s.", e.From, e.To)
+}
+
+/*
+Struktur untuk layanan booking.
+Struktur ini menyediakan logika bisnis untuk booking customer dan pemrosesannya oleh hoster.
+*/
+type bookingService struct {
+	repo         BookingRepository
+	pricing      pricing.PricingService
+	cancellation cancellation.CancellationService
+	holds        config.StockHoldConfig
+}
+
+/*
+Metode untuk membuat booking baru oleh customer.
+Booking disiapkan dari item dan rentang tanggal, lalu stok dicek dan dipesan secara atomik.
+*/
+func (s *bookingService) CreateBooking(ctx context.Context, input *model.BookingModel) (*model.BookingModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.newBooking(customerID, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateBooking(booking); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+/*
+Metode untuk checkout beberapa item sekaligus oleh customer.
+Hold stok yang dirujuk dengan ID dipasangkan ke barisnya, setiap baris disiapkan seperti booking tunggal lalu dikelompokkan menjadi satu order per toko; semua order dibuat dan hold dikonversi secara atomik atau tidak sama sekali.
+*/
+func (s *bookingService) Checkout(ctx context.Context, lines []*model.BookingModel, notes string, holdIDs []string) ([]*model.OrderModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, errors.New(message.MsgCartEmpty)
+	}
+	if err := s.attachHolds(customerID, lines, holdIDs); err != nil {
+		return nil, err
+	}
+
+	orders := []*model.OrderModel{}
+	byStore := map[string]*model.OrderModel{}
+	for _, line := range lines {
+		line.Notes = notes
+		booking, err := s.newBooking(customerID, line)
+		if err != nil {
+			return nil, err
+		}
+
+		order, ok := byStore[booking.StoreID]
+		if !ok {
+			order = &model.OrderModel{
+				CustomerID: customerID,
+				StoreID:    booking.StoreID,
+				StartDate:  booking.StartDate,
+				EndDate:    booking.EndDate,
+				Notes:      booking.Notes,
+				Bookings:   []*model.BookingModel{},
+			}
+			byStore[booking.StoreID] = order
+			orders = append(orders, order)
+		}
+		order.Bookings = append(order.Bookings, booking)
+		order.TotalPrice += booking.TotalPrice
+		order.Deposit += booking.Deposit
+		order.AmountDue = order.TotalPrice + order.Deposit
+	}
+
+	if err := s.repo.CreateOrders(orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+/*
+Metode untuk menahan stok item sementara oleh customer.
+Setiap baris divalidasi dan dihitung harganya seperti booking, lalu unitnya ditahan secara atomik sampai masa berlaku hold habis.
+*/
+func (s *bookingService) CreateHolds(ctx context.Context, lines []*model.BookingModel) ([]*model.StockHoldModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, errors.New(message.MsgCartEmpty)
+	}
+
+	holds := make([]*model.StockHoldModel, 0, len(lines))
+	for _, line := range lines {
+		booking, err := s.newBooking(customerID, line)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, &model.StockHoldModel{
+			CustomerID: customerID,
+			ItemID:     booking.ItemID,
+			ItemName:   booking.ItemName,
+			StoreID:    booking.StoreID,
+			Quantity:   booking.Quantity,
+			StartDate:  booking.StartDate,
+			EndDate:    booking.EndDate,
+			Quote:      booking.Quote,
+		})
+	}
+
+	if err := s.repo.CreateHolds(holds, s.holds); err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+/*
+Metode untuk mengambil hold stok aktif milik customer yang sedang login.
+Daftar hold yang belum kedaluwarsa dikembalikan.
+*/
+func (s *bookingService) GetCustomerHolds(ctx context.Context) ([]*model.StockHoldModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetHoldsByCustomer(customerID)
+}
+
+/*
+Metode untuk melepas hold stok oleh customer.
+Unit yang ditahan langsung tersedia kembali untuk customer lain.
+*/
+func (s *bookingService) ReleaseHold(ctx context.Context, id string) error {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return errors.New(message.MsgStockHoldIDRequired)
+	}
+	released, err := s.repo.ReleaseHold(customerID, id)
+	if err != nil {
+		return err
+	}
+	if !released {
+		return errors.New(message.MsgStockHoldNotFound)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk mengubah hold stok menjadi booking saat customer membayar.
+Booking dibuat dari item, jumlah, dan tanggal hold lalu hold tersebut dikonversi berdasarkan ID-nya; hold yang sudah kedaluwarsa atau tidak aktif ditolak.
+*/
+func (s *bookingService) BookHold(ctx context.Context, id, notes string) (*model.BookingModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, errors.New(message.MsgStockHoldIDRequired)
+	}
+	stockHold, err := s.repo.FindHoldByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if stockHold == nil || stockHold.CustomerID != customerID {
+		return nil, errors.New(message.MsgStockHoldNotFound)
+	}
+	if stockHold.Status != model.StockHoldStatusActive || !time.Now().Before(stockHold.ExpiresAt) {
+		return nil, errors.New(message.MsgStockHoldExpired)
+	}
+
+	booking, err := s.newBooking(customerID, &model.BookingModel{
+		ItemID:    stockHold.ItemID,
+		Quantity:  stockHold.Quantity,
+		StartDate: stockHold.StartDate,
+		EndDate:   stockHold.EndDate,
+		Notes:     notes,
+		HoldID:    &stockHold.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateBooking(booking); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
+
+/*
+Metode untuk menandai hold stok yang kedaluwarsa.
+Dijalankan berkala oleh sweeper latar belakang; hold kedaluwarsa sudah tidak dihitung sebagai pemakaian stok sehingga sweeper hanya merapikan statusnya.
+*/
+func (s *bookingService) ExpireHolds() {
+	expired, err := s.repo.ExpireHolds()
+	if err != nil {
+		log.Printf("ExpireHolds: error expiring stock holds: %v", err)
+		return
+	}
+	if expired > 0 {
+		log.Printf("ExpireHolds: %d stock holds expired", expired)
+	}
+}
+
+/*
+Metode untuk mengambil order milik customer yang sedang login.
+Daftar order beserta baris booking-nya dikembalikan.
+*/
+func (s *bookingService) GetCustomerOrders(ctx context.Context) ([]*model.OrderModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetOrdersByCustomer(customerID)
+}
+
+/*
+Metode untuk mengambil detail order milik customer.
+Order milik customer lain diperlakukan sebagai tidak ditemukan.
+*/
+func (s *bookingService) GetCustomerOrder(ctx context.Context, id string) (*model.OrderModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.repo.FindOrderByID(strings.TrimSpace(id))
+	if err != nil {
+		return nil, err
+	}
+	if order == nil || order.CustomerID != customerID {
+		return nil, errors.New(message.MsgOrderNotFound)
+	}
+
+	return order, nil
+}
+
+/*
+Metode untuk mengambil booking milik customer yang sedang login.
+Daftar booking dikembalikan.
+*/
+func (s *bookingService) GetCustomerBookings(ctx context.Context) ([]*model.BookingModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetBookingsByCustomer(customerID)
+}
+
+/*
+Metode untuk mengambil detail booking milik customer.
+Booking milik customer lain diperlakukan sebagai tidak ditemukan.
+*/
+func (s *bookingService) GetCustomerBooking(ctx context.Context, id string) (*model.BookingModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.repo.FindBookingByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil || booking.CustomerID != customerID {
+		return nil, errors.New(message.MsgBookingNotFound)
+	}
+
+	return booking, nil
+}
+
+/*
+Metode untuk membatalkan booking oleh customer.
+Booking yang masih menunggu atau sudah dikonfirmasi dibatalkan sehingga stoknya kembali tersedia, dengan refund dihitung dari kebijakan pembatalan yang disalin saat booking dibuat dan deposit dikembalikan penuh.
+*/
+func (s *bookingService) CancelBooking(ctx context.Context, id string) (*model.BookingModel, error) {
+	booking, err := s.GetCustomerBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canTransition(booking.Status, model.BookingStatusCancelled) {
+		return nil, &TransitionError{From: booking.Status, To: model.BookingStatusCancelled}
+	}
+
+	percent, amount := s.cancellation.Refund(booking, time.Now().UTC())
+	cancelled, err := s.repo.CancelBooking(booking.ID, booking.Status, percent, amount)
+	if err != nil {
+		return nil, err
+	}
+	if cancelled == nil {
+		return nil, s.staleTransition(booking.ID, model.BookingStatusCancelled)
+	}
+
+	return cancelled, nil
+}
+
+/*
+Metode untuk mengambil booking item milik toko.
+Daftar booking dikembalikan, dapat difilter dengan status.
+*/
+func (s *bookingService) GetStoreBookings(ctx context.Context, status string) ([]*model.BookingModel, error) {
+	storeID, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status = strings.TrimSpace(status)
+	if status != "" && !isBookingStatus(status) {
+		return nil, errors.New(message.MsgBookingStatusFilterInvalid)
+	}
+
+	return s.repo.GetBookingsByStore(storeID, status)
+}
+
+/*
+Metode untuk mengambil detail booking item milik toko.
+Booking toko lain diperlakukan sebagai tidak ditemukan.
+*/
+func (s *bookingService) GetStoreBooking(ctx context.Context, id string) (*model.BookingModel, error) {
+	storeID, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.repo.FindBookingByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil || booking.StoreID != storeID {
+		return nil, errors.New(message.MsgBookingNotFound)
+	}
+
+	return booking, nil
+}
+
+/*
+Metode untuk mengonfirmasi booking oleh hoster.
+Booking yang menunggu dikonfirmasi dan stoknya tetap dipesan.
+*/
+func (s *bookingService) ConfirmBooking(ctx context.Context, id string) (*model.BookingModel, error) {
+	booking, err := s.GetStoreBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(booking, model.BookingStatusConfirmed)
+}
+
+/*
+Metode untuk menolak booking oleh hoster.
+Booking yang menunggu ditolak sehingga stoknya kembali tersedia dan deposit dikembalikan penuh.
+*/
+func (s *bookingService) RejectBooking(ctx context.Context, id string) (*model.BookingModel, error) {
+	booking, err := s.GetStoreBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(booking, model.BookingStatusRejected)
+}
+
+/*
+Metode untuk menandai booking sudah diambil customer.
+Booking yang sudah dikonfirmasi berpindah ke status picked_up saat item diserahkan.
+*/
+func (s *bookingService) PickUpBooking(ctx context.Context, id string) (*model.BookingModel, error) {
+	booking, err := s.GetStoreBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(booking, model.BookingStatusPickedUp)
+}
+
+/*
+Metode untuk menandai booking sudah dikembalikan customer.
+Booking yang sedang disewa berpindah ke status returned dan stoknya kembali tersedia.
+*/
+func (s *bookingService) ReturnBooking(ctx context.Context, id string) (*model.BookingModel, error) {
+	booking, err := s.GetStoreBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(booking, model.BookingStatusReturned)
+}
+
+/*
+Metode untuk menyelesaikan booking oleh hoster.
+Booking yang item-nya sudah dikembalikan ditandai selesai.
+*/
+func (s *bookingService) CompleteBooking(ctx context.Context, id string) (*model.BookingModel, error) {
+	booking, err := s.GetStoreBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.transition(booking, model.BookingStatusCompleted)
+}
+
+/*
+Metode untuk mengambil kalender ketersediaan item.
+Sisa unit per hari dihitung dari stok item dikurangi booking aktif, blackout, dan hold stok, default 90 hari mulai hari ini.
+*/
+func (s *bookingService) GetAvailability(itemID string, from, to time.Time) (*model.ItemAvailabilityModel, error) {
+	itemID = strings.TrimSpace(itemID)
+	if itemID == "" {
+		return nil, errors.New(message.MsgItemIDRequired)
+	}
+	if from.IsZero() {
+		from = today()
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, defaultAvailabilityDays-1)
+	}
+	if to.Before(from) || to.After(from.AddDate(0, 0, maxAvailabilityDays-1)) {
+		return nil, errors.New(message.MsgAvailabilityRangeInvalid)
+	}
+
+	item, err := s.pricing.FindItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := s.repo.GetDailyUsage(item.ID, item.Stock, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range days {
+		day.Available = max(item.Stock-day.Reserved-day.Blocked-day.Held, 0)
+	}
+
+	return &model.ItemAvailabilityModel{
+		ItemID: item.ID,
+		Stock:  item.Stock,
+		From:   from.Format(dateLayout),
+		To:     to.Format(dateLayout),
+		Days:   days,
+	}, nil
+}
+
+/*
+Metode untuk membuat periode blackout item milik toko.
+Blackout tanpa jumlah memblokir seluruh unit item pada rentang tanggal.
+*/
+func (s *bookingService) CreateBlackout(ctx context.Context, input *model.ItemBlackoutModel) (*model.ItemBlackoutModel, error) {
+	userID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.storeItem(ctx, input.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.EndDate.Before(input.StartDate) {
+		return nil, errors.New(message.MsgBookingDateRangeInvalid)
+	}
+	if input.EndDate.Before(today()) {
+		return nil, errors.New(message.MsgBookingDateInPast)
+	}
+	if input.Quantity != nil && (*input.Quantity < 1 || *input.Quantity > item.Stock) {
+		return nil, errors.New(message.MsgBlackoutQuantityInvalid)
+	}
+
+	input.StoreID = item.UserID
+	input.CreatedBy = userID
+	input.Reason = strings.TrimSpace(input.Reason)
+	if err := s.repo.CreateBlackout(input); err != nil {
+		return nil, err
+	}
+
+	return input, nil
+}
+
+/*
+Metode untuk mengambil periode blackout item milik toko.
+Daftar blackout yang belum berakhir dikembalikan.
+*/
+func (s *bookingService) GetBlackouts(ctx context.Context, itemID string) ([]*model.ItemBlackoutModel, error) {
+	item, err := s.storeItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetBlackoutsByItem(item.ID)
+}
+
+/*
+Metode untuk menghapus periode blackout item milik toko.
+Unit yang diblokir kembali tersedia untuk dibooking.
+*/
+func (s *bookingService) DeleteBlackout(ctx context.Context, itemID, id string) error {
+	item, err := s.storeItem(ctx, itemID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteBlackout(item.UserID, item.ID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New(message.MsgBlackoutNotFound)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk mengambil item milik toko yang sedang login.
+Item dikembalikan, atau error jika item tidak ada atau milik toko lain.
+*/
+func (s *bookingService) storeItem(ctx context.Context, itemID string) (*model.ItemModel, error) {
+	storeID, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	itemID = strings.TrimSpace(itemID)
+	if itemID == "" {
+		return nil, errors.New(message.MsgItemIDRequired)
+	}
+	item, err := s.pricing.FindItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.UserID != storeID {
+		return nil, errors.New(message.MsgStoreAccessDenied)
+	}
+
+	return item, nil
+}
+
+/*
+Metode untuk menyiapkan booking baru milik customer.
+Item dan rentang tanggal divalidasi, harga dihitung oleh layanan harga, dan aturan kebijakan pembatalan yang berlaku disalin.
+*/
+func (s *bookingService) newBooking(customerID string, input *model.BookingModel) (*model.BookingModel, error) {
+	input.ItemID = strings.TrimSpace(input.ItemID)
+	input.Notes = strings.TrimSpace(input.Notes)
+	if input.ItemID == "" {
+		return nil, errors.New(message.MsgItemIDRequired)
+	}
+	if input.Quantity < 1 {
+		return nil, errors.New(message.MsgBookingQuantityInvalid)
+	}
+	if input.EndDate.Before(input.StartDate) {
+		return nil, errors.New(message.MsgBookingDateRangeInvalid)
+	}
+	if input.StartDate.Before(today()) {
+		return nil, errors.New(message.MsgBookingDateInPast)
+	}
+
+	item, err := s.pricing.FindItem(input.ItemID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Quantity > item.Stock {
+		return nil, errors.New(message.MsgBookingStockUnavailable)
+	}
+
+	quote, err := s.pricing.Quote(item, input.Quantity, input.StartDate, input.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.cancellation.ResolvePolicy(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	booking := &model.BookingModel{
+		ItemID:      item.ID,
+		ItemName:    item.Name,
+		CustomerID:  customerID,
+		StoreID:     item.UserID,
+		Quantity:    input.Quantity,
+		StartDate:   input.StartDate,
+		EndDate:     input.EndDate,
+		Days:        quote.Days,
+		PricePerDay: item.PricePerDay,
+		Discount:    quote.Discount,
+		DeliveryFee: quote.DeliveryFee,
+		TotalPrice:  quote.Total,
+		Deposit:     quote.Deposit,
+		Status:      model.BookingStatusPending,
+		HoldID:      input.HoldID,
+		Notes:       input.Notes,
+		Quote:       quote,
+	}
+	if policy != nil {
+		booking.CancellationPolicyID = &policy.ID
+		booking.CancellationRules = policy.Rules
+	}
+
+	return booking, nil
+}
+
+/*
+Metode untuk memasangkan hold stok customer ke baris checkout.
+Setiap hold harus milik customer, masih aktif, dan cocok dengan tepat satu baris yang item, jumlah, dan tanggalnya sama.
+*/
+func (s *bookingService) attachHolds(customerID string, lines []*model.BookingModel, holdIDs []string) error {
+	for _, id := range holdIDs {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return errors.New(message.MsgStockHoldIDRequired)
+		}
+		stockHold, err := s.repo.FindHoldByID(id)
+		if err != nil {
+			return err
+		}
+		if stockHold == nil || stockHold.CustomerID != customerID {
+			return errors.New(message.MsgStockHoldNotFound)
+		}
+		if stockHold.Status != model.StockHoldStatusActive || !time.Now().Before(stockHold.ExpiresAt) {
+			return errors.New(message.MsgStockHoldExpired)
+		}
+
+		matched := false
+		for _, line := range lines {
+			if line.HoldID == nil && line.ItemID == stockHold.ItemID && line.Quantity == stockHold.Quantity &&
+				line.StartDate.Equal(stockHold.StartDate) && line.EndDate.Equal(stockHold.EndDate) {
+				line.HoldID = &stockHold.ID
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return errors.New(message.MsgStockHoldMismatch)
+		}
+	}
+	return nil
+}
+
+/*
+Metode untuk memindahkan booking ke status baru.
+Perpindahan dicek terhadap tabel transisi lalu disimpan secara bersyarat; TransitionError dikembalikan jika perpindahan tidak diizinkan atau status sudah diubah permintaan lain.
+*/
+func (s *bookingService) transition(booking *model.BookingModel, to string) (*model.BookingModel, error) {
+	if !canTransition(booking.Status, to) {
+		return nil, &TransitionError{From: booking.Status, To: to}
+	}
+
+	updated, err := s.repo.UpdateBookingStatus(booking.ID, booking.Status, to)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, s.staleTransition(booking.ID, to)
+	}
+
+	return updated, nil
+}
+
+/*
+Metode untuk membuat error perpindahan status yang didahului permintaan lain.
+Status terbaru booking dibaca ulang agar TransitionError berisi status yang sebenarnya.
+*/
+func (s *bookingService) staleTransition(id, to string) error {
+	current, err := s.repo.FindBookingByID(id)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return errors.New(message.MsgBookingNotFound)
+	}
+	return &TransitionError{From: current.Status, To: to}
+}
+
+/*
+Interface untuk operasi layanan booking.
+Interface ini mendefinisikan metode untuk logika bisnis booking.
+*/
+type BookingService interface {
+	CreateBooking(ctx context.Context, input *model.BookingModel) (*model.BookingModel, error)
+	Checkout(ctx context.Context, lines []*model.BookingModel, notes string, holdIDs []string) ([]*model.OrderModel, error)
+	CreateHolds(ctx context.Context, lines []*model.BookingModel) ([]*model.StockHoldModel, error)
+	GetCustomerHolds(ctx context.Context) ([]*model.StockHoldModel, error)
+	ReleaseHold(ctx context.Context, id string) error
+	BookHold(ctx context.Context, id, notes string) (*model.BookingModel, error)
+	ExpireHolds()
+	GetCustomerOrders(ctx context.Context) ([]*model.OrderModel, error)
+	GetCustomerOrder(ctx context.Context, id string) (*model.OrderModel, error)
+	GetCustomerBookings(ctx context.Context) ([]*model.BookingModel, error)
+	GetCustomerBooking(ctx context.Context, id string) (*model.BookingModel, error)
+	CancelBooking(ctx context.Context, id string) (*model.BookingModel, error)
+	GetStoreBookings(ctx context.Context, status string) ([]*model.BookingModel, error)
+	GetStoreBooking(ctx context.Context, id string) (*model.BookingModel, error)
+	ConfirmBooking(ctx context.Context, id string) (*model.BookingModel, error)
+	RejectBooking(ctx context.Context, id string) (*model.BookingModel, error)
+	PickUpBooking(ctx context.Context, id string) (*model.BookingModel, error)
+	ReturnBooking(ctx context.Context, id string) (*model.BookingModel, error)
+	CompleteBooking(ctx context.Context, id string) (*model.BookingModel, error)
+	GetAvailability(itemID string, from, to time.Time) (*model.ItemAvailabilityModel, error)
+	CreateBlackout(ctx context.Context, input *model.ItemBlackoutModel) (*model.ItemBlackoutModel, error)
+	GetBlackouts(ctx context.Context, itemID string) ([]*model.ItemBlackoutModel, error)
+	DeleteBlackout(ctx context.Context, itemID, id string) error
+}
+
+/*
+Fungsi untuk mengambil ID pengguna dari konteks.
+ID pengguna dikembalikan, atau error jika klaim token tidak valid.
+*/
+func userFromContext(ctx context.Context) (string, error) {
+	claims := middleware.GetClaims(ctx)
+	if claims == nil || claims.Subject == "" {
+		return "", errors.New("invalid token claims")
+	}
+	return claims.Subject, nil
+}
+
+/*
+Fungsi untuk mengambil ID toko dari konteks.
+ID toko dikembalikan, yaitu ID pemilik toko untuk pemilik maupun stafnya.
+*/
+func storeFromContext(ctx context.Context) (string, error) {
+	claims := middleware.GetClaims(ctx)
+	if claims == nil || claims.Subject == "" {
+		return "", errors.New("invalid token claims")
+	}
+	return claims.StoreID(), nil
+}
+
+/*
+Fungsi untuk memeriksa apakah status booking dikenal.
+Nilai true dikembalikan untuk status yang didefinisikan model booking.
+*/
+func isBookingStatus(status string) bool {
+	switch status {
+	case model.BookingStatusPending, model.BookingStatusConfirmed, model.BookingStatusRejected, model.BookingStatusPickedUp,
+		model.BookingStatusReturned, model.BookingStatusCompleted, model.BookingStatusCancelled:
+		return true
+	}
+	return false
+}
+
+/*
+Fungsi untuk memeriksa apakah perpindahan status booking diizinkan.
+Nilai true dikembalikan jika status tujuan terdaftar untuk status asal di tabel transisi.
+*/
+func canTransition(from, to string) bool {
+	return slices.Contains(bookingTransitions[from], to)
+}
+
+/*
+Fungsi untuk mengambil tanggal hari ini.
+Tanggal hari ini dalam UTC tanpa komponen jam dikembalikan.
+*/
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+/*
+Fungsi untuk membuat instance baru dari BookingService.
+Instance layanan dikembalikan.
+*/
+func NewBookingService(repo BookingRepository, pricing pricing.PricingService, cancellation cancellation.CancellationService, holds config.StockHoldConfig) BookingService {
+	return &bookingService{repo: repo, pricing: pricing, cancellation: cancellation, holds: holds}
+}
@@ -0,0 +1,249 @@
+package cancellation
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/model"
+	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
+)
+
+/*
+Struktur untuk handler kebijakan pembatalan.
+Struktur ini menangani permintaan pengelolaan kebijakan pembatalan toko dan pemasangannya ke item.
+*/
+type CancellationHandler struct {
+	service CancellationService
+}
+
+/*
+Struktur untuk permintaan pembuatan atau perubahan kebijakan pembatalan.
+Struktur ini berisi nama, deskripsi, status bawaan, dan aturan refund atau nama preset yang menggantikan aturan.
+*/
+type PolicyRequest struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Preset      string                  `json:"preset"`
+	Rules       model.CancellationRules `json:"rules"`
+	IsDefault   bool                    `json:"is_default"`
+}
+
+/*
+Struktur untuk permintaan pemasangan kebijakan pembatalan ke item.
+Struktur ini berisi ID kebijakan, kosong untuk kembali ke kebijakan bawaan toko.
+*/
+type ItemPolicyRequest struct {
+	PolicyID string `json:"policy_id"`
+}
+
+/*
+Metode untuk mengambil kebijakan pembatalan siap pakai.
+Aturan refund setiap preset dikembalikan.
+*/
+func (h *CancellationHandler) GetPresets(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetPresets: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	response.OK(w, h.service.Presets(), message.MsgSuccess)
+}
+
+/*
+Metode untuk mengambil kebijakan pembatalan milik toko.
+Daftar kebijakan dikembalikan.
+*/
+func (h *CancellationHandler) GetPolicies(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetPolicies: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	policies, err := h.service.ListPolicies(r.Context())
+	if err != nil {
+		log.Printf("GetPolicies: error: %v", err)
+		writeCancellationError(w, err)
+		return
+	}
+
+	response.OK(w, policies, message.MsgSuccess)
+}
+
+/*
+Metode untuk membuat kebijakan pembatalan toko.
+Kebijakan yang dibuat dikembalikan.
+*/
+func (h *CancellationHandler) CreatePolicy(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CreatePolicy: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req PolicyRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("CreatePolicy: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	input := &model.CancellationPolicyModel{
+		Name:        req.Name,
+		Description: req.Description,
+		Rules:       req.Rules,
+		IsDefault:   req.IsDefault,
+	}
+	policy, err := h.service.CreatePolicy(r.Context(), input, req.Preset)
+	if err != nil {
+		log.Printf("CreatePolicy: error: %v", err)
+		writeCancellationError(w, err)
+		return
+	}
+
+	response.Created(w, policy, message.MsgCancellationPolicyCreated)
+}
+
+/*
+Metode untuk memperbarui kebijakan pembatalan toko.
+Kebijakan yang diperbarui dikembalikan.
+*/
+func (h *CancellationHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	log.Printf("UpdatePolicy: received request")
+	if r.Method != http.MethodPut {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req PolicyRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("UpdatePolicy: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	input := &model.CancellationPolicyModel{
+		ID:          mux.Vars(r)["id"],
+		Name:        req.Name,
+		Description: req.Description,
+		Rules:       req.Rules,
+		IsDefault:   req.IsDefault,
+	}
+	policy, err := h.service.UpdatePolicy(r.Context(), input, req.Preset)
+	if err != nil {
+		log.Printf("UpdatePolicy: error: %v", err)
+		writeCancellationError(w, err)
+		return
+	}
+
+	response.OK(w, policy, message.MsgCancellationPolicyUpdated)
+}
+
+/*
+Metode untuk menghapus kebijakan pembatalan toko.
+Respons sukses dikembalikan jika kebijakan dihapus.
+*/
+func (h *CancellationHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	log.Printf("DeletePolicy: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	id := strings.TrimSpace(mux.Vars(r)["id"])
+	if id == "" {
+		response.BadRequest(w, message.MsgCancellationPolicyIDRequired)
+		return
+	}
+
+	if err := h.service.DeletePolicy(r.Context(), id); err != nil {
+		log.Printf("DeletePolicy: error: %v", err)
+		writeCancellationError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgCancellationPolicyDeleted)
+}
+
+/*
+Metode untuk memasang kebijakan pembatalan ke item milik toko.
+Respons sukses dikembalikan jika kebijakan item diperbarui.
+*/
+func (h *CancellationHandler) AssignItemPolicy(w http.ResponseWriter, r *http.Request) {
+	log.Printf("AssignItemPolicy: received request")
+	if r.Method != http.MethodPut {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ItemPolicyRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("AssignItemPolicy: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	if err := h.service.AssignItemPolicy(r.Context(), mux.Vars(r)["id"], req.PolicyID); err != nil {
+		log.Printf("AssignItemPolicy: error: %v", err)
+		writeCancellationError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgCancellationPolicyAssigned)
+}
+
+/*
+Metode untuk mengambil kebijakan pembatalan yang berlaku untuk item.
+Kebijakan item atau kebijakan bawaan toko dikembalikan untuk ditampilkan sebelum booking.
+*/
+func (h *CancellationHandler) GetItemPolicy(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetItemPolicy: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	policy, err := h.service.ResolvePolicy(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetItemPolicy: error: %v", err)
+		writeCancellationError(w, err)
+		return
+	}
+	if policy == nil {
+		response.Error(w, http.StatusNotFound, message.MsgCancellationPolicyNone)
+		return
+	}
+
+	response.OK(w, policy, message.MsgSuccess)
+}
+
+/*
+Fungsi untuk menulis respons error kebijakan pembatalan.
+Pesan error layanan dipetakan ke status HTTP yang sesuai.
+*/
+func writeCancellationError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.MsgItemIDRequired, message.MsgCancellationPolicyIDRequired, message.MsgCancellationPolicyNameRequired,
+		message.MsgCancellationPolicyPresetInvalid, message.MsgCancellationPolicyRulesInvalid:
+		response.BadRequest(w, err.Error())
+	case message.MsgItemNotFound, message.MsgCancellationPolicyNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+	}
+}
+
+/*
+Fungsi untuk membuat instance baru dari CancellationHandler.
+Instance handler dikembalikan.
+*/
+func NewCancellationHandler(s CancellationService) *CancellationHandler {
+	return &CancellationHandler{service: s}
+}
@@ -0,0 +1,246 @@
+package cancellation
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Konstanta untuk kolom kebijakan pembatalan yang dipilih.
+Konstanta ini dipakai bersama oleh query pencarian dan daftar kebijakan.
+*/
+const policyColumns = `
+	p.id,
+	p.store_id,
+	p.name,
+	p.description,
+	p.rules,
+	p.is_default,
+	p.created_at,
+	p.updated_at
+`
+
+/*
+Struktur untuk repositori kebijakan pembatalan.
+Struktur ini menyediakan akses ke operasi database untuk kebijakan pembatalan toko.
+*/
+type cancellationRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk membuat kebijakan pembatalan baru.
+Kebijakan bawaan lain milik toko dilepas dalam transaksi yang sama jika kebijakan baru dijadikan bawaan.
+*/
+func (r *cancellationRepository) CreatePolicy(policy *model.CancellationPolicyModel) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if policy.IsDefault {
+		if err := clearDefault(tx, policy.StoreID, ""); err != nil {
+			return err
+		}
+	}
+
+	insert := `
+		INSERT INTO cancellation_policies (
+			store_id,
+			name,
+			description,
+			rules,
+			is_default
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	err = tx.QueryRow(insert, policy.StoreID, policy.Name, policy.Description, policy.Rules, policy.IsDefault).Scan(&policy.ID, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+Metode untuk memperbarui kebijakan pembatalan milik toko.
+Nilai true dikembalikan jika kebijakan ditemukan dan diperbarui.
+*/
+func (r *cancellationRepository) UpdatePolicy(policy *model.CancellationPolicyModel) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if policy.IsDefault {
+		if err := clearDefault(tx, policy.StoreID, policy.ID); err != nil {
+			return false, err
+		}
+	}
+
+	update := `
+		UPDATE cancellation_policies
+		SET name = $1, description = $2, rules = $3, is_default = $4
+		WHERE id = $5 AND store_id = $6
+		RETURNING created_at, updated_at
+	`
+	err = tx.QueryRow(update, policy.Name, policy.Description, policy.Rules, policy.IsDefault, policy.ID, policy.StoreID).Scan(&policy.CreatedAt, &policy.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+/*
+Metode untuk menghapus kebijakan pembatalan milik toko.
+Item yang memakai kebijakan ini kembali ke kebijakan bawaan toko; nilai true dikembalikan jika kebijakan dihapus.
+*/
+func (r *cancellationRepository) DeletePolicy(storeID, id string) (bool, error) {
+	query := `
+		DELETE FROM cancellation_policies
+		WHERE id = $1 AND store_id = $2
+	`
+	res, err := r.db.Exec(query, id, storeID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+/*
+Metode untuk mencari kebijakan pembatalan berdasarkan ID.
+Model kebijakan dikembalikan jika ditemukan.
+*/
+func (r *cancellationRepository) FindPolicyByID(id string) (*model.CancellationPolicyModel, error) {
+	var policy model.CancellationPolicyModel
+	query := `
+		SELECT ` + policyColumns + `
+		FROM cancellation_policies p
+		WHERE p.id = $1
+	`
+	err := r.db.Get(&policy, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindPolicyByID: error querying policy %s: %v", id, err)
+		return nil, err
+	}
+	return &policy, nil
+}
+
+/*
+Metode untuk mengambil kebijakan pembatalan milik toko.
+Daftar kebijakan dikembalikan dengan kebijakan bawaan lebih dulu.
+*/
+func (r *cancellationRepository) GetPoliciesByStore(storeID string) ([]*model.CancellationPolicyModel, error) {
+	policies := []*model.CancellationPolicyModel{}
+	query := `
+		SELECT ` + policyColumns + `
+		FROM cancellation_policies p
+		WHERE p.store_id = $1
+		ORDER BY p.is_default DESC, p.name
+	`
+	if err := r.db.Select(&policies, query, storeID); err != nil {
+		log.Printf("GetPoliciesByStore: error querying store %s: %v", storeID, err)
+		return nil, err
+	}
+	return policies, nil
+}
+
+/*
+Metode untuk memasang kebijakan pembatalan ke item milik toko.
+Kebijakan kosong melepas kebijakan item; nilai true dikembalikan jika item ditemukan.
+*/
+func (r *cancellationRepository) AssignItemPolicy(storeID, itemID string, policyID *string) (bool, error) {
+	query := `
+		UPDATE item
+		SET cancellation_policy_id = $1
+		WHERE id = $2 AND user_id = $3
+	`
+	res, err := r.db.Exec(query, policyID, itemID, storeID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+/*
+Metode untuk mencari kebijakan pembatalan yang berlaku untuk item.
+Kebijakan item dikembalikan, atau kebijakan bawaan toko jika item tidak memiliki kebijakan sendiri, atau nil jika keduanya tidak ada.
+*/
+func (r *cancellationRepository) FindPolicyForItem(itemID string) (*model.CancellationPolicyModel, error) {
+	var policy model.CancellationPolicyModel
+	query := `
+		SELECT ` + policyColumns + `
+		FROM item i
+		JOIN cancellation_policies p
+			ON p.id = i.cancellation_policy_id
+			OR (i.cancellation_policy_id IS NULL AND p.store_id = i.user_id AND p.is_default)
+		WHERE i.id = $1
+		LIMIT 1
+	`
+	err := r.db.Get(&policy, query, itemID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindPolicyForItem: error querying item %s: %v", itemID, err)
+		return nil, err
+	}
+	return &policy, nil
+}
+
+/*
+Interface untuk operasi repositori kebijakan pembatalan.
+Interface ini mendefinisikan metode untuk mengelola kebijakan pembatalan dan pemasangannya ke item.
+*/
+type CancellationRepository interface {
+	CreatePolicy(policy *model.CancellationPolicyModel) error
+	UpdatePolicy(policy *model.CancellationPolicyModel) (bool, error)
+	DeletePolicy(storeID, id string) (bool, error)
+	FindPolicyByID(id string) (*model.CancellationPolicyModel, error)
+	GetPoliciesByStore(storeID string) ([]*model.CancellationPolicyModel, error)
+	AssignItemPolicy(storeID, itemID string, policyID *string) (bool, error)
+	FindPolicyForItem(itemID string) (*model.CancellationPolicyModel, error)
+}
+
+/*
+Fungsi untuk melepas status bawaan kebijakan lain milik toko.
+Semua kebijakan bawaan toko selain ID yang dikecualikan diubah menjadi bukan bawaan.
+*/
+func clearDefault(tx *sqlx.Tx, storeID, exceptID string) error {
+	query := `
+		UPDATE cancellation_policies
+		SET is_default = FALSE
+		WHERE store_id = $1 AND is_default AND id::text <> $2
+	`
+	_, err := tx.Exec(query, storeID, exceptID)
+	return err
+}
+
+/*
+Fungsi untuk membuat instance baru dari CancellationRepository.
+Instance repositori dikembalikan.
+*/
+func NewCancellationRepository(db *sqlx.DB) CancellationRepository {
+	return &cancellationRepository{db: db}
+}
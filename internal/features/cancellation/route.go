@@ -0,0 +1,33 @@
+package cancellation
+
+import (
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/middleware"
+)
+
+/*
+Fungsi untuk mengatur rute fitur kebijakan pembatalan.
+Router dikonfigurasi dengan rute kebijakan item publik dan pengelolaan kebijakan oleh hoster.
+*/
+func SetupCancellationRoutes(router *mux.Router, h *CancellationHandler) {
+	// Setup public routes
+	router.HandleFunc("/api/v1/public/item/{id}/cancellation-policy", h.GetItemPolicy).Methods("GET")
+
+	// Setup group kebijakan pembatalan hoster
+	policies := router.PathPrefix("/api/v1/hoster/cancellation-policies").Subrouter()
+	policies.Use(middleware.JWTMiddleware)
+	policies.Use(middleware.Hoster)
+	policies.Handle("", middleware.RequireFunc(h.GetPolicies, auth.PermTermsRead)).Methods("GET")
+	policies.Handle("", middleware.RequireFunc(h.CreatePolicy, auth.PermTermsWrite)).Methods("POST")
+	policies.Handle("/presets", middleware.RequireFunc(h.GetPresets, auth.PermTermsRead)).Methods("GET")
+	policies.Handle("/{id}", middleware.RequireFunc(h.UpdatePolicy, auth.PermTermsWrite)).Methods("PUT")
+	policies.Handle("/{id}", middleware.RequireFunc(h.DeletePolicy, auth.PermTermsWrite)).Methods("DELETE")
+
+	// Setup group kebijakan pembatalan item hoster
+	items := router.PathPrefix("/api/v1/hoster/items/{id}/cancellation-policy").Subrouter()
+	items.Use(middleware.JWTMiddleware)
+	items.Use(middleware.Hoster)
+	items.Handle("", middleware.RequireFunc(h.AssignItemPolicy, auth.PermItemWrite)).Methods("PUT")
+}
@@ -0,0 +1,282 @@
+package cancellation
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk batas jumlah aturan dalam satu kebijakan pembatalan.
+Konstanta ini mencegah kebijakan dengan tingkatan refund yang berlebihan.
+*/
+const maxPolicyRules = 10
+
+/*
+Variabel untuk kebijakan pembatalan siap pakai.
+Variabel ini memetakan nama preset ke aturan refund yang dapat dipilih hoster tanpa menyusun aturan sendiri.
+*/
+var presetRules = map[string]model.CancellationRules{
+	"flexible": {{HoursBefore: 24, RefundPercent: 100}},
+	"moderate": {{HoursBefore: 72, RefundPercent: 100}, {HoursBefore: 24, RefundPercent: 50}},
+	"strict":   {{HoursBefore: 168, RefundPercent: 50}},
+}
+
+/*
+Struktur untuk layanan kebijakan pembatalan.
+Struktur ini menyediakan logika bisnis untuk kebijakan pembatalan toko dan perhitungan refund booking.
+*/
+type cancellationService struct {
+	repo CancellationRepository
+}
+
+/*
+Metode untuk mengambil kebijakan pembatalan siap pakai.
+Peta nama preset ke aturan refund dikembalikan.
+*/
+func (s *cancellationService) Presets() map[string]model.CancellationRules {
+	return presetRules
+}
+
+/*
+Metode untuk mengambil kebijakan pembatalan milik toko yang sedang login.
+Daftar kebijakan dikembalikan.
+*/
+func (s *cancellationService) ListPolicies(ctx context.Context) ([]*model.CancellationPolicyModel, error) {
+	storeID, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetPoliciesByStore(storeID)
+}
+
+/*
+Metode untuk membuat kebijakan pembatalan toko.
+Aturan diambil dari preset jika diisi, lalu divalidasi dan diurutkan sebelum disimpan.
+*/
+func (s *cancellationService) CreatePolicy(ctx context.Context, input *model.CancellationPolicyModel, preset string) (*model.CancellationPolicyModel, error) {
+	storeID, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := preparePolicy(input, preset); err != nil {
+		return nil, err
+	}
+
+	input.StoreID = storeID
+	if err := s.repo.CreatePolicy(input); err != nil {
+		return nil, err
+	}
+
+	return input, nil
+}
+
+/*
+Metode untuk memperbarui kebijakan pembatalan toko.
+Booking yang sudah dibuat tetap memakai salinan aturan saat booking dibuat.
+*/
+func (s *cancellationService) UpdatePolicy(ctx context.Context, input *model.CancellationPolicyModel, preset string) (*model.CancellationPolicyModel, error) {
+	storeID, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	input.ID = strings.TrimSpace(input.ID)
+	if input.ID == "" {
+		return nil, errors.New(message.MsgCancellationPolicyIDRequired)
+	}
+	if err := preparePolicy(input, preset); err != nil {
+		return nil, err
+	}
+
+	input.StoreID = storeID
+	updated, err := s.repo.UpdatePolicy(input)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.New(message.MsgCancellationPolicyNotFound)
+	}
+
+	return input, nil
+}
+
+/*
+Metode untuk menghapus kebijakan pembatalan toko.
+Item yang memakai kebijakan ini kembali ke kebijakan bawaan toko.
+*/
+func (s *cancellationService) DeletePolicy(ctx context.Context, id string) error {
+	storeID, err := storeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeletePolicy(storeID, strings.TrimSpace(id))
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New(message.MsgCancellationPolicyNotFound)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk memasang kebijakan pembatalan ke item milik toko.
+ID kebijakan kosong melepas kebijakan item sehingga kebijakan bawaan toko berlaku.
+*/
+func (s *cancellationService) AssignItemPolicy(ctx context.Context, itemID, policyID string) error {
+	storeID, err := storeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	itemID = strings.TrimSpace(itemID)
+	if itemID == "" {
+		return errors.New(message.MsgItemIDRequired)
+	}
+
+	var assigned *string
+	if policyID = strings.TrimSpace(policyID); policyID != "" {
+		policy, err := s.repo.FindPolicyByID(policyID)
+		if err != nil {
+			return err
+		}
+		if policy == nil || policy.StoreID != storeID {
+			return errors.New(message.MsgCancellationPolicyNotFound)
+		}
+		assigned = &policy.ID
+	}
+
+	found, err := s.repo.AssignItemPolicy(storeID, itemID, assigned)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.New(message.MsgItemNotFound)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk mencari kebijakan pembatalan yang berlaku untuk item.
+Kebijakan item atau kebijakan bawaan toko dikembalikan, atau nil jika toko belum menentukan kebijakan.
+*/
+func (s *cancellationService) ResolvePolicy(itemID string) (*model.CancellationPolicyModel, error) {
+	itemID = strings.TrimSpace(itemID)
+	if itemID == "" {
+		return nil, errors.New(message.MsgItemIDRequired)
+	}
+	return s.repo.FindPolicyForItem(itemID)
+}
+
+/*
+Metode untuk menghitung refund pembatalan booking.
+Booking yang belum dikonfirmasi atau tanpa kebijakan mendapat refund penuh; selain itu aturan pertama yang batas jamnya terpenuhi sebelum tanggal mulai sewa dipakai, dan deposit tidak termasuk karena selalu dikembalikan.
+*/
+func (s *cancellationService) Refund(booking *model.BookingModel, at time.Time) (int, int) {
+	percent := 100
+	if booking.Status != model.BookingStatusPending && len(booking.CancellationRules) > 0 {
+		percent = 0
+		hours := booking.StartDate.Sub(at).Hours()
+		for _, rule := range booking.CancellationRules {
+			if hours >= float64(rule.HoursBefore) {
+				percent = rule.RefundPercent
+				break
+			}
+		}
+	}
+	return percent, booking.TotalPrice * percent / 100
+}
+
+/*
+Interface untuk operasi layanan kebijakan pembatalan.
+Interface ini mendefinisikan metode untuk mengelola kebijakan pembatalan dan menghitung refund.
+*/
+type CancellationService interface {
+	Presets() map[string]model.CancellationRules
+	ListPolicies(ctx context.Context) ([]*model.CancellationPolicyModel, error)
+	CreatePolicy(ctx context.Context, input *model.CancellationPolicyModel, preset string) (*model.CancellationPolicyModel, error)
+	UpdatePolicy(ctx context.Context, input *model.CancellationPolicyModel, preset string) (*model.CancellationPolicyModel, error)
+	DeletePolicy(ctx context.Context, id string) error
+	AssignItemPolicy(ctx context.Context, itemID, policyID string) error
+	ResolvePolicy(itemID string) (*model.CancellationPolicyModel, error)
+	Refund(booking *model.BookingModel, at time.Time) (int, int)
+}
+
+/*
+Fungsi untuk menyiapkan kebijakan pembatalan sebelum disimpan.
+Nama dan deskripsi dirapikan, aturan preset diterapkan, lalu aturan divalidasi dan diurutkan dari batas jam terbesar.
+*/
+func preparePolicy(policy *model.CancellationPolicyModel, preset string) error {
+	policy.Name = strings.TrimSpace(policy.Name)
+	policy.Description = strings.TrimSpace(policy.Description)
+	if policy.Name == "" {
+		return errors.New(message.MsgCancellationPolicyNameRequired)
+	}
+
+	if preset = strings.ToLower(strings.TrimSpace(preset)); preset != "" {
+		rules, ok := presetRules[preset]
+		if !ok {
+			return errors.New(message.MsgCancellationPolicyPresetInvalid)
+		}
+		policy.Rules = slices.Clone(rules)
+	}
+
+	if !validRules(policy.Rules) {
+		return errors.New(message.MsgCancellationPolicyRulesInvalid)
+	}
+	slices.SortFunc(policy.Rules, func(a, b model.CancellationRuleModel) int {
+		return b.HoursBefore - a.HoursBefore
+	})
+
+	return nil
+}
+
+/*
+Fungsi untuk memvalidasi aturan refund.
+Nilai true dikembalikan jika jumlah aturan 1 sampai 10, batas jam tidak negatif dan tidak berulang, serta persentase 0 sampai 100.
+*/
+func validRules(rules model.CancellationRules) bool {
+	if len(rules) == 0 || len(rules) > maxPolicyRules {
+		return false
+	}
+	seen := make(map[int]struct{}, len(rules))
+	for _, rule := range rules {
+		if rule.HoursBefore < 0 || rule.RefundPercent < 0 || rule.RefundPercent > 100 {
+			return false
+		}
+		if _, ok := seen[rule.HoursBefore]; ok {
+			return false
+		}
+		seen[rule.HoursBefore] = struct{}{}
+	}
+	return true
+}
+
+/*
+Fungsi untuk mengambil ID toko dari konteks.
+ID toko dikembalikan, yaitu ID pemilik toko untuk pemilik maupun stafnya.
+*/
+func storeFromContext(ctx context.Context) (string, error) {
+	claims := middleware.GetClaims(ctx)
+	if claims == nil || claims.Subject == "" {
+		return "", errors.New("invalid token claims")
+	}
+	return claims.StoreID(), nil
+}
+
+/*
+Fungsi untuk membuat instance baru dari CancellationService.
+Instance layanan dikembalikan.
+*/
+func NewCancellationService(repo CancellationRepository) CancellationService {
+	return &cancellationService{repo: repo}
+}
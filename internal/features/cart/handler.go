@@ -0,0 +1,288 @@
+package cart
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk format tanggal keranjang.
+Konstanta ini digunakan untuk membaca tanggal mulai dan selesai sewa keranjang.
+*/
+const dateLayout = "2006-01-02"
+
+/*
+Struktur untuk handler keranjang.
+Struktur ini menangani permintaan pengelolaan keranjang dan checkout customer.
+*/
+type CartHandler struct {
+	service CartService
+}
+
+/*
+Struktur untuk permintaan item keranjang.
+Struktur ini berisi item dan jumlah unit; ID item diambil dari path saat mengubah jumlah.
+*/
+type CartItemRequest struct {
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"`
+}
+
+/*
+Struktur untuk permintaan rentang tanggal keranjang.
+Struktur ini berisi tanggal mulai dan selesai sewa dalam format YYYY-MM-DD.
+*/
+type CartDatesRequest struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+/*
+Struktur untuk permintaan checkout keranjang.
+Struktur ini berisi catatan yang disalin ke setiap order dan booking.
+*/
+type CheckoutRequest struct {
+	Notes   string   `json:"notes"`
+	HoldIDs []string `json:"hold_ids"`
+}
+
+/*
+Metode untuk mengambil keranjang customer.
+Item per toko beserta penawaran harganya dikembalikan.
+*/
+func (h *CartHandler) GetCart(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetCart: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	cart, err := h.service.GetCart(r.Context())
+	if err != nil {
+		log.Printf("GetCart: error: %v", err)
+		writeCartError(w, err)
+		return
+	}
+
+	response.OK(w, cart, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengatur rentang tanggal sewa keranjang.
+Respons sukses dikembalikan jika tanggal tersimpan.
+*/
+func (h *CartHandler) SetDates(w http.ResponseWriter, r *http.Request) {
+	log.Printf("SetDates: received request")
+	if r.Method != http.MethodPut {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req CartDatesRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("SetDates: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	// Validasi format tanggal
+	start, err := time.Parse(dateLayout, strings.TrimSpace(req.StartDate))
+	if err != nil {
+		response.BadRequest(w, message.MsgBookingDateInvalid)
+		return
+	}
+	end, err := time.Parse(dateLayout, strings.TrimSpace(req.EndDate))
+	if err != nil {
+		response.BadRequest(w, message.MsgBookingDateInvalid)
+		return
+	}
+
+	if err := h.service.SetDates(r.Context(), start, end); err != nil {
+		log.Printf("SetDates: error: %v", err)
+		writeCartError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgCartDatesUpdated)
+}
+
+/*
+Metode untuk menambahkan item ke keranjang.
+Respons sukses dikembalikan jika item ditambahkan.
+*/
+func (h *CartHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	log.Printf("AddItem: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req CartItemRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("AddItem: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	if err := h.service.AddItem(r.Context(), req.ItemID, req.Quantity); err != nil {
+		log.Printf("AddItem: error: %v", err)
+		writeCartError(w, err)
+		return
+	}
+
+	response.Created(w, nil, message.MsgCartItemAdded)
+}
+
+/*
+Metode untuk mengubah jumlah unit item di keranjang.
+Respons sukses dikembalikan jika jumlah diperbarui.
+*/
+func (h *CartHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	log.Printf("UpdateItem: received request")
+	if r.Method != http.MethodPut {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req CartItemRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("UpdateItem: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	if err := h.service.UpdateItem(r.Context(), mux.Vars(r)["item_id"], req.Quantity); err != nil {
+		log.Printf("UpdateItem: error: %v", err)
+		writeCartError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgCartItemUpdated)
+}
+
+/*
+Metode untuk menghapus item dari keranjang.
+Respons sukses dikembalikan jika item dihapus.
+*/
+func (h *CartHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RemoveItem: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	if err := h.service.RemoveItem(r.Context(), mux.Vars(r)["item_id"]); err != nil {
+		log.Printf("RemoveItem: error: %v", err)
+		writeCartError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgCartItemRemoved)
+}
+
+/*
+Metode untuk mengosongkan keranjang customer.
+Respons sukses dikembalikan setelah keranjang dikosongkan.
+*/
+func (h *CartHandler) ClearCart(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ClearCart: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	if err := h.service.Clear(r.Context()); err != nil {
+		log.Printf("ClearCart: error: %v", err)
+		writeCartError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgCartCleared)
+}
+
+/*
+Metode untuk menahan stok seluruh item keranjang.
+Hold yang dibuat dikembalikan beserta penawaran harga dan waktu kedaluwarsanya.
+*/
+func (h *CartHandler) Hold(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Hold: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	holds, err := h.service.Hold(r.Context())
+	if err != nil {
+		log.Printf("Hold: error: %v", err)
+		writeCartError(w, err)
+		return
+	}
+
+	response.Created(w, holds, message.MsgCartHeld)
+}
+
+/*
+Metode untuk checkout keranjang customer.
+Order yang dibuat, satu per toko, dikembalikan beserta baris booking-nya.
+*/
+func (h *CartHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Checkout: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req CheckoutRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("Checkout: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	orders, err := h.service.Checkout(r.Context(), req.Notes, req.HoldIDs)
+	if err != nil {
+		log.Printf("Checkout: error: %v", err)
+		writeCartError(w, err)
+		return
+	}
+
+	response.Created(w, orders, message.MsgCartCheckedOut)
+}
+
+/*
+Fungsi untuk mengirim respons error keranjang.
+Error validasi dipetakan ke 400, data tidak ditemukan ke 404, dan stok yang tidak mencukupi, hold kedaluwarsa, atau batas hold ke 409.
+*/
+func writeCartError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.MsgItemIDRequired, message.MsgBookingQuantityInvalid, message.MsgBookingDateRangeInvalid, message.MsgBookingDateInPast,
+		message.MsgCartEmpty, message.MsgCartDatesRequired, message.MsgStockHoldIDRequired, message.MsgStockHoldMismatch, message.MsgBookingPeriodTooLong:
+		response.BadRequest(w, err.Error())
+	case message.MsgItemNotFound, message.MsgCustomerNotFound, message.MsgCartItemNotFound, message.MsgStockHoldNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case message.MsgBookingStockUnavailable, message.MsgStockHoldExpired, message.MsgStockHoldLimit, message.MsgStockHoldItemLimit:
+		response.Conflict(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+	}
+}
+
+/*
+Fungsi untuk membuat instance baru dari CartHandler.
+Instance handler dikembalikan.
+*/
+func NewCartHandler(s CartService) *CartHandler {
+	return &CartHandler{service: s}
+}
@@ -0,0 +1,174 @@
+package cart
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Struktur untuk repositori keranjang.
+Struktur ini menyediakan akses ke operasi database untuk keranjang customer.
+*/
+type cartRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk mengambil keranjang customer.
+Rentang tanggal keranjang dan item di dalamnya dikembalikan urut waktu ditambahkan; item dari toko yang sudah dihapus tidak disertakan.
+*/
+func (r *cartRepository) GetCart(customerID string) (*model.CartModel, []*model.CartItemModel, error) {
+	cart := &model.CartModel{}
+	query := `SELECT start_date, end_date FROM carts WHERE customer_id = $1`
+	err := r.db.Get(cart, query, customerID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("GetCart: error querying customer %s: %v", customerID, err)
+		return nil, nil, err
+	}
+
+	items := []*model.CartItemModel{}
+	query = `
+		SELECT
+			ci.item_id,
+			i.name AS item_name,
+			i.user_id AS store_id,
+			h.store_name,
+			i.stock,
+			ci.quantity,
+			ci.added_at
+		FROM cart_items ci
+		JOIN item i ON i.id = ci.item_id
+		JOIN hoster h ON h.id = i.user_id
+		WHERE ci.customer_id = $1 AND h.deleted_at IS NULL
+		ORDER BY ci.added_at, ci.item_id
+	`
+	if err := r.db.Select(&items, query, customerID); err != nil {
+		log.Printf("GetCart: error querying items of customer %s: %v", customerID, err)
+		return nil, nil, err
+	}
+	return cart, items, nil
+}
+
+/*
+Metode untuk menyimpan rentang tanggal sewa keranjang.
+Keranjang dibuat jika belum ada.
+*/
+func (r *cartRepository) SetDates(customerID string, start, end time.Time) error {
+	query := `
+		INSERT INTO carts (customer_id, start_date, end_date)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (customer_id) DO UPDATE
+		SET start_date = EXCLUDED.start_date, end_date = EXCLUDED.end_date, updated_at = NOW()
+	`
+	_, err := r.db.Exec(query, customerID, start, end)
+	return err
+}
+
+/*
+Metode untuk menambahkan item ke keranjang.
+Jumlah unit ditambahkan ke jumlah yang sudah ada di keranjang; nilai false dikembalikan tanpa perubahan jika jumlah gabungan melebihi batas.
+*/
+func (r *cartRepository) AddItem(customerID, itemID string, quantity, limit int) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	ensure := `INSERT INTO carts (customer_id) VALUES ($1) ON CONFLICT (customer_id) DO NOTHING`
+	if _, err := tx.Exec(ensure, customerID); err != nil {
+		return false, err
+	}
+
+	var total int
+	upsert := `
+		INSERT INTO cart_items (customer_id, item_id, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (customer_id, item_id) DO UPDATE
+		SET quantity = cart_items.quantity + EXCLUDED.quantity
+		WHERE cart_items.quantity + EXCLUDED.quantity <= $4
+		RETURNING quantity
+	`
+	err = tx.QueryRow(upsert, customerID, itemID, quantity, limit).Scan(&total)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+/*
+Metode untuk mengubah jumlah unit item di keranjang.
+Nilai true dikembalikan jika item ada di keranjang.
+*/
+func (r *cartRepository) UpdateItem(customerID, itemID string, quantity int) (bool, error) {
+	query := `
+		UPDATE cart_items
+		SET quantity = $3
+		WHERE customer_id = $1 AND item_id = $2
+	`
+	res, err := r.db.Exec(query, customerID, itemID, quantity)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+/*
+Metode untuk menghapus item dari keranjang.
+Nilai true dikembalikan jika item ada di keranjang dan dihapus.
+*/
+func (r *cartRepository) RemoveItem(customerID, itemID string) (bool, error) {
+	query := `DELETE FROM cart_items WHERE customer_id = $1 AND item_id = $2`
+	res, err := r.db.Exec(query, customerID, itemID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+/*
+Metode untuk mengosongkan keranjang customer.
+Keranjang beserta item dan rentang tanggalnya dihapus.
+*/
+func (r *cartRepository) Clear(customerID string) error {
+	_, err := r.db.Exec(`DELETE FROM carts WHERE customer_id = $1`, customerID)
+	return err
+}
+
+/*
+Interface untuk operasi repositori keranjang.
+Interface ini mendefinisikan metode untuk mengelola keranjang customer.
+*/
+type CartRepository interface {
+	GetCart(customerID string) (*model.CartModel, []*model.CartItemModel, error)
+	SetDates(customerID string, start, end time.Time) error
+	AddItem(customerID, itemID string, quantity, limit int) (bool, error)
+	UpdateItem(customerID, itemID string, quantity int) (bool, error)
+	RemoveItem(customerID, itemID string) (bool, error)
+	Clear(customerID string) error
+}
+
+/*
+Fungsi untuk membuat instance baru dari CartRepository.
+Instance repositori dikembalikan.
+*/
+func NewCartRepository(db *sqlx.DB) CartRepository {
+	return &cartRepository{db: db}
+}
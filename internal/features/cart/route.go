@@ -0,0 +1,27 @@
+package cart
+
+import (
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/middleware"
+)
+
+/*
+Fungsi untuk mengatur rute fitur keranjang.
+Router dikonfigurasi dengan rute keranjang, hold stok, dan checkout customer.
+*/
+func SetupCartRoutes(router *mux.Router, h *CartHandler) {
+	// Setup group keranjang customer
+	cart := router.PathPrefix("/api/v1/customer/cart").Subrouter()
+	cart.Use(middleware.JWTMiddleware)
+	cart.Use(middleware.Customer)
+	cart.Handle("", middleware.RequireFunc(h.GetCart, auth.PermBookingRead)).Methods("GET")
+	cart.Handle("", middleware.RequireFunc(h.ClearCart, auth.PermBookingWrite)).Methods("DELETE")
+	cart.Handle("/dates", middleware.RequireFunc(h.SetDates, auth.PermBookingWrite)).Methods("PUT")
+	cart.Handle("/items", middleware.RequireFunc(h.AddItem, auth.PermBookingWrite)).Methods("POST")
+	cart.Handle("/items/{item_id}", middleware.RequireFunc(h.UpdateItem, auth.PermBookingWrite)).Methods("PUT")
+	cart.Handle("/items/{item_id}", middleware.RequireFunc(h.RemoveItem, auth.PermBookingWrite)).Methods("DELETE")
+	cart.Handle("/hold", middleware.RequireFunc(h.Hold, auth.PermBookingWrite)).Methods("POST")
+	cart.Handle("/checkout", middleware.RequireFunc(h.Checkout, auth.PermBookingWrite)).Methods("POST")
+}
@@ -0,0 +1,325 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"lalan-be/internal/features/booking"
+	"lalan-be/internal/features/pricing"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Struktur untuk layanan keranjang.
+Struktur ini menyediakan logika bisnis untuk keranjang customer dan checkout menjadi order per toko.
+*/
+type cartService struct {
+	repo     CartRepository
+	pricing  pricing.PricingService
+	bookings booking.BookingService
+}
+
+/*
+Metode untuk mengambil keranjang customer yang sedang login.
+Item dikelompokkan per toko dan, jika tanggal sewa sudah diisi dan stoknya masih cukup, setiap item dihitung harganya dengan layanan harga.
+*/
+func (s *cartService) GetCart(ctx context.Context) (*model.CartModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, items, err := s.repo.GetCart(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Aturan harga semua item dimuat dalam satu query, bukan satu query per item
+	priced := map[string]*model.ItemModel{}
+	if cart.StartDate != nil && cart.EndDate != nil {
+		itemIDs := make([]string, 0, len(items))
+		for _, item := range items {
+			itemIDs = append(itemIDs, item.ItemID)
+		}
+		priced, err = s.pricing.FindItems(itemIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cart.Groups = []*model.CartGroupModel{}
+	byStore := map[string]*model.CartGroupModel{}
+	for _, item := range items {
+		group, ok := byStore[item.StoreID]
+		if !ok {
+			group = &model.CartGroupModel{
+				StoreID:   item.StoreID,
+				StoreName: item.StoreName,
+				Items:     []*model.CartItemModel{},
+			}
+			byStore[item.StoreID] = group
+			cart.Groups = append(cart.Groups, group)
+		}
+		group.Items = append(group.Items, item)
+
+		// Item tanpa tanggal atau yang stoknya kini kurang tetap tampil tanpa penawaran
+		rules, ok := priced[item.ItemID]
+		if !ok || item.Quantity > rules.Stock {
+			continue
+		}
+		quote, err := s.pricing.Quote(rules, item.Quantity, *cart.StartDate, *cart.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		item.Quote = quote
+		group.TotalPrice += quote.Total
+		group.Deposit += quote.Deposit
+		group.AmountDue += quote.AmountDue
+		cart.TotalPrice += quote.Total
+		cart.Deposit += quote.Deposit
+		cart.AmountDue += quote.AmountDue
+	}
+
+	return cart, nil
+}
+
+/*
+Metode untuk mengatur rentang tanggal sewa keranjang.
+Rentang tanggal berlaku untuk semua item di keranjang dan tidak boleh dimulai di masa lalu.
+*/
+func (s *cartService) SetDates(ctx context.Context, start, end time.Time) error {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if end.Before(start) {
+		return errors.New(message.MsgBookingDateRangeInvalid)
+	}
+	if pricing.SpanDays(start, end) > pricing.MaxRentalDays {
+		return errors.New(message.MsgBookingPeriodTooLong)
+	}
+	now := time.Now().UTC()
+	if start.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)) {
+		return errors.New(message.MsgBookingDateInPast)
+	}
+	return s.repo.SetDates(customerID, start, end)
+}
+
+/*
+Metode untuk menambahkan item ke keranjang.
+Jumlah unit ditambahkan ke item yang sudah ada di keranjang tanpa melebihi stok item.
+*/
+func (s *cartService) AddItem(ctx context.Context, itemID string, quantity int) error {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	item, err := s.validItem(itemID, quantity)
+	if err != nil {
+		return err
+	}
+
+	added, err := s.repo.AddItem(customerID, item.ID, quantity, item.Stock)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return errors.New(message.MsgBookingStockUnavailable)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk mengubah jumlah unit item di keranjang.
+Jumlah baru harus minimal satu dan tidak melebihi stok item.
+*/
+func (s *cartService) UpdateItem(ctx context.Context, itemID string, quantity int) error {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	item, err := s.validItem(itemID, quantity)
+	if err != nil {
+		return err
+	}
+
+	found, err := s.repo.UpdateItem(customerID, item.ID, quantity)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.New(message.MsgCartItemNotFound)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk menghapus item dari keranjang.
+Error dikembalikan jika item tidak ada di keranjang.
+*/
+func (s *cartService) RemoveItem(ctx context.Context, itemID string) error {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.repo.RemoveItem(customerID, strings.TrimSpace(itemID))
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.New(message.MsgCartItemNotFound)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk mengosongkan keranjang customer.
+Semua item dan rentang tanggal keranjang dihapus.
+*/
+func (s *cartService) Clear(ctx context.Context) error {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repo.Clear(customerID)
+}
+
+/*
+Metode untuk menahan stok seluruh item keranjang sebelum pembayaran.
+Unit setiap item ditahan dengan rentang tanggal keranjang sampai hold kedaluwarsa; checkout berikutnya memakai unit yang ditahan ini jika ID hold-nya dikirim.
+*/
+func (s *cartService) Hold(ctx context.Context) ([]*model.StockHoldModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := s.lines(customerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.bookings.CreateHolds(ctx, lines)
+}
+
+/*
+Metode untuk checkout keranjang customer.
+Item keranjang dibooking dengan rentang tanggal bersama, hold yang dirujuk dengan ID dikonversi, dipecah menjadi satu order per toko, lalu keranjang dikosongkan; order yang sudah dibuat tetap dikembalikan walaupun pengosongan keranjang gagal agar klien tidak mengulang checkout.
+*/
+func (s *cartService) Checkout(ctx context.Context, notes string, holdIDs []string) ([]*model.OrderModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := s.lines(customerID)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := s.bookings.Checkout(ctx, lines, strings.TrimSpace(notes), holdIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Order sudah tersimpan, sehingga kegagalan mengosongkan keranjang hanya dicatat
+	if err := s.repo.Clear(customerID); err != nil {
+		log.Printf("Checkout: error clearing cart of customer %s after creating %d order(s): %v", customerID, len(orders), err)
+	}
+
+	return orders, nil
+}
+
+/*
+Metode untuk menyusun baris booking dari keranjang customer.
+Setiap item menjadi satu baris dengan rentang tanggal keranjang, atau error jika keranjang kosong atau tanggal belum diisi.
+*/
+func (s *cartService) lines(customerID string) ([]*model.BookingModel, error) {
+	cart, items, err := s.repo.GetCart(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errors.New(message.MsgCartEmpty)
+	}
+	if cart.StartDate == nil || cart.EndDate == nil {
+		return nil, errors.New(message.MsgCartDatesRequired)
+	}
+
+	lines := make([]*model.BookingModel, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, &model.BookingModel{
+			ItemID:    item.ItemID,
+			Quantity:  item.Quantity,
+			StartDate: *cart.StartDate,
+			EndDate:   *cart.EndDate,
+		})
+	}
+	return lines, nil
+}
+
+/*
+Metode untuk memvalidasi item dan jumlah unit keranjang.
+Item dikembalikan, atau error jika item tidak ditemukan atau jumlah unit tidak valid.
+*/
+func (s *cartService) validItem(itemID string, quantity int) (*model.ItemModel, error) {
+	itemID = strings.TrimSpace(itemID)
+	if itemID == "" {
+		return nil, errors.New(message.MsgItemIDRequired)
+	}
+	if quantity < 1 {
+		return nil, errors.New(message.MsgBookingQuantityInvalid)
+	}
+
+	item, err := s.pricing.FindItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if quantity > item.Stock {
+		return nil, errors.New(message.MsgBookingStockUnavailable)
+	}
+
+	return item, nil
+}
+
+/*
+Interface untuk operasi layanan keranjang.
+Interface ini mendefinisikan metode untuk mengelola keranjang, hold stok, dan checkout.
+*/
+type CartService interface {
+	GetCart(ctx context.Context) (*model.CartModel, error)
+	SetDates(ctx context.Context, start, end time.Time) error
+	AddItem(ctx context.Context, itemID string, quantity int) error
+	UpdateItem(ctx context.Context, itemID string, quantity int) error
+	RemoveItem(ctx context.Context, itemID string) error
+	Clear(ctx context.Context) error
+	Hold(ctx context.Context) ([]*model.StockHoldModel, error)
+	Checkout(ctx context.Context, notes string, holdIDs []string) ([]*model.OrderModel, error)
+}
+
+/*
+Fungsi untuk mengambil ID customer dari konteks.
+ID customer dikembalikan, atau error jika klaim token tidak valid.
+*/
+func userFromContext(ctx context.Context) (string, error) {
+	claims := middleware.GetClaims(ctx)
+	if claims == nil || claims.Subject == "" {
+		return "", errors.New("invalid token claims")
+	}
+	return claims.Subject, nil
+}
+
+/*
+Fungsi untuk membuat instance baru dari CartService.
+Instance layanan dikembalikan.
+*/
+func NewCartService(repo CartRepository, pricing pricing.PricingService, bookings booking.BookingService) CartService {
+	return &cartService{repo: repo, pricing: pricing, bookings: bookings}
+}
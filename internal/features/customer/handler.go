@@ -0,0 +1,919 @@
+package customer
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/model"
+	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
+)
+
+/*
+Struktur untuk handler customer.
+Struktur ini menangani permintaan terkait customer.
+*/
+type CustomerHandler struct {
+	service CustomerService
+}
+
+/*
+Struktur untuk permintaan pendaftaran customer.
+Struktur ini berisi data yang diperlukan untuk membuat customer baru.
+*/
+type CustomerRequest struct {
+	FullName     string `json:"full_name"`
+	PhoneNumber  string `json:"phone_number"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	Address      string `json:"address"`
+	ProfilePhoto string `json:"profile_photo"`
+}
+
+/*
+Struktur untuk permintaan login customer.
+Struktur ini berisi kredensial untuk autentikasi customer.
+*/
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+/*
+Struktur untuk permintaan callback login OpenID Connect.
+Struktur ini berisi state dan kode otorisasi yang diterima frontend dari penyedia.
+*/
+type OIDCCallbackRequest struct {
+	State string `json:"state"`
+	Code  string `json:"code"`
+}
+
+/*
+Struktur untuk permintaan refresh token customer.
+Struktur ini berisi refresh token yang akan dirotasi atau dicabut.
+*/
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+/*
+Struktur untuk permintaan lupa password customer.
+Struktur ini berisi email akun yang ingin dipulihkan.
+*/
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+/*
+Struktur untuk permintaan reset password customer.
+Struktur ini berisi token reset dan password baru.
+*/
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+/*
+Struktur untuk permintaan ganti password customer.
+Struktur ini berisi password saat ini dan password baru.
+*/
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+/*
+Struktur untuk permintaan penghapusan akun customer.
+Struktur ini berisi password untuk konfirmasi ulang.
+*/
+type AccountDeletionRequest struct {
+	Password string `json:"password"`
+}
+
+/*
+Struktur untuk permintaan verifikasi email customer.
+Struktur ini berisi token dari tautan verifikasi.
+*/
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+/*
+Struktur untuk permintaan pembaruan profil customer.
+Struktur ini berisi field profil yang dapat diubah.
+*/
+type ProfileRequest struct {
+	FullName    string `json:"full_name"`
+	PhoneNumber string `json:"phone_number"`
+	Address     string `json:"address"`
+}
+
+/*
+Struktur untuk permintaan pembaruan foto profil customer.
+Struktur ini berisi URL foto profil baru.
+*/
+type ProfilePhotoRequest struct {
+	ProfilePhoto string `json:"profile_photo"`
+}
+
+/*
+Metode untuk membuat customer baru.
+Metode ini memvalidasi input dan membuat customer melalui layanan.
+*/
+func (h *CustomerHandler) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CreateCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req CustomerRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("CreateCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.FullName) == "" {
+		log.Printf("CreateCustomer: full name required")
+		response.BadRequest(w, message.MsgCustomerFullNameRequired)
+		return
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		log.Printf("CreateCustomer: email required")
+		response.BadRequest(w, "Email is required")
+		return
+	}
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	if !emailRegex.MatchString(req.Email) {
+		log.Printf("CreateCustomer: invalid email format: %s", req.Email)
+		response.BadRequest(w, message.MsgCustomerInvalidEmail)
+		return
+	}
+	if strings.TrimSpace(req.Password) == "" {
+		log.Printf("CreateCustomer: password required")
+		response.BadRequest(w, "Password is required")
+		return
+	}
+	input := &model.CustomerModel{
+		FullName:     strings.TrimSpace(req.FullName),
+		PhoneNumber:  strings.TrimSpace(req.PhoneNumber),
+		Email:        strings.TrimSpace(req.Email),
+		PasswordHash: req.Password,
+		Address:      strings.TrimSpace(req.Address),
+		ProfilePhoto: strings.TrimSpace(req.ProfilePhoto),
+	}
+	err := h.service.CreateCustomer(input)
+	if err != nil {
+		log.Printf("CreateCustomer: error creating customer: %v", err)
+		response.BadRequest(w, err.Error())
+		return
+	}
+	response.Created(w, input, message.MsgCustomerCreatedSuccess)
+}
+
+/*
+Metode untuk login customer.
+Metode ini memvalidasi kredensial dan mengembalikan token autentikasi.
+*/
+func (h *CustomerHandler) LoginCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("LoginCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req LoginRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("LoginCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if req.Email == "" || req.Password == "" {
+		log.Printf("LoginCustomer: email or password empty")
+		response.Error(w, http.StatusBadRequest, "Email and password are required")
+		return
+	}
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	if !emailRegex.MatchString(req.Email) {
+		log.Printf("LoginCustomer: invalid email format: %s", req.Email)
+		response.Error(w, http.StatusBadRequest, message.MsgCustomerInvalidEmail)
+		return
+	}
+	resp, err := h.service.LoginCustomer(req.Email, req.Password, auth.ClientInfoFromRequest(r))
+	if err != nil {
+		log.Printf("LoginCustomer: login failed: %v", err)
+		var blocked *auth.LoginBlockedError
+		if errors.As(err, &blocked) {
+			if blocked.Locked {
+				response.Locked(w, blocked.Error(), blocked.RetryAfter)
+				return
+			}
+			response.TooManyRequests(w, blocked.Error(), blocked.RetryAfter)
+			return
+		}
+		response.Error(w, http.StatusUnauthorized, message.MsgCustomerInvalidCredentials)
+		return
+	}
+	log.Printf("LoginCustomer: login successful for email %s", req.Email)
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+	userData := map[string]interface{}{
+		"id":            resp.ID,
+		"access_token":  resp.AccessToken,
+		"refresh_token": resp.RefreshToken,
+		"token_type":    resp.TokenType,
+		"expires_in":    resp.ExpiresIn,
+	}
+	response.Success(w, http.StatusOK, userData, message.MsgCustomerLoginSuccess)
+}
+
+/*
+Metode untuk merotasi refresh token customer.
+Metode ini mengembalikan pasangan token baru jika refresh token valid.
+*/
+func (h *CustomerHandler) RefreshTokenCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RefreshTokenCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req RefreshRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("RefreshTokenCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		response.BadRequest(w, message.MsgRefreshTokenRequired)
+		return
+	}
+	resp, err := h.service.RefreshTokenCustomer(req.RefreshToken)
+	if err != nil {
+		log.Printf("RefreshTokenCustomer: refresh failed: %v", err)
+		if errors.Is(err, auth.ErrRefreshTokenInvalid) || errors.Is(err, auth.ErrRefreshTokenExpired) || errors.Is(err, auth.ErrRefreshTokenReused) {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+	userData := map[string]interface{}{
+		"id":            resp.ID,
+		"access_token":  resp.AccessToken,
+		"refresh_token": resp.RefreshToken,
+		"token_type":    resp.TokenType,
+		"expires_in":    resp.ExpiresIn,
+	}
+	response.OK(w, userData, message.MsgTokenRefreshed)
+}
+
+/*
+Metode untuk logout customer.
+Metode ini mencabut refresh token dan menghapus cookie autentikasi.
+*/
+func (h *CustomerHandler) LogoutCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("LogoutCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req RefreshRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("LogoutCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		response.BadRequest(w, message.MsgRefreshTokenRequired)
+		return
+	}
+	if err := h.service.LogoutCustomer(r.Context(), req.RefreshToken); err != nil {
+		log.Printf("LogoutCustomer: logout failed: %v", err)
+		if errors.Is(err, auth.ErrRefreshTokenInvalid) || errors.Is(err, auth.ErrRefreshTokenExpired) || errors.Is(err, auth.ErrRefreshTokenReused) {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+	response.OK(w, nil, message.MsgLogoutSuccess)
+}
+
+/*
+Metode untuk logout customer dari semua perangkat.
+Metode ini mencabut seluruh token milik customer yang sedang login.
+*/
+func (h *CustomerHandler) LogoutAllCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("LogoutAllCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	if err := h.service.LogoutAllCustomer(r.Context()); err != nil {
+		log.Printf("LogoutAllCustomer: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+	response.OK(w, nil, message.MsgLogoutAllSuccess)
+}
+
+/*
+Metode untuk mengganti password customer yang sedang login.
+Metode ini memverifikasi password lama dan mengeluarkan customer dari semua perangkat setelah berhasil.
+*/
+func (h *CustomerHandler) ChangePasswordCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ChangePasswordCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ChangePasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ChangePasswordCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if req.CurrentPassword == "" {
+		response.BadRequest(w, message.MsgPasswordCurrentRequired)
+		return
+	}
+	if strings.TrimSpace(req.NewPassword) == "" {
+		response.BadRequest(w, message.MsgPasswordRequired)
+		return
+	}
+	if err := h.service.ChangePasswordCustomer(r.Context(), req.CurrentPassword, req.NewPassword); err != nil {
+		log.Printf("ChangePasswordCustomer: error: %v", err)
+		var weak *auth.PasswordPolicyError
+		if errors.As(err, &weak) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgPasswordCurrentRequired || err.Error() == message.MsgPasswordCurrentInvalid || err.Error() == message.MsgPasswordUnchanged {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgUnauthorized {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+	response.OK(w, nil, message.MsgPasswordChanged)
+}
+
+/*
+Metode untuk mengambil daftar sesi login customer.
+Sesi aktif beserta perangkat dan waktu terakhir digunakan dikembalikan.
+*/
+func (h *CustomerHandler) GetSessionsCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetSessionsCustomer: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	sessions, err := h.service.GetSessionsCustomer(r.Context())
+	if err != nil {
+		log.Printf("GetSessionsCustomer: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, sessions, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengakhiri sesi login customer tertentu.
+Sesi dicabut sehingga perangkat terkait harus login ulang.
+*/
+func (h *CustomerHandler) TerminateSessionCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("TerminateSessionCustomer: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgSessionIDRequired)
+		return
+	}
+
+	if err := h.service.TerminateSessionCustomer(r.Context(), id); err != nil {
+		log.Printf("TerminateSessionCustomer: error: %v", err)
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, nil, message.MsgSessionTerminated)
+}
+
+/*
+Metode untuk mengekspor data pribadi customer.
+Data dikembalikan sebagai JSON, atau sebagai arsip ZIP jika format=zip.
+*/
+func (h *CustomerHandler) ExportCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ExportCustomer: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "zip" {
+		response.BadRequest(w, message.MsgAccountExportFormatInvalid)
+		return
+	}
+
+	export, err := h.service.ExportCustomer(r.Context())
+	if err != nil {
+		log.Printf("ExportCustomer: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	if format == "zip" {
+		if err := response.Archive(w, "lalan-customer-export.zip", "customer.json", export); err != nil {
+			log.Printf("ExportCustomer: error writing archive: %v", err)
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+	response.OK(w, export, message.MsgAccountExported)
+}
+
+/*
+Metode untuk menjadwalkan penghapusan akun customer.
+Akun dianonimkan setelah masa tenggang kecuali penghapusan dibatalkan.
+*/
+func (h *CustomerHandler) RequestDeletionCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RequestDeletionCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req AccountDeletionRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("RequestDeletionCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if req.Password == "" {
+		response.BadRequest(w, message.MsgPasswordRequired)
+		return
+	}
+
+	deletion, err := h.service.RequestDeletionCustomer(r.Context(), req.Password)
+	if err != nil {
+		log.Printf("RequestDeletionCustomer: error: %v", err)
+		if err.Error() == message.MsgPasswordCurrentInvalid || errors.Is(err, auth.ErrAccountDeletionPending) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgAccountDeletionActiveBookings {
+			response.Conflict(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.Created(w, deletion, message.MsgAccountDeletionScheduled)
+}
+
+/*
+Metode untuk mengambil jadwal penghapusan akun customer.
+Tanggal penghapusan yang masih menunggu dikembalikan.
+*/
+func (h *CustomerHandler) GetDeletionCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetDeletionCustomer: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	deletion, err := h.service.GetDeletionCustomer(r.Context())
+	if err != nil {
+		log.Printf("GetDeletionCustomer: error: %v", err)
+		if errors.Is(err, auth.ErrAccountDeletionNotFound) {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, deletion, message.MsgSuccess)
+}
+
+/*
+Metode untuk membatalkan penghapusan akun customer.
+Akun tetap aktif jika dibatalkan sebelum masa tenggang berakhir.
+*/
+func (h *CustomerHandler) CancelDeletionCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CancelDeletionCustomer: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	if err := h.service.CancelDeletionCustomer(r.Context()); err != nil {
+		log.Printf("CancelDeletionCustomer: error: %v", err)
+		if errors.Is(err, auth.ErrAccountDeletionNotFound) {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, nil, message.MsgAccountDeletionCancelled)
+}
+
+/*
+Metode untuk memulai login customer melalui penyedia OpenID Connect.
+URL otorisasi penyedia dikembalikan untuk dibuka oleh frontend.
+*/
+func (h *CustomerHandler) OIDCAuthorizeCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("OIDCAuthorizeCustomer: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	authorizationURL, err := h.service.OIDCAuthorizeCustomer()
+	if err != nil {
+		log.Printf("OIDCAuthorizeCustomer: error: %v", err)
+		switch {
+		case errors.Is(err, auth.ErrOIDCDisabled):
+			response.Error(w, http.StatusNotImplemented, err.Error())
+		case errors.Is(err, auth.ErrOIDCExchangeFailed):
+			response.Error(w, http.StatusBadGateway, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	response.OK(w, map[string]interface{}{"authorization_url": authorizationURL}, message.MsgSuccess)
+}
+
+/*
+Metode untuk menyelesaikan login customer melalui penyedia OpenID Connect.
+Kode otorisasi ditukar dan pasangan token customer dikembalikan.
+*/
+func (h *CustomerHandler) OIDCCallbackCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("OIDCCallbackCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	var req OIDCCallbackRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("OIDCCallbackCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.State) == "" || strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, message.MsgOIDCCodeRequired)
+		return
+	}
+
+	resp, err := h.service.LoginOIDCCustomer(req.State, req.Code, auth.ClientInfoFromRequest(r))
+	if err != nil {
+		log.Printf("OIDCCallbackCustomer: error: %v", err)
+		switch {
+		case errors.Is(err, auth.ErrOIDCDisabled):
+			response.Error(w, http.StatusNotImplemented, err.Error())
+		case errors.Is(err, auth.ErrOIDCStateInvalid), errors.Is(err, auth.ErrOIDCEmailUnverified), err.Error() == message.MsgCustomerEmailExists:
+			response.BadRequest(w, err.Error())
+		case errors.Is(err, auth.ErrOIDCAccountUnverified):
+			response.Conflict(w, err.Error())
+		case errors.Is(err, auth.ErrOIDCExchangeFailed):
+			response.Unauthorized(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+	userData := map[string]interface{}{
+		"id":            resp.ID,
+		"access_token":  resp.AccessToken,
+		"refresh_token": resp.RefreshToken,
+		"token_type":    resp.TokenType,
+		"expires_in":    resp.ExpiresIn,
+	}
+	response.Success(w, http.StatusOK, userData, message.MsgOIDCLoginSuccess)
+}
+
+/*
+Metode untuk meminta tautan reset password customer.
+Metode ini selalu mengembalikan respons yang sama agar email terdaftar tidak bocor.
+*/
+func (h *CustomerHandler) ForgotPasswordCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ForgotPasswordCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ForgotPasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ForgotPasswordCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		response.BadRequest(w, message.MsgEmailRequired)
+		return
+	}
+	if err := h.service.ForgotPasswordCustomer(req.Email); err != nil {
+		log.Printf("ForgotPasswordCustomer: error: %v", err)
+	}
+	response.OK(w, nil, message.MsgPasswordResetRequested)
+}
+
+/*
+Metode untuk mengatur ulang password customer.
+Metode ini memvalidasi token reset dan menyimpan password baru.
+*/
+func (h *CustomerHandler) ResetPasswordCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ResetPasswordCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ResetPasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ResetPasswordCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Token) == "" {
+		response.BadRequest(w, message.MsgPasswordResetTokenMissing)
+		return
+	}
+	if strings.TrimSpace(req.Password) == "" {
+		response.BadRequest(w, message.MsgPasswordRequired)
+		return
+	}
+	if err := h.service.ResetPasswordCustomer(req.Token, req.Password); err != nil {
+		log.Printf("ResetPasswordCustomer: error: %v", err)
+		var weak *auth.PasswordPolicyError
+		if errors.Is(err, auth.ErrResetTokenInvalid) || errors.As(err, &weak) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	response.OK(w, nil, message.MsgPasswordResetSuccess)
+}
+
+/*
+Metode untuk memverifikasi email customer.
+Metode ini memvalidasi token dari tautan verifikasi.
+*/
+func (h *CustomerHandler) VerifyEmailCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("VerifyEmailCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req VerifyEmailRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("VerifyEmailCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Token) == "" {
+		response.BadRequest(w, message.MsgEmailVerificationMissing)
+		return
+	}
+	if err := h.service.VerifyEmailCustomer(req.Token); err != nil {
+		log.Printf("VerifyEmailCustomer: error: %v", err)
+		if errors.Is(err, auth.ErrVerificationTokenInvalid) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	response.OK(w, nil, message.MsgEmailVerified)
+}
+
+/*
+Metode untuk mengirim ulang email verifikasi customer.
+Metode ini selalu mengembalikan respons yang sama agar status akun tidak bocor.
+*/
+func (h *CustomerHandler) ResendVerificationCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ResendVerificationCustomer: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ForgotPasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ResendVerificationCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		response.BadRequest(w, message.MsgEmailRequired)
+		return
+	}
+	if err := h.service.ResendVerificationCustomer(req.Email); err != nil {
+		log.Printf("ResendVerificationCustomer: error: %v", err)
+	}
+	response.OK(w, nil, message.MsgEmailVerificationSent)
+}
+
+/*
+Metode untuk mendapatkan profil customer.
+Metode ini mengambil data customer berdasarkan konteks permintaan.
+*/
+func (h *CustomerHandler) GetDetailCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetDetailCustomer: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	customer, err := h.service.GetDetailCustomer(r.Context())
+	if err != nil {
+		log.Printf("GetDetailCustomer: error getting customer: %v", err)
+		if err.Error() == message.MsgCustomerNotFound {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	log.Printf("GetDetailCustomer: retrieved customer for ID %s", customer.ID)
+	response.OK(w, customer, message.MsgCustomerFetched)
+}
+
+/*
+Metode untuk memperbarui profil customer.
+Metode ini memvalidasi dan memperbarui profil melalui layanan.
+*/
+func (h *CustomerHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("UpdateCustomer: received request")
+	if r.Method != http.MethodPut {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ProfileRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("UpdateCustomer: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	input := &model.CustomerModel{
+		FullName:    req.FullName,
+		PhoneNumber: req.PhoneNumber,
+		Address:     req.Address,
+	}
+	customer, err := h.service.UpdateCustomer(r.Context(), input)
+	if err != nil {
+		log.Printf("UpdateCustomer: error: %v", err)
+		response.BadRequest(w, err.Error())
+		return
+	}
+	response.OK(w, customer, message.MsgCustomerProfileUpdated)
+}
+
+/*
+Metode untuk memperbarui foto profil customer.
+Metode ini memvalidasi URL foto dan menyimpannya melalui layanan.
+*/
+func (h *CustomerHandler) UpdateProfilePhoto(w http.ResponseWriter, r *http.Request) {
+	log.Printf("UpdateProfilePhoto: received request")
+	if r.Method != http.MethodPut {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ProfilePhotoRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("UpdateProfilePhoto: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.ProfilePhoto) == "" {
+		log.Printf("UpdateProfilePhoto: photo required")
+		response.BadRequest(w, message.MsgCustomerPhotoRequired)
+		return
+	}
+	customer, err := h.service.UpdateProfilePhoto(r.Context(), req.ProfilePhoto)
+	if err != nil {
+		log.Printf("UpdateProfilePhoto: error: %v", err)
+		response.BadRequest(w, err.Error())
+		return
+	}
+	response.OK(w, customer, message.MsgCustomerPhotoUpdated)
+}
+
+/*
+Metode untuk menghapus foto profil customer.
+Metode ini mengosongkan foto profil melalui layanan.
+*/
+func (h *CustomerHandler) DeleteProfilePhoto(w http.ResponseWriter, r *http.Request) {
+	log.Printf("DeleteProfilePhoto: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	customer, err := h.service.UpdateProfilePhoto(r.Context(), "")
+	if err != nil {
+		log.Printf("DeleteProfilePhoto: error: %v", err)
+		response.BadRequest(w, err.Error())
+		return
+	}
+	response.OK(w, customer, message.MsgCustomerPhotoRemoved)
+}
+
+/*
+Fungsi untuk membuat instance baru dari CustomerHandler.
+Instance handler dikembalikan.
+*/
+func NewCustomerHandler(s CustomerService) *CustomerHandler {
+	return &CustomerHandler{service: s}
+}
@@ -0,0 +1,530 @@
+package customer
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk kolom booking yang diekspor.
+Konstanta ini menyertakan nama item agar riwayat sewa dapat dibaca tanpa data lain.
+*/
+const exportBookingColumns = `
+	b.id,
+	b.item_id,
+	i.name AS item_name,
+	b.customer_id,
+	b.store_id,
+	b.order_id,
+	b.quantity,
+	b.start_date,
+	b.end_date,
+	b.days,
+	b.price_per_day,
+	b.discount,
+	b.delivery_fee,
+	b.total_price,
+	b.deposit,
+	b.status,
+	b.notes,
+	b.cancellation_policy_id,
+	b.cancellation_rules,
+	b.refund_percent,
+	b.refund_amount,
+	b.created_at,
+	b.updated_at,
+	b.confirmed_at,
+	b.rejected_at,
+	b.picked_up_at,
+	b.returned_at,
+	b.completed_at,
+	b.cancelled_at
+`
+
+/*
+Konstanta untuk kolom entri deposit yang diekspor.
+Konstanta ini mengikuti kolom buku besar deposit booking.
+*/
+const exportDepositColumns = `
+	d.id,
+	d.booking_id,
+	d.entry_type,
+	d.amount,
+	d.reason,
+	d.photos,
+	d.status,
+	d.customer_note,
+	d.created_by,
+	d.created_at,
+	d.responded_at
+`
+
+/*
+Variabel untuk status booking yang menahan penghapusan akun customer.
+Booking dengan status ini harus dibatalkan atau diselesaikan sebelum akun dapat dihapus.
+*/
+var activeBookingStatuses = []string{
+	model.BookingStatusPending,
+	model.BookingStatusConfirmed,
+	model.BookingStatusPickedUp,
+	model.BookingStatusReturned,
+}
+
+/*
+Struktur untuk repositori customer.
+Struktur ini menyediakan akses ke operasi database untuk customer.
+*/
+type customerRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk membuat customer baru di database.
+ID dan timestamp customer dikembalikan setelah penyisipan.
+*/
+func (r *customerRepository) CreateCustomer(customer *model.CustomerModel) error {
+	query := `
+		INSERT INTO customers (
+			full_name,
+			profile_photo,
+			phone_number,
+			email,
+			address,
+			password_hash,
+			created_at,
+			updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRow(query, customer.FullName, customer.ProfilePhoto, customer.PhoneNumber, customer.Email, customer.Address, customer.PasswordHash, customer.CreatedAt, customer.UpdatedAt).Scan(&customer.ID, &customer.CreatedAt, &customer.UpdatedAt)
+	log.Printf("CreateCustomer: inserted customer with email %s, ID %s", customer.Email, customer.ID)
+	return err
+}
+
+/*
+Metode untuk mencari customer berdasarkan email untuk login.
+Model customer dikembalikan jika ditemukan.
+*/
+func (r *customerRepository) FindByEmailCustomerForLogin(email string) (*model.CustomerModel, error) {
+	var customer model.CustomerModel
+	query := `
+		SELECT
+			id,
+			full_name,
+			COALESCE(profile_photo, '') AS profile_photo,
+			COALESCE(phone_number, '') AS phone_number,
+			email,
+			COALESCE(address, '') AS address,
+			password_hash,
+			email_verified_at,
+			created_at,
+			updated_at
+		FROM customers
+		WHERE email = $1
+	`
+	err := r.db.Get(&customer, query, email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Printf("FindByEmailCustomerForLogin: no customer found for email %s", email)
+			return nil, nil
+		}
+		log.Printf("FindByEmailCustomerForLogin: error querying email %s: %v", email, err)
+		return nil, err
+	}
+	log.Printf("FindByEmailCustomerForLogin: found customer for email %s", email)
+	return &customer, nil
+}
+
+/*
+Metode untuk mengambil detail customer berdasarkan ID.
+Model customer dikembalikan jika ditemukan.
+*/
+func (r *customerRepository) GetDetailCustomer(id string) (*model.CustomerModel, error) {
+	var customer model.CustomerModel
+	query := `
+		SELECT
+			id,
+			full_name,
+			COALESCE(profile_photo, '') AS profile_photo,
+			COALESCE(phone_number, '') AS phone_number,
+			email,
+			COALESCE(address, '') AS address,
+			password_hash,
+			email_verified_at,
+			created_at,
+			updated_at
+		FROM customers
+		WHERE id = $1
+	`
+	err := r.db.Get(&customer, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Printf("GetDetailCustomer: no customer found for id %s", id)
+			return nil, nil
+		}
+		log.Printf("GetDetailCustomer: error for id %s: %v", id, err)
+		return nil, err
+	}
+	log.Printf("GetDetailCustomer: found customer id %s", id)
+	return &customer, nil
+}
+
+/*
+Metode untuk memperbarui profil customer di database.
+Profil customer diperbarui berdasarkan ID.
+*/
+func (r *customerRepository) UpdateCustomer(customer *model.CustomerModel) error {
+	query := `
+		UPDATE customers
+		SET
+			full_name = $1,
+			phone_number = $2,
+			address = $3,
+			updated_at = $4
+		WHERE id = $5
+	`
+	_, err := r.db.Exec(query, customer.FullName, customer.PhoneNumber, customer.Address, customer.UpdatedAt, customer.ID)
+	if err != nil {
+		log.Printf("UpdateCustomer: error updating customer: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk memperbarui foto profil customer di database.
+Foto profil diperbarui atau dikosongkan berdasarkan ID.
+*/
+func (r *customerRepository) UpdateProfilePhoto(id string, photo string) error {
+	query := `
+		UPDATE customers
+		SET
+			profile_photo = NULLIF($1, ''),
+			updated_at = NOW()
+		WHERE id = $2
+	`
+	_, err := r.db.Exec(query, photo, id)
+	if err != nil {
+		log.Printf("UpdateProfilePhoto: error updating customer photo: %v", err)
+		return err
+	}
+	return nil
+}
+
+/*
+Metode untuk memperbarui hash password customer di database.
+Password diperbarui berdasarkan ID.
+*/
+func (r *customerRepository) UpdatePasswordCustomer(id string, passwordHash string) error {
+	query := `
+		UPDATE customers
+		SET
+			password_hash = $1,
+			updated_at = NOW()
+		WHERE id = $2
+	`
+	_, err := r.db.Exec(query, passwordHash, id)
+	if err != nil {
+		log.Printf("UpdatePasswordCustomer: error updating password for %s: %v", id, err)
+		return err
+	}
+	log.Printf("UpdatePasswordCustomer: updated password for ID %s", id)
+	return nil
+}
+
+/*
+Metode untuk menandai email customer sudah terverifikasi.
+Nilai true dikembalikan jika email cocok dan belum pernah diverifikasi.
+*/
+func (r *customerRepository) MarkEmailVerifiedCustomer(id string, email string) (bool, error) {
+	query := `
+		UPDATE customers
+		SET
+			email_verified_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND LOWER(email) = LOWER($2) AND email_verified_at IS NULL
+	`
+	res, err := r.db.Exec(query, id, email)
+	if err != nil {
+		log.Printf("MarkEmailVerifiedCustomer: error verifying %s: %v", id, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	log.Printf("MarkEmailVerifiedCustomer: verified %d row(s) for ID %s", affected, id)
+	return affected > 0, nil
+}
+
+/*
+Metode untuk mengambil booking milik customer untuk ekspor data.
+Daftar booking beserta nama item dikembalikan dari yang terbaru.
+*/
+func (r *customerRepository) GetBookingsByCustomer(id string) ([]*model.BookingModel, error) {
+	bookings := []*model.BookingModel{}
+	query := `
+		SELECT ` + exportBookingColumns + `
+		FROM bookings b
+		JOIN item i ON i.id = b.item_id
+		WHERE b.customer_id = $1
+		ORDER BY b.created_at DESC
+	`
+	if err := r.db.Select(&bookings, query, id); err != nil {
+		log.Printf("GetBookingsByCustomer: error querying customer %s: %v", id, err)
+		return nil, err
+	}
+	return bookings, nil
+}
+
+/*
+Metode untuk mengambil order milik customer untuk ekspor data.
+Daftar order beserta nama toko dikembalikan dari yang terbaru, baris booking-nya diekspor terpisah.
+*/
+func (r *customerRepository) GetOrdersByCustomer(id string) ([]*model.OrderModel, error) {
+	orders := []*model.OrderModel{}
+	query := `
+		SELECT
+			o.id,
+			o.customer_id,
+			o.store_id,
+			h.store_name,
+			o.start_date,
+			o.end_date,
+			o.total_price,
+			o.deposit,
+			o.notes,
+			o.created_at
+		FROM orders o
+		JOIN hoster h ON h.id = o.store_id
+		WHERE o.customer_id = $1
+		ORDER BY o.created_at DESC
+	`
+	if err := r.db.Select(&orders, query, id); err != nil {
+		log.Printf("GetOrdersByCustomer: error querying customer %s: %v", id, err)
+		return nil, err
+	}
+	for _, order := range orders {
+		order.AmountDue = order.TotalPrice + order.Deposit
+	}
+	return orders, nil
+}
+
+/*
+Metode untuk mengambil hold stok milik customer untuk ekspor data.
+Semua hold, termasuk yang sudah dilepas atau kedaluwarsa, dikembalikan dari yang terbaru.
+*/
+func (r *customerRepository) GetStockHoldsByCustomer(id string) ([]*model.StockHoldModel, error) {
+	holds := []*model.StockHoldModel{}
+	query := `
+		SELECT
+			s.id,
+			s.customer_id,
+			s.item_id,
+			i.name AS item_name,
+			s.store_id,
+			s.quantity,
+			s.start_date,
+			s.end_date,
+			s.status,
+			s.booking_id,
+			s.expires_at,
+			s.created_at
+		FROM stock_holds s
+		JOIN item i ON i.id = s.item_id
+		WHERE s.customer_id = $1
+		ORDER BY s.created_at DESC
+	`
+	if err := r.db.Select(&holds, query, id); err != nil {
+		log.Printf("GetStockHoldsByCustomer: error querying customer %s: %v", id, err)
+		return nil, err
+	}
+	return holds, nil
+}
+
+/*
+Metode untuk mengambil entri deposit booking milik customer untuk ekspor data.
+Entri buku besar semua booking customer dikembalikan urut booking dan waktu pencatatan.
+*/
+func (r *customerRepository) GetDepositEntriesByCustomer(id string) ([]*model.DepositEntryModel, error) {
+	entries := []*model.DepositEntryModel{}
+	query := `
+		SELECT ` + exportDepositColumns + `
+		FROM deposit_entries d
+		JOIN bookings b ON b.id = d.booking_id
+		WHERE b.customer_id = $1
+		ORDER BY d.booking_id, d.created_at, d.id
+	`
+	if err := r.db.Select(&entries, query, id); err != nil {
+		log.Printf("GetDepositEntriesByCustomer: error querying customer %s: %v", id, err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+/*
+Metode untuk mengambil isi keranjang customer untuk ekspor data.
+Item keranjang beserta toko dan jumlah unit dikembalikan urut waktu ditambahkan.
+*/
+func (r *customerRepository) GetCartItemsByCustomer(id string) ([]*model.CartItemModel, error) {
+	items := []*model.CartItemModel{}
+	query := `
+		SELECT
+			ci.item_id,
+			i.name AS item_name,
+			i.user_id AS store_id,
+			h.store_name,
+			i.stock,
+			ci.quantity,
+			ci.added_at
+		FROM cart_items ci
+		JOIN item i ON i.id = ci.item_id
+		JOIN hoster h ON h.id = i.user_id
+		WHERE ci.customer_id = $1
+		ORDER BY ci.added_at, ci.item_id
+	`
+	if err := r.db.Select(&items, query, id); err != nil {
+		log.Printf("GetCartItemsByCustomer: error querying customer %s: %v", id, err)
+		return nil, err
+	}
+	return items, nil
+}
+
+/*
+Metode untuk menghitung booking customer yang masih berjalan.
+Booking yang menunggu, dikonfirmasi, sedang disewa, atau sudah dikembalikan tetapi belum selesai dihitung.
+*/
+func (r *customerRepository) CountActiveBookingsByCustomer(id string) (int, error) {
+	return countActiveBookings(r.db, id)
+}
+
+/*
+Metode untuk menganonimkan data pribadi customer yang dihapus.
+Baris customer dan riwayat sewa tetap ada agar tetap utuh, tetapi nama, kontak, foto, password, catatan booking, dan keranjang dihapus; penghapusan ditolak selama masih ada booking yang berjalan.
+*/
+func (r *customerRepository) AnonymizeCustomer(id string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Booking, order, dan hold mengunci baris customer yang sama sehingga tidak ada yang lolos dari pengecekan
+	if _, err := tx.Exec(`SELECT id FROM customers WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return err
+	}
+	active, err := countActiveBookings(tx, id)
+	if err != nil {
+		return err
+	}
+	if active > 0 {
+		return errors.New(message.MsgAccountDeletionActiveBookings)
+	}
+
+	release := `UPDATE stock_holds SET status = $2 WHERE customer_id = $1 AND status = $3`
+	if _, err := tx.Exec(release, id, model.StockHoldStatusReleased, model.StockHoldStatusActive); err != nil {
+		log.Printf("AnonymizeCustomer: error releasing holds of %s: %v", id, err)
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM cart_items WHERE customer_id = $1`, id); err != nil {
+		log.Printf("AnonymizeCustomer: error clearing cart items of %s: %v", id, err)
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM carts WHERE customer_id = $1`, id); err != nil {
+		log.Printf("AnonymizeCustomer: error clearing cart of %s: %v", id, err)
+		return err
+	}
+	// Catatan bebas bisa berisi data pribadi, sedangkan angka sewa tetap disimpan
+	if _, err := tx.Exec(`UPDATE bookings SET notes = '' WHERE customer_id = $1`, id); err != nil {
+		log.Printf("AnonymizeCustomer: error scrubbing booking notes of %s: %v", id, err)
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE orders SET notes = '' WHERE customer_id = $1`, id); err != nil {
+		log.Printf("AnonymizeCustomer: error scrubbing order notes of %s: %v", id, err)
+		return err
+	}
+	scrub := `
+		UPDATE deposit_entries
+		SET customer_note = ''
+		WHERE booking_id IN (SELECT id FROM bookings WHERE customer_id = $1)
+	`
+	if _, err := tx.Exec(scrub, id); err != nil {
+		log.Printf("AnonymizeCustomer: error scrubbing deposit notes of %s: %v", id, err)
+		return err
+	}
+
+	query := `
+		UPDATE customers
+		SET
+			full_name = 'Deleted customer',
+			email = 'deleted-' || id || '@deleted.invalid',
+			phone_number = NULL,
+			address = NULL,
+			profile_photo = NULL,
+			password_hash = '!',
+			email_verified_at = NULL,
+			deleted_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	if _, err := tx.Exec(query, id); err != nil {
+		log.Printf("AnonymizeCustomer: error anonymizing customer %s: %v", id, err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Printf("AnonymizeCustomer: anonymized customer %s", id)
+	return nil
+}
+
+/*
+Antarmuka untuk repositori customer.
+Antarmuka ini mendefinisikan metode untuk operasi data customer.
+*/
+type CustomerRepository interface {
+	CreateCustomer(customer *model.CustomerModel) error
+	FindByEmailCustomerForLogin(email string) (*model.CustomerModel, error)
+	UpdatePasswordCustomer(id string, passwordHash string) error
+	MarkEmailVerifiedCustomer(id string, email string) (bool, error)
+	GetDetailCustomer(id string) (*model.CustomerModel, error)
+	UpdateCustomer(customer *model.CustomerModel) error
+	UpdateProfilePhoto(id string, photo string) error
+	GetBookingsByCustomer(id string) ([]*model.BookingModel, error)
+	GetOrdersByCustomer(id string) ([]*model.OrderModel, error)
+	GetStockHoldsByCustomer(id string) ([]*model.StockHoldModel, error)
+	GetDepositEntriesByCustomer(id string) ([]*model.DepositEntryModel, error)
+	GetCartItemsByCustomer(id string) ([]*model.CartItemModel, error)
+	CountActiveBookingsByCustomer(id string) (int, error)
+	AnonymizeCustomer(id string) error
+}
+
+/*
+Fungsi untuk menghitung booking customer yang masih berjalan.
+Query dijalankan di database atau di dalam transaksi penghapusan.
+*/
+func countActiveBookings(q sqlx.Queryer, id string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM bookings WHERE customer_id = $1 AND status = ANY($2)`
+	if err := sqlx.Get(q, &count, query, id, pq.StringArray(activeBookingStatuses)); err != nil {
+		log.Printf("countActiveBookings: error counting bookings of %s: %v", id, err)
+		return 0, err
+	}
+	return count, nil
+}
+
+/*
+Fungsi untuk membuat instance baru dari CustomerRepository.
+Instance repositori dikembalikan.
+*/
+func NewCustomerRepository(db *sqlx.DB) CustomerRepository {
+	return &customerRepository{db: db}
+}
@@ -0,0 +1,56 @@
+package customer
+
+import (
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/middleware"
+)
+
+/*
+Fungsi untuk mengatur rute fitur customer.
+Router dikonfigurasi dengan rute yang diperlukan.
+*/
+func SetupCustomerRoutes(router *mux.Router, h *CustomerHandler) {
+	// Setup group customer
+	customer := router.PathPrefix("/api/v1/customer").Subrouter()
+
+	// Setup public routes
+	customer.HandleFunc("/register", h.CreateCustomer).Methods("POST")
+	customer.HandleFunc("/login", h.LoginCustomer).Methods("POST")
+	customer.HandleFunc("/auth/refresh", h.RefreshTokenCustomer).Methods("POST")
+	customer.HandleFunc("/auth/oidc/authorize", h.OIDCAuthorizeCustomer).Methods("GET")
+	customer.HandleFunc("/auth/oidc/callback", h.OIDCCallbackCustomer).Methods("POST")
+	customer.HandleFunc("/auth/forgot-password", h.ForgotPasswordCustomer).Methods("POST")
+	customer.HandleFunc("/auth/reset-password", h.ResetPasswordCustomer).Methods("POST")
+	customer.HandleFunc("/auth/verify-email", h.VerifyEmailCustomer).Methods("POST")
+	customer.HandleFunc("/auth/resend-verification", h.ResendVerificationCustomer).Methods("POST")
+
+	// Setup optional auth routes
+	optional := customer.PathPrefix("").Subrouter()
+	optional.Use(middleware.OptionalJWTMiddleware)
+	optional.HandleFunc("/auth/logout", h.LogoutCustomer).Methods("POST")
+
+	// Setup protected routes
+	protected := customer.PathPrefix("").Subrouter()
+
+	// Middleware JWT
+	protected.Use(middleware.JWTMiddleware)
+
+	// Middleware customer only
+	protected.Use(middleware.Customer)
+
+	// Endpoint protected
+	protected.HandleFunc("/auth/logout-all", h.LogoutAllCustomer).Methods("POST")
+	protected.HandleFunc("/auth/change-password", h.ChangePasswordCustomer).Methods("POST")
+	protected.HandleFunc("/account/export", h.ExportCustomer).Methods("GET")
+	protected.HandleFunc("/account/deletion", h.RequestDeletionCustomer).Methods("POST")
+	protected.HandleFunc("/account/deletion", h.GetDeletionCustomer).Methods("GET")
+	protected.HandleFunc("/account/deletion", h.CancelDeletionCustomer).Methods("DELETE")
+	protected.HandleFunc("/auth/sessions", h.GetSessionsCustomer).Methods("GET")
+	protected.HandleFunc("/auth/sessions", h.TerminateSessionCustomer).Methods("DELETE")
+	protected.Handle("/profile", middleware.RequireFunc(h.GetDetailCustomer, auth.PermProfileRead)).Methods("GET")
+	protected.Handle("/profile", middleware.RequireFunc(h.UpdateCustomer, auth.PermProfileWrite)).Methods("PUT")
+	protected.Handle("/profile/photo", middleware.RequireFunc(h.UpdateProfilePhoto, auth.PermProfileWrite)).Methods("PUT")
+	protected.Handle("/profile/photo", middleware.RequireFunc(h.DeleteProfilePhoto, auth.PermProfileWrite)).Methods("DELETE")
+}
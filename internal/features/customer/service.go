@@ -0,0 +1,731 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Struktur untuk respons customer.
+Struktur ini berisi data token dan informasi customer.
+*/
+type CustomerResponse struct {
+	ID           string `json:"id"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+/*
+Struktur untuk ekspor data pribadi customer.
+Struktur ini berisi profil, riwayat sewa, deposit, keranjang, sesi login, identitas tertaut, dan jadwal penghapusan akun.
+*/
+type CustomerExport struct {
+	ExportedAt      time.Time                   `json:"exported_at"`
+	Profile         *model.CustomerModel        `json:"profile"`
+	Bookings        []*model.BookingModel       `json:"bookings"`
+	Orders          []*model.OrderModel         `json:"orders"`
+	StockHolds      []*model.StockHoldModel     `json:"stock_holds"`
+	DepositEntries  []*model.DepositEntryModel  `json:"deposit_entries"`
+	Cart            []*model.CartItemModel      `json:"cart"`
+	Sessions        []*model.SessionModel       `json:"sessions"`
+	Identities      []*model.OIDCIdentityModel  `json:"linked_identities"`
+	PendingDeletion *model.AccountDeletionModel `json:"pending_deletion,omitempty"`
+}
+
+/*
+Struktur untuk layanan customer.
+Struktur ini menyediakan logika bisnis untuk operasi customer.
+*/
+type customerService struct {
+	repo       CustomerRepository
+	tokens     auth.TokenIssuer
+	revocation auth.RevocationStore
+	sessions   auth.SessionStore
+	reset      auth.PasswordResetService
+	passwords  auth.PasswordPolicy
+	guard      auth.LoginGuard
+	verifier   auth.EmailVerifier
+	oidc       auth.OIDCProvider
+	deletion   auth.AccountDeletionService
+}
+
+/*
+Metode untuk menghasilkan access token JWT untuk customer.
+Respons token yang terikat ke sesi tanpa refresh token dikembalikan jika berhasil.
+*/
+func (s *customerService) generateAccessTokenCustomer(userID, sessionID string) (*CustomerResponse, error) {
+	claims := auth.NewClaims(userID, "customer")
+	claims.SessionID = sessionID
+	accessToken, expiresIn, err := s.tokens.IssueAccessToken(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CustomerResponse{
+		ID:          userID,
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   expiresIn,
+	}, nil
+}
+
+/*
+Metode untuk menghasilkan pasangan token untuk customer dalam sesi baru.
+Respons access token dan refresh token tersimpan dikembalikan jika berhasil.
+*/
+func (s *customerService) generateTokenCustomer(userID string, client auth.ClientInfo) (*CustomerResponse, error) {
+	refreshToken, sessionID, err := s.tokens.IssueRefreshToken(userID, "customer", client)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.generateAccessTokenCustomer(userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	resp.RefreshToken = refreshToken
+	return resp, nil
+}
+
+/*
+Metode untuk mengautentikasi customer dengan email dan password.
+Respons token dikembalikan jika berhasil.
+*/
+func (s *customerService) LoginCustomer(email, password string, client auth.ClientInfo) (*CustomerResponse, error) {
+	// Tolak lebih awal jika email atau IP sedang diblokir
+	if err := s.guard.Check("customer", email, client); err != nil {
+		return nil, err
+	}
+
+	customer, err := s.repo.FindByEmailCustomerForLogin(email)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil || bcrypt.CompareHashAndPassword([]byte(customer.PasswordHash), []byte(password)) != nil {
+		s.guard.RecordFailure("customer", email, client)
+		return nil, errors.New(message.MsgCustomerInvalidCredentials)
+	}
+
+	s.guard.RecordSuccess("customer", email)
+	return s.generateTokenCustomer(customer.ID, client)
+}
+
+/*
+Metode untuk membuat URL login customer melalui penyedia OpenID Connect.
+URL otorisasi dengan state dan PKCE dikembalikan.
+*/
+func (s *customerService) OIDCAuthorizeCustomer() (string, error) {
+	return s.oidc.AuthorizationURL("customer")
+}
+
+/*
+Metode untuk menyelesaikan login customer melalui penyedia OpenID Connect.
+Akun ditautkan atau dibuat berdasarkan email terverifikasi, lalu pasangan token dikembalikan.
+*/
+func (s *customerService) LoginOIDCCustomer(state, code string, client auth.ClientInfo) (*CustomerResponse, error) {
+	identity, err := s.oidc.Exchange("customer", state, code)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := s.oidc.LinkedUserID(identity, "customer")
+	if err != nil {
+		return nil, err
+	}
+	if userID != "" {
+		customer, err := s.repo.GetDetailCustomer(userID)
+		if err != nil {
+			return nil, err
+		}
+		if customer == nil {
+			userID = ""
+		}
+	}
+	if userID == "" {
+		if userID, err = s.linkOIDCCustomer(identity); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.generateTokenCustomer(userID, client)
+}
+
+/*
+Metode untuk menautkan identitas OpenID Connect ke akun customer berdasarkan email.
+Akun terverifikasi yang ada ditautkan tanpa mengubah password dan sesinya, akun yang emailnya belum terverifikasi ditolak, atau akun baru dibuat jika email belum terdaftar.
+*/
+func (s *customerService) linkOIDCCustomer(identity *auth.OIDCIdentity) (string, error) {
+	if !identity.EmailVerified || identity.Email == "" {
+		return "", auth.ErrOIDCEmailUnverified
+	}
+
+	customer, err := s.repo.FindByEmailCustomerForLogin(identity.Email)
+	if err != nil {
+		return "", err
+	}
+	if customer != nil {
+		// Akun dengan email belum terverifikasi bisa saja didaftarkan orang lain lebih dulu
+		if customer.EmailVerifiedAt == nil {
+			return "", auth.ErrOIDCAccountUnverified
+		}
+	} else {
+		if customer, err = newOIDCCustomer(identity); err != nil {
+			return "", err
+		}
+		if err := s.repo.CreateCustomer(customer); err != nil {
+			if strings.Contains(err.Error(), "duplicate") {
+				return "", errors.New(message.MsgCustomerEmailExists)
+			}
+			return "", err
+		}
+
+		// Penyedia sudah memverifikasi email sehingga akun baru ikut ditandai terverifikasi
+		if _, err := s.repo.MarkEmailVerifiedCustomer(customer.ID, customer.Email); err != nil {
+			return "", err
+		}
+	}
+
+	if err := s.oidc.Link(identity, "customer", customer.ID); err != nil {
+		return "", err
+	}
+	return customer.ID, nil
+}
+
+/*
+Metode untuk merotasi refresh token customer.
+Pasangan token baru dikembalikan jika refresh token valid.
+*/
+func (s *customerService) RefreshTokenCustomer(refreshToken string) (*CustomerResponse, error) {
+	next, raw, err := s.tokens.RotateRefreshToken(refreshToken, "customer")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.generateAccessTokenCustomer(next.UserID, next.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	resp.RefreshToken = raw
+	return resp, nil
+}
+
+/*
+Metode untuk logout customer.
+Refresh token beserta keluarganya dicabut.
+*/
+func (s *customerService) LogoutCustomer(ctx context.Context, refreshToken string) error {
+	if _, err := s.tokens.RevokeRefreshToken(refreshToken, "customer"); err != nil {
+		return err
+	}
+
+	// Cabut access token yang sedang dipakai jika disertakan
+	claims := middleware.GetClaims(ctx)
+	if claims != nil && claims.Role == "customer" && claims.ExpiresAt != nil {
+		return s.revocation.RevokeToken(claims.ID, claims.Subject, claims.Role, claims.ExpiresAt.Time)
+	}
+	return nil
+}
+
+/*
+Metode untuk meminta tautan reset password customer.
+Tautan dikirim jika email terdaftar tanpa membocorkan keberadaan akun.
+*/
+func (s *customerService) ForgotPasswordCustomer(email string) error {
+	customer, err := s.repo.FindByEmailCustomerForLogin(strings.TrimSpace(email))
+	if err != nil {
+		return err
+	}
+	if customer == nil {
+		return nil
+	}
+	return s.reset.SendResetLink(customer.ID, "customer", customer.Email, customer.FullName)
+}
+
+/*
+Metode untuk mengatur ulang password customer dengan token reset.
+Password diperbarui dan semua sesi customer dicabut jika token valid.
+*/
+func (s *customerService) ResetPasswordCustomer(token, password string) error {
+	userID, err := s.reset.Peek(token, "customer")
+	if err != nil {
+		return err
+	}
+	customer, err := s.repo.GetDetailCustomer(userID)
+	if err != nil {
+		return err
+	}
+	if customer == nil {
+		return auth.ErrResetTokenInvalid
+	}
+	if err := s.passwords.Validate(password, customer.Email, customer.FullName); err != nil {
+		return err
+	}
+	if _, err := s.reset.Consume(token, "customer"); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New(message.MsgFailedToHashPassword)
+	}
+	if err := s.repo.UpdatePasswordCustomer(userID, string(hash)); err != nil {
+		return err
+	}
+
+	return s.revocation.RevokeAllForUser(userID, "customer")
+}
+
+/*
+Metode untuk mengganti password customer yang sedang login.
+Password lama diverifikasi, password baru divalidasi terhadap kebijakan, lalu semua sesi dicabut.
+*/
+func (s *customerService) ChangePasswordCustomer(ctx context.Context, currentPassword, newPassword string) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	if currentPassword == "" {
+		return errors.New(message.MsgPasswordCurrentRequired)
+	}
+	customer, err := s.repo.GetDetailCustomer(userID)
+	if err != nil {
+		return err
+	}
+	if customer == nil {
+		return errors.New(message.MsgUnauthorized)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(customer.PasswordHash), []byte(currentPassword)) != nil {
+		return errors.New(message.MsgPasswordCurrentInvalid)
+	}
+	if currentPassword == newPassword {
+		return errors.New(message.MsgPasswordUnchanged)
+	}
+	if err := s.passwords.Validate(newPassword, customer.Email, customer.FullName); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New(message.MsgFailedToHashPassword)
+	}
+	if err := s.repo.UpdatePasswordCustomer(userID, string(hash)); err != nil {
+		return err
+	}
+
+	return s.revocation.RevokeAllForUser(userID, "customer")
+}
+
+/*
+Metode untuk logout customer dari semua perangkat.
+Semua access token dan refresh token milik customer dicabut.
+*/
+func (s *customerService) LogoutAllCustomer(ctx context.Context) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	return s.revocation.RevokeAllForUser(userID, "customer")
+}
+
+/*
+Metode untuk mengambil sesi login aktif milik customer.
+Daftar sesi dikembalikan dengan sesi permintaan saat ini ditandai.
+*/
+func (s *customerService) GetSessionsCustomer(ctx context.Context) ([]*model.SessionModel, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	var currentSessionID string
+	if claims := middleware.GetClaims(ctx); claims != nil {
+		currentSessionID = claims.SessionID
+	}
+	return s.sessions.List(userID, "customer", currentSessionID)
+}
+
+/*
+Metode untuk mengakhiri satu sesi login milik customer dari jarak jauh.
+Refresh token sesi dicabut dan access token sesi tersebut ditolak.
+*/
+func (s *customerService) TerminateSessionCustomer(ctx context.Context, sessionID string) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	return s.sessions.Terminate(userID, "customer", sessionID)
+}
+
+/*
+Metode untuk membuat customer baru dengan hashing password.
+Customer berhasil dibuat atau error dikembalikan.
+*/
+func (s *customerService) CreateCustomer(customer *model.CustomerModel) error {
+	if customer.ProfilePhoto != "" && !isValidPhotoURL(customer.ProfilePhoto) {
+		return errors.New(message.MsgCustomerPhotoInvalid)
+	}
+	if err := s.passwords.Validate(customer.PasswordHash, customer.Email, customer.FullName); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(customer.PasswordHash), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New(message.MsgFailedToHashPassword)
+	}
+	customer.PasswordHash = string(hash)
+	customer.CreatedAt = time.Now()
+	customer.UpdatedAt = time.Now()
+
+	err = s.repo.CreateCustomer(customer)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return errors.New(message.MsgCustomerEmailExists)
+		}
+		return err
+	}
+
+	// Kirim tautan verifikasi tanpa menggagalkan pendaftaran
+	if err := s.verifier.SendVerification(customer.ID, "customer", customer.Email, customer.FullName); err != nil {
+		log.Printf("CreateCustomer: failed to send verification email to %s: %v", customer.Email, err)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk memverifikasi email customer dengan token dari tautan.
+Email ditandai terverifikasi jika token valid.
+*/
+func (s *customerService) VerifyEmailCustomer(token string) error {
+	claims, err := s.verifier.Verify(token, "customer")
+	if err != nil {
+		return err
+	}
+	verified, err := s.repo.MarkEmailVerifiedCustomer(claims.UserID, claims.Email)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		existing, err := s.repo.GetDetailCustomer(claims.UserID)
+		if err != nil {
+			return err
+		}
+		// Tautan lama tetap dianggap berhasil jika email sudah terverifikasi
+		if existing == nil || existing.EmailVerifiedAt == nil || !strings.EqualFold(existing.Email, claims.Email) {
+			return auth.ErrVerificationTokenInvalid
+		}
+	}
+	return nil
+}
+
+/*
+Metode untuk mengirim ulang email verifikasi customer.
+Email dikirim hanya jika akun ada dan belum terverifikasi.
+*/
+func (s *customerService) ResendVerificationCustomer(email string) error {
+	customer, err := s.repo.FindByEmailCustomerForLogin(strings.TrimSpace(email))
+	if err != nil {
+		return err
+	}
+	if customer == nil || customer.EmailVerifiedAt != nil {
+		return nil
+	}
+	return s.verifier.SendVerification(customer.ID, "customer", customer.Email, customer.FullName)
+}
+
+/*
+Metode untuk mengambil detail customer dari konteks.
+Model customer dikembalikan jika ditemukan.
+*/
+func (s *customerService) GetDetailCustomer(ctx context.Context) (*model.CustomerModel, error) {
+	id, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || id == "" {
+		return nil, errors.New("invalid token claims")
+	}
+
+	customer, err := s.repo.GetDetailCustomer(id)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, errors.New(message.MsgCustomerNotFound)
+	}
+
+	return customer, nil
+}
+
+/*
+Metode untuk memperbarui profil customer dari konteks.
+Model customer terbaru dikembalikan jika berhasil.
+*/
+func (s *customerService) UpdateCustomer(ctx context.Context, input *model.CustomerModel) (*model.CustomerModel, error) {
+	existing, err := s.GetDetailCustomer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.FullName = strings.TrimSpace(input.FullName)
+	input.PhoneNumber = strings.TrimSpace(input.PhoneNumber)
+	input.Address = strings.TrimSpace(input.Address)
+
+	if input.FullName == "" {
+		return nil, errors.New(message.MsgCustomerFullNameRequired)
+	}
+	if len(input.PhoneNumber) > 20 {
+		return nil, errors.New(message.MsgCustomerPhoneTooLong)
+	}
+
+	input.ID = existing.ID
+	input.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateCustomer(input); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDetailCustomer(existing.ID)
+}
+
+/*
+Metode untuk memperbarui foto profil customer dari konteks.
+Model customer terbaru dikembalikan jika berhasil.
+*/
+func (s *customerService) UpdateProfilePhoto(ctx context.Context, photo string) (*model.CustomerModel, error) {
+	existing, err := s.GetDetailCustomer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	photo = strings.TrimSpace(photo)
+	if photo != "" && !isValidPhotoURL(photo) {
+		return nil, errors.New(message.MsgCustomerPhotoInvalid)
+	}
+
+	if err := s.repo.UpdateProfilePhoto(existing.ID, photo); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDetailCustomer(existing.ID)
+}
+
+/*
+Metode untuk mengekspor semua data pribadi customer yang sedang login.
+Profil, booking termasuk yang akan datang, order, hold stok, entri deposit, keranjang, sesi login, identitas tertaut, dan jadwal penghapusan dikembalikan dalam satu dokumen.
+*/
+func (s *customerService) ExportCustomer(ctx context.Context) (*CustomerExport, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	customer, err := s.repo.GetDetailCustomer(userID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, errors.New(message.MsgCustomerNotFound)
+	}
+
+	export := &CustomerExport{ExportedAt: time.Now(), Profile: customer}
+	if export.Bookings, err = s.repo.GetBookingsByCustomer(userID); err != nil {
+		return nil, err
+	}
+	if export.Orders, err = s.repo.GetOrdersByCustomer(userID); err != nil {
+		return nil, err
+	}
+	if export.StockHolds, err = s.repo.GetStockHoldsByCustomer(userID); err != nil {
+		return nil, err
+	}
+	if export.DepositEntries, err = s.repo.GetDepositEntriesByCustomer(userID); err != nil {
+		return nil, err
+	}
+	if export.Cart, err = s.repo.GetCartItemsByCustomer(userID); err != nil {
+		return nil, err
+	}
+	if export.Sessions, err = s.sessions.List(userID, "customer", ""); err != nil {
+		return nil, err
+	}
+	if export.Identities, err = s.oidc.Identities(userID, "customer"); err != nil {
+		return nil, err
+	}
+	if export.PendingDeletion, err = s.deletion.Pending(userID, "customer"); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+/*
+Metode untuk menjadwalkan penghapusan akun customer yang sedang login.
+Password diverifikasi ulang dan jadwal penghapusan setelah masa tenggang dikembalikan; penghapusan ditolak selama masih ada booking yang berjalan.
+*/
+func (s *customerService) RequestDeletionCustomer(ctx context.Context, password string) (*model.AccountDeletionModel, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	customer, err := s.repo.GetDetailCustomer(userID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, errors.New(message.MsgCustomerNotFound)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(customer.PasswordHash), []byte(password)) != nil {
+		return nil, errors.New(message.MsgPasswordCurrentInvalid)
+	}
+	active, err := s.repo.CountActiveBookingsByCustomer(userID)
+	if err != nil {
+		return nil, err
+	}
+	if active > 0 {
+		return nil, errors.New(message.MsgAccountDeletionActiveBookings)
+	}
+	return s.deletion.Schedule(userID, "customer")
+}
+
+/*
+Metode untuk mengambil jadwal penghapusan akun customer yang sedang login.
+ErrAccountDeletionNotFound dikembalikan jika akun tidak sedang dijadwalkan untuk dihapus.
+*/
+func (s *customerService) GetDeletionCustomer(ctx context.Context) (*model.AccountDeletionModel, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	deletion, err := s.deletion.Pending(userID, "customer")
+	if err != nil {
+		return nil, err
+	}
+	if deletion == nil {
+		return nil, auth.ErrAccountDeletionNotFound
+	}
+	return deletion, nil
+}
+
+/*
+Metode untuk membatalkan penghapusan akun customer selama masa tenggang.
+Akun tetap aktif seperti semula jika pembatalan berhasil.
+*/
+func (s *customerService) CancelDeletionCustomer(ctx context.Context) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	return s.deletion.Cancel(userID, "customer")
+}
+
+/*
+Metode untuk memproses penghapusan akun customer yang sudah jatuh tempo.
+Data pribadi dianonimkan dan data autentikasi dihapus, sedangkan riwayat sewa tetap tersimpan; akun dengan booking yang dibuat selama masa tenggang dan masih berjalan ditunda ke putaran berikutnya.
+*/
+func (s *customerService) ProcessDeletionsCustomer() {
+	deletions, err := s.deletion.Due("customer")
+	if err != nil {
+		log.Printf("ProcessDeletionsCustomer: error fetching due deletions: %v", err)
+		return
+	}
+	for _, deletion := range deletions {
+		customer, err := s.repo.GetDetailCustomer(deletion.UserID)
+		if err != nil {
+			log.Printf("ProcessDeletionsCustomer: error fetching customer %s: %v", deletion.UserID, err)
+			continue
+		}
+		var email string
+		if customer != nil {
+			email = customer.Email
+			if err := s.repo.AnonymizeCustomer(deletion.UserID); err != nil {
+				log.Printf("ProcessDeletionsCustomer: error anonymizing customer %s: %v", deletion.UserID, err)
+				continue
+			}
+		}
+		if err := s.deletion.Finish(deletion, email); err != nil {
+			log.Printf("ProcessDeletionsCustomer: error finishing deletion %s: %v", deletion.ID, err)
+		}
+	}
+}
+
+/*
+Antarmuka untuk layanan customer.
+Antarmuka ini mendefinisikan metode untuk operasi customer.
+*/
+type CustomerService interface {
+	CreateCustomer(*model.CustomerModel) error
+	LoginCustomer(email, password string, client auth.ClientInfo) (*CustomerResponse, error)
+	OIDCAuthorizeCustomer() (string, error)
+	LoginOIDCCustomer(state, code string, client auth.ClientInfo) (*CustomerResponse, error)
+	RefreshTokenCustomer(refreshToken string) (*CustomerResponse, error)
+	LogoutCustomer(ctx context.Context, refreshToken string) error
+	LogoutAllCustomer(ctx context.Context) error
+	ExportCustomer(ctx context.Context) (*CustomerExport, error)
+	RequestDeletionCustomer(ctx context.Context, password string) (*model.AccountDeletionModel, error)
+	GetDeletionCustomer(ctx context.Context) (*model.AccountDeletionModel, error)
+	CancelDeletionCustomer(ctx context.Context) error
+	ProcessDeletionsCustomer()
+	ChangePasswordCustomer(ctx context.Context, currentPassword, newPassword string) error
+	GetSessionsCustomer(ctx context.Context) ([]*model.SessionModel, error)
+	TerminateSessionCustomer(ctx context.Context, sessionID string) error
+	ForgotPasswordCustomer(email string) error
+	ResetPasswordCustomer(token, password string) error
+	VerifyEmailCustomer(token string) error
+	ResendVerificationCustomer(email string) error
+	GetDetailCustomer(ctx context.Context) (*model.CustomerModel, error)
+	UpdateCustomer(ctx context.Context, input *model.CustomerModel) (*model.CustomerModel, error)
+	UpdateProfilePhoto(ctx context.Context, photo string) (*model.CustomerModel, error)
+}
+
+/*
+Fungsi untuk memvalidasi URL foto profil.
+Nilai true dikembalikan jika URL berupa http atau https dan tidak melebihi panjang kolom.
+*/
+func isValidPhotoURL(raw string) bool {
+	if len(raw) > 500 {
+		return false
+	}
+	u, err := url.ParseRequestURI(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+/*
+Fungsi untuk membentuk akun customer baru dari identitas OpenID Connect.
+Password acak yang tidak diketahui siapa pun dipasang sampai customer mengatur password lewat reset.
+*/
+func newOIDCCustomer(identity *auth.OIDCIdentity) (*model.CustomerModel, error) {
+	hash, err := auth.UnusablePasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	name := identity.Name
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+	now := time.Now()
+	return &model.CustomerModel{
+		FullName:     name,
+		Email:        identity.Email,
+		PasswordHash: hash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+/*
+Fungsi untuk membuat instance baru dari CustomerService.
+Instance layanan dikembalikan.
+*/
+func NewCustomerService(repo CustomerRepository, tokens auth.TokenIssuer, revocation auth.RevocationStore, sessions auth.SessionStore, reset auth.PasswordResetService, passwords auth.PasswordPolicy, guard auth.LoginGuard, verifier auth.EmailVerifier, oidc auth.OIDCProvider, deletion auth.AccountDeletionService) CustomerService {
+	return &customerService{repo: repo, tokens: tokens, revocation: revocation, sessions: sessions, reset: reset, passwords: passwords, guard: guard, verifier: verifier, oidc: oidc, deletion: deletion}
+}
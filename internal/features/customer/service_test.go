@@ -0,0 +1,141 @@
+package customer
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/auth/authtest"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/mailer"
+)
+
+/*
+Struktur untuk repositori customer di memori.
+Struktur ini hanya mengimplementasikan metode yang dipakai alur reset password.
+*/
+type memoryCustomerRepository struct {
+	CustomerRepository
+	customers map[string]*model.CustomerModel
+}
+
+func (r *memoryCustomerRepository) FindByEmailCustomerForLogin(email string) (*model.CustomerModel, error) {
+	for _, customer := range r.customers {
+		if customer.Email == email {
+			return customer, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryCustomerRepository) GetDetailCustomer(id string) (*model.CustomerModel, error) {
+	return r.customers[id], nil
+}
+
+func (r *memoryCustomerRepository) UpdatePasswordCustomer(id string, passwordHash string) error {
+	r.customers[id].PasswordHash = passwordHash
+	return nil
+}
+
+/*
+Struktur untuk repositori pencabutan token di memori.
+Struktur ini menyimpan batas pencabutan setiap pengguna.
+*/
+type memoryRevocationRepository struct {
+	auth.RevocationRepository
+	before map[string]time.Time
+}
+
+func (r *memoryRevocationRepository) RevokeAllForUser(userID, role string, before time.Time) error {
+	r.before[role+":"+userID] = before
+	return nil
+}
+
+func (r *memoryRevocationRepository) IsTokenRevoked(jti string) (bool, error) {
+	return false, nil
+}
+
+func (r *memoryRevocationRepository) FindRevokedBefore(userID, role string) (*time.Time, error) {
+	if before, ok := r.before[role+":"+userID]; ok {
+		return &before, nil
+	}
+	return nil, nil
+}
+
+/*
+Struktur untuk repositori refresh token di memori.
+Struktur ini mencatat pengguna yang seluruh refresh token-nya dicabut.
+*/
+type memoryRefreshRepository struct {
+	auth.RefreshTokenRepository
+	revoked []string
+}
+
+func (r *memoryRefreshRepository) RevokeRefreshTokensByUser(userID, role string) error {
+	r.revoked = append(r.revoked, role+":"+userID)
+	return nil
+}
+
+/*
+Struktur untuk kebijakan password yang menerima semua password.
+Struktur ini memisahkan pengujian reset dari aturan kekuatan password.
+*/
+type acceptAllPolicy struct{}
+
+func (acceptAllPolicy) Validate(password string, personal ...string) error {
+	return nil
+}
+
+func TestResetPasswordCustomerRevokesSessions(t *testing.T) {
+	mailPath := filepath.Join(t.TempDir(), "mail.log")
+	hash, err := bcrypt.GenerateFromPassword([]byte("old password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	repo := &memoryCustomerRepository{customers: map[string]*model.CustomerModel{
+		"customer-1": {ID: "customer-1", Email: "user@example.com", FullName: "Test User", PasswordHash: string(hash)},
+	}}
+	revocations := &memoryRevocationRepository{before: map[string]time.Time{}}
+	refresh := &memoryRefreshRepository{}
+	service := &customerService{
+		repo:       repo,
+		revocation: auth.NewRevocationStore(revocations, refresh),
+		reset:      auth.NewPasswordResetService(authtest.NewResetRepository(), mailer.NewFileMailer(mailPath)),
+		passwords:  acceptAllPolicy{},
+	}
+	issuedBeforeReset := time.Now().Add(-time.Minute)
+
+	if err := service.ForgotPasswordCustomer("user@example.com"); err != nil {
+		t.Fatalf("ForgotPasswordCustomer: %v", err)
+	}
+	token := authtest.LastResetToken(t, mailPath)
+
+	if err := service.ResetPasswordCustomer(token, "new password"); err != nil {
+		t.Fatalf("ResetPasswordCustomer: %v", err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(repo.customers["customer-1"].PasswordHash), []byte("new password")) != nil {
+		t.Fatal("password was not updated")
+	}
+
+	revoked, err := service.revocation.IsRevoked("", "customer-1", "customer", issuedBeforeReset)
+	if err != nil {
+		t.Fatalf("IsRevoked: %v", err)
+	}
+	if !revoked {
+		t.Fatal("access token issued before the reset is still valid")
+	}
+	if len(refresh.revoked) != 1 || refresh.revoked[0] != "customer:customer-1" {
+		t.Fatalf("refresh tokens revoked for %v, want [customer:customer-1]", refresh.revoked)
+	}
+
+	if err := service.ResetPasswordCustomer(token, "another password"); !errors.Is(err, auth.ErrResetTokenInvalid) {
+		t.Fatalf("reused token error = %v, want %v", err, auth.ErrResetTokenInvalid)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(repo.customers["customer-1"].PasswordHash), []byte("new password")) != nil {
+		t.Fatal("reused token changed the password")
+	}
+}
@@ -0,0 +1,293 @@
+package deposit
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/model"
+	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
+)
+
+/*
+Struktur untuk handler deposit.
+Struktur ini menangani permintaan laporan dan penyelesaian deposit booking oleh customer, hoster, dan admin.
+*/
+type DepositHandler struct {
+	service DepositService
+}
+
+/*
+Struktur untuk permintaan potongan deposit.
+Struktur ini berisi jumlah potongan, alasan kerusakan, dan URL foto bukti.
+*/
+type DeductionRequest struct {
+	Amount int      `json:"amount"`
+	Reason string   `json:"reason"`
+	Photos []string `json:"photos"`
+}
+
+/*
+Struktur untuk permintaan sanggahan potongan deposit.
+Struktur ini berisi catatan alasan customer menyanggah potongan.
+*/
+type DisputeRequest struct {
+	Note string `json:"note"`
+}
+
+/*
+Struktur untuk permintaan keputusan sengketa potongan deposit.
+Struktur ini berisi keputusan admin (uphold atau withdraw) dan catatan alasannya.
+*/
+type ResolveRequest struct {
+	Outcome string `json:"outcome"`
+	Note    string `json:"note"`
+}
+
+/*
+Metode untuk mengambil laporan deposit booking milik customer.
+Total deposit dan entri buku besar dikembalikan.
+*/
+func (h *DepositHandler) GetCustomerStatement(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetCustomerStatement: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	statement, err := h.service.GetCustomerStatement(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetCustomerStatement: error: %v", err)
+		writeDepositError(w, err)
+		return
+	}
+
+	response.OK(w, statement, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengakui potongan deposit oleh customer.
+Respons sukses dikembalikan jika potongan diakui.
+*/
+func (h *DepositHandler) AcknowledgeDeduction(w http.ResponseWriter, r *http.Request) {
+	log.Printf("AcknowledgeDeduction: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.service.AcknowledgeDeduction(r.Context(), vars["id"], vars["entry_id"]); err != nil {
+		log.Printf("AcknowledgeDeduction: error: %v", err)
+		writeDepositError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgDepositDeductionAcknowledged)
+}
+
+/*
+Metode untuk menyanggah potongan deposit oleh customer.
+Respons sukses dikembalikan jika sanggahan dan catatannya tersimpan.
+*/
+func (h *DepositHandler) DisputeDeduction(w http.ResponseWriter, r *http.Request) {
+	log.Printf("DisputeDeduction: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req DisputeRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("DisputeDeduction: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.service.DisputeDeduction(r.Context(), vars["id"], vars["entry_id"], req.Note); err != nil {
+		log.Printf("DisputeDeduction: error: %v", err)
+		writeDepositError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgDepositDeductionDisputed)
+}
+
+/*
+Metode untuk mengambil laporan deposit booking milik toko.
+Total deposit dan entri buku besar dikembalikan.
+*/
+func (h *DepositHandler) GetStoreStatement(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetStoreStatement: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	statement, err := h.service.GetStoreStatement(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetStoreStatement: error: %v", err)
+		writeDepositError(w, err)
+		return
+	}
+
+	response.OK(w, statement, message.MsgSuccess)
+}
+
+/*
+Metode untuk mencatat potongan deposit oleh hoster.
+Potongan yang tercatat dikembalikan dengan status menunggu tanggapan customer.
+*/
+func (h *DepositHandler) CreateDeduction(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CreateDeduction: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req DeductionRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("CreateDeduction: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	input := &model.DepositEntryModel{
+		Amount: req.Amount,
+		Reason: req.Reason,
+		Photos: req.Photos,
+	}
+	entry, err := h.service.CreateDeduction(r.Context(), mux.Vars(r)["id"], input)
+	if err != nil {
+		log.Printf("CreateDeduction: error: %v", err)
+		writeDepositError(w, err)
+		return
+	}
+
+	response.Created(w, entry, message.MsgDepositDeductionCreated)
+}
+
+/*
+Metode untuk membatalkan potongan deposit oleh hoster.
+Respons sukses dikembalikan jika potongan dibatalkan.
+*/
+func (h *DepositHandler) WithdrawDeduction(w http.ResponseWriter, r *http.Request) {
+	log.Printf("WithdrawDeduction: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.service.WithdrawDeduction(r.Context(), vars["id"], vars["entry_id"]); err != nil {
+		log.Printf("WithdrawDeduction: error: %v", err)
+		writeDepositError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgDepositDeductionWithdrawn)
+}
+
+/*
+Metode untuk mengembalikan sisa deposit oleh hoster.
+Laporan deposit terbaru dikembalikan setelah sisa deposit dicatat sebagai pengembalian.
+*/
+func (h *DepositHandler) Release(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Release: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	statement, err := h.service.Release(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("Release: error: %v", err)
+		writeDepositError(w, err)
+		return
+	}
+
+	response.OK(w, statement, message.MsgDepositReleased)
+}
+
+/*
+Metode untuk mengambil laporan deposit booking oleh admin.
+Total deposit dan entri buku besar dikembalikan.
+*/
+func (h *DepositHandler) GetAdminStatement(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetAdminStatement: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	statement, err := h.service.GetAdminStatement(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetAdminStatement: error: %v", err)
+		writeDepositError(w, err)
+		return
+	}
+
+	response.OK(w, statement, message.MsgSuccess)
+}
+
+/*
+Metode untuk memutuskan potongan deposit yang disengketakan oleh admin.
+Respons sukses dikembalikan jika keputusan dan catatannya tersimpan.
+*/
+func (h *DepositHandler) ResolveDispute(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ResolveDispute: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ResolveRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ResolveDispute: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := h.service.ResolveDispute(r.Context(), vars["id"], vars["entry_id"], req.Outcome, req.Note); err != nil {
+		log.Printf("ResolveDispute: error: %v", err)
+		writeDepositError(w, err)
+		return
+	}
+
+	response.OK(w, nil, message.MsgDepositDisputeResolved)
+}
+
+/*
+Fungsi untuk menulis respons error deposit.
+Pesan error layanan dipetakan ke status HTTP yang sesuai.
+*/
+func writeDepositError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.MsgBookingIDRequired, message.MsgDepositDeductionIDRequired, message.MsgDepositDeductionAmountInvalid,
+		message.MsgDepositDeductionReasonRequired, message.MsgDepositDeductionPhotosInvalid, message.MsgDepositDisputeNoteRequired,
+		message.MsgDepositResolutionInvalid, message.MsgDepositResolutionNoteRequired:
+		response.BadRequest(w, err.Error())
+	case message.MsgBookingNotFound, message.MsgDepositDeductionNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	case message.MsgDepositNone, message.MsgDepositAlreadyReleased, message.MsgDepositNotReturned, message.MsgDepositDeductionsUnresolved:
+		response.Conflict(w, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+	}
+}
+
+/*
+Fungsi untuk membuat instance baru dari DepositHandler.
+Instance handler dikembalikan.
+*/
+func NewDepositHandler(s DepositService) *DepositHandler {
+	return &DepositHandler{service: s}
+}
@@ -0,0 +1,355 @@
+package deposit
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk kolom entri deposit yang dipilih.
+Konstanta ini dipakai bersama oleh query laporan dan pencarian entri.
+*/
+const entryColumns = `
+	id,
+	booking_id,
+	entry_type,
+	amount,
+	reason,
+	photos,
+	status,
+	customer_note,
+	created_by,
+	created_at,
+	responded_at,
+	resolution_note,
+	resolved_by,
+	resolved_at
+`
+
+/*
+Struktur untuk repositori deposit.
+Struktur ini menyediakan akses ke buku besar deposit booking.
+*/
+type depositRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk mencari booking pemilik deposit.
+Model booking dengan pemilik, toko, status, dan jumlah deposit dikembalikan jika ditemukan.
+*/
+func (r *depositRepository) FindBookingByID(id string) (*model.BookingModel, error) {
+	var booking model.BookingModel
+	query := `
+		SELECT id, customer_id, store_id, status, deposit
+		FROM bookings
+		WHERE id = $1
+	`
+	err := r.db.Get(&booking, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindBookingByID: error querying booking %s: %v", id, err)
+		return nil, err
+	}
+	return &booking, nil
+}
+
+/*
+Metode untuk mengambil laporan deposit booking.
+Total deposit dan seluruh entri buku besar dikembalikan urut waktu pencatatan.
+*/
+func (r *depositRepository) GetStatement(bookingID string) (*model.DepositStatementModel, error) {
+	statement, err := depositTotals(r.db, bookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement.Entries = []*model.DepositEntryModel{}
+	query := `
+		SELECT ` + entryColumns + `
+		FROM deposit_entries
+		WHERE booking_id = $1
+		ORDER BY created_at, id
+	`
+	if err := r.db.Select(&statement.Entries, query, bookingID); err != nil {
+		log.Printf("GetStatement: error querying booking %s: %v", bookingID, err)
+		return nil, err
+	}
+	return statement, nil
+}
+
+/*
+Metode untuk mencatat potongan deposit.
+Baris booking dikunci selama transaksi sehingga potongan bersamaan tidak pernah melebihi sisa deposit dan tidak dicatat setelah deposit dikembalikan.
+*/
+func (r *depositRepository) CreateDeduction(entry *model.DepositEntryModel) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockBooking(tx, entry.BookingID); err != nil {
+		return err
+	}
+	totals, err := depositTotals(tx, entry.BookingID)
+	if err != nil {
+		return err
+	}
+	if totals.Settled {
+		return errors.New(message.MsgDepositAlreadyReleased)
+	}
+	if entry.Amount > totals.Held-totals.Deducted {
+		return errors.New(message.MsgDepositDeductionAmountInvalid)
+	}
+
+	if err := insertEntry(tx, entry); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+/*
+Metode untuk mengembalikan sisa deposit booking.
+Baris booking dikunci, pengembalian ditolak selama masih ada potongan yang menunggu tanggapan atau disengketakan, lalu sisa saldo dicatat sebagai entri pengembalian sehingga deposit tidak dapat dipotong lagi.
+*/
+func (r *depositRepository) Release(entry *model.DepositEntryModel) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockBooking(tx, entry.BookingID); err != nil {
+		return err
+	}
+	totals, err := depositTotals(tx, entry.BookingID)
+	if err != nil {
+		return err
+	}
+	if totals.Held == 0 {
+		return errors.New(message.MsgDepositNone)
+	}
+	if totals.Settled {
+		return errors.New(message.MsgDepositAlreadyReleased)
+	}
+	// Potongan yang belum selesai masih dapat dibatalkan sehingga jumlah pengembalian belum pasti
+	if totals.Unresolved > 0 {
+		return errors.New(message.MsgDepositDeductionsUnresolved)
+	}
+
+	entry.Amount = totals.Held - totals.Deducted
+	if err := insertEntry(tx, entry); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Printf("Release: deposit of booking %s released, %d returned", entry.BookingID, entry.Amount)
+	return nil
+}
+
+/*
+Metode untuk mengubah status potongan deposit.
+Baris booking dikunci dan status hanya diubah jika deposit belum dikembalikan dan potongan masih berstatus salah satu status asal; nilai true dikembalikan jika potongan diperbarui.
+*/
+func (r *depositRepository) UpdateDeductionStatus(bookingID, id string, from []string, to, note string) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if err := lockBooking(tx, bookingID); err != nil {
+		return false, err
+	}
+	totals, err := depositTotals(tx, bookingID)
+	if err != nil {
+		return false, err
+	}
+	if totals.Settled {
+		return false, errors.New(message.MsgDepositAlreadyReleased)
+	}
+
+	query := `
+		UPDATE deposit_entries
+		SET status = $4, customer_note = COALESCE(NULLIF($5, ''), customer_note), responded_at = NOW()
+		WHERE id = $1 AND booking_id = $2 AND entry_type = 'deduction' AND status = ANY($3)
+	`
+	res, err := tx.Exec(query, id, bookingID, pq.StringArray(from), to, note)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rows == 0 {
+		return false, nil
+	}
+	return true, tx.Commit()
+}
+
+/*
+Metode untuk membatalkan potongan deposit oleh hoster.
+Potongan yang belum diakui customer ditandai withdrawn selama deposit belum dikembalikan; nilai true dikembalikan jika potongan dibatalkan.
+*/
+func (r *depositRepository) WithdrawDeduction(bookingID, id string) (bool, error) {
+	query := `
+		UPDATE deposit_entries d
+		SET status = 'withdrawn'
+		WHERE d.id = $1 AND d.booking_id = $2 AND d.entry_type = 'deduction' AND d.status IN ('pending', 'disputed')
+			AND NOT EXISTS (
+				SELECT 1 FROM deposit_entries x
+				WHERE x.booking_id = d.booking_id AND x.entry_type = 'release'
+			)
+	`
+	res, err := r.db.Exec(query, id, bookingID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+/*
+Metode untuk memutuskan potongan deposit yang disengketakan.
+Baris booking dikunci dan potongan yang masih disengketakan diubah ke status keputusan beserta catatan dan admin yang memutuskan selama deposit belum dikembalikan; nilai true dikembalikan jika potongan diperbarui.
+*/
+func (r *depositRepository) ResolveDispute(bookingID, id, to, note, adminID string) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if err := lockBooking(tx, bookingID); err != nil {
+		return false, err
+	}
+	totals, err := depositTotals(tx, bookingID)
+	if err != nil {
+		return false, err
+	}
+	if totals.Settled {
+		return false, errors.New(message.MsgDepositAlreadyReleased)
+	}
+
+	query := `
+		UPDATE deposit_entries
+		SET status = $3, resolution_note = $4, resolved_by = $5, resolved_at = NOW()
+		WHERE id = $1 AND booking_id = $2 AND entry_type = 'deduction' AND status = 'disputed'
+	`
+	res, err := tx.Exec(query, id, bookingID, to, note, adminID)
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rows == 0 {
+		return false, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	log.Printf("ResolveDispute: deduction %s of booking %s resolved as %s by admin %s", id, bookingID, to, adminID)
+	return true, nil
+}
+
+/*
+Interface untuk operasi repositori deposit.
+Interface ini mendefinisikan metode untuk membaca dan mencatat buku besar deposit booking.
+*/
+type DepositRepository interface {
+	FindBookingByID(id string) (*model.BookingModel, error)
+	GetStatement(bookingID string) (*model.DepositStatementModel, error)
+	CreateDeduction(entry *model.DepositEntryModel) error
+	Release(entry *model.DepositEntryModel) error
+	UpdateDeductionStatus(bookingID, id string, from []string, to, note string) (bool, error)
+	WithdrawDeduction(bookingID, id string) (bool, error)
+	ResolveDispute(bookingID, id, to, note, adminID string) (bool, error)
+}
+
+/*
+Fungsi untuk mengunci baris booking selama transaksi deposit.
+Error dikembalikan jika booking tidak ditemukan.
+*/
+func lockBooking(tx *sqlx.Tx, bookingID string) error {
+	var id string
+	lock := `SELECT id FROM bookings WHERE id = $1 FOR UPDATE`
+	if err := tx.Get(&id, lock, bookingID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New(message.MsgBookingNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+/*
+Fungsi untuk menghitung total deposit booking.
+Jumlah yang ditahan, dipotong, disengketakan, dan dikembalikan serta banyaknya potongan yang belum selesai dihitung dalam satu query; potongan yang dibatalkan tidak dihitung.
+*/
+func depositTotals(q sqlx.Queryer, bookingID string) (*model.DepositStatementModel, error) {
+	var totals model.DepositStatementModel
+	query := `
+		SELECT
+			COALESCE(SUM(amount) FILTER (WHERE entry_type = 'hold'), 0) AS held,
+			COALESCE(SUM(amount) FILTER (WHERE entry_type = 'deduction' AND status <> 'withdrawn'), 0) AS deducted,
+			COALESCE(SUM(amount) FILTER (WHERE entry_type = 'deduction' AND status = 'disputed'), 0) AS disputed,
+			COUNT(*) FILTER (WHERE entry_type = 'deduction' AND status IN ('pending', 'disputed')) AS unresolved,
+			COALESCE(SUM(amount) FILTER (WHERE entry_type = 'release'), 0) AS released,
+			COUNT(*) FILTER (WHERE entry_type = 'release') > 0 AS settled
+		FROM deposit_entries
+		WHERE booking_id = $1
+	`
+	if err := sqlx.Get(q, &totals, query, bookingID); err != nil {
+		log.Printf("depositTotals: error querying booking %s: %v", bookingID, err)
+		return nil, err
+	}
+	return &totals, nil
+}
+
+/*
+Fungsi untuk menyimpan entri deposit baru.
+ID dan waktu pencatatan diisi dari database.
+*/
+func insertEntry(tx *sqlx.Tx, entry *model.DepositEntryModel) error {
+	if entry.Photos == nil {
+		entry.Photos = pq.StringArray{}
+	}
+	insert := `
+		INSERT INTO deposit_entries (
+			booking_id,
+			entry_type,
+			amount,
+			reason,
+			photos,
+			status,
+			created_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	return tx.QueryRow(insert, entry.BookingID, entry.EntryType, entry.Amount, entry.Reason, entry.Photos, entry.Status, entry.CreatedBy).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+/*
+Fungsi untuk membuat instance baru dari DepositRepository.
+Instance repositori dikembalikan.
+*/
+func NewDepositRepository(db *sqlx.DB) DepositRepository {
+	return &depositRepository{db: db}
+}
@@ -0,0 +1,39 @@
+package deposit
+
+import (
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/middleware"
+)
+
+/*
+Fungsi untuk mengatur rute fitur deposit.
+Router dikonfigurasi dengan rute laporan dan tanggapan deposit customer, potongan dan pengembalian deposit oleh hoster, serta keputusan sengketa oleh admin.
+*/
+func SetupDepositRoutes(router *mux.Router, h *DepositHandler) {
+	// Setup group deposit customer
+	customer := router.PathPrefix("/api/v1/customer/bookings/{id}/deposit").Subrouter()
+	customer.Use(middleware.JWTMiddleware)
+	customer.Use(middleware.Customer)
+	customer.Handle("", middleware.RequireFunc(h.GetCustomerStatement, auth.PermBookingRead)).Methods("GET")
+	customer.Handle("/deductions/{entry_id}/acknowledge", middleware.RequireFunc(h.AcknowledgeDeduction, auth.PermBookingWrite)).Methods("POST")
+	customer.Handle("/deductions/{entry_id}/dispute", middleware.RequireFunc(h.DisputeDeduction, auth.PermBookingWrite)).Methods("POST")
+
+	// Setup group deposit hoster
+	hoster := router.PathPrefix("/api/v1/hoster/bookings/{id}/deposit").Subrouter()
+	hoster.Use(middleware.JWTMiddleware)
+	hoster.Use(middleware.Hoster)
+	hoster.Handle("", middleware.RequireFunc(h.GetStoreStatement, auth.PermBookingRead)).Methods("GET")
+	hoster.Handle("/deductions", middleware.RequireFunc(h.CreateDeduction, auth.PermBookingWrite)).Methods("POST")
+	hoster.Handle("/deductions/{entry_id}", middleware.RequireFunc(h.WithdrawDeduction, auth.PermBookingWrite)).Methods("DELETE")
+	hoster.Handle("/release", middleware.RequireFunc(h.Release, auth.PermBookingWrite)).Methods("POST")
+
+	// Setup group deposit admin
+	admin := router.PathPrefix("/api/v1/admin/bookings/{id}/deposit").Subrouter()
+	admin.Use(middleware.JWTMiddleware)
+	admin.Use(middleware.Admin)
+	admin.Use(middleware.RequireAdminMFA)
+	admin.Handle("", middleware.RequireFunc(h.GetAdminStatement, auth.PermDepositRead)).Methods("GET")
+	admin.Handle("/deductions/{entry_id}/resolve", middleware.RequireFunc(h.ResolveDispute, auth.PermDepositWrite)).Methods("POST")
+}
@@ -0,0 +1,392 @@
+package deposit
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"lalan-be/internal/middleware"
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk batas jumlah foto bukti potongan deposit.
+Konstanta ini mencegah satu potongan menyimpan daftar foto yang berlebihan.
+*/
+const maxDeductionPhotos = 10
+
+/*
+Variabel untuk status booking yang deposit-nya dapat dipotong.
+Variabel ini memastikan potongan kerusakan hanya dicatat setelah item kembali ke hoster.
+*/
+var deductibleStatuses = []string{model.BookingStatusReturned, model.BookingStatusCompleted}
+
+/*
+Variabel untuk status booking yang deposit-nya dapat dikembalikan.
+Variabel ini mencakup booking yang item-nya sudah kembali serta booking yang batal sebelum item diambil.
+*/
+var releasableStatuses = []string{model.BookingStatusReturned, model.BookingStatusCompleted, model.BookingStatusCancelled, model.BookingStatusRejected}
+
+/*
+Variabel untuk keputusan admin atas potongan yang disengketakan.
+Variabel ini memetakan keputusan ke status potongan: uphold mempertahankan potongan, withdraw membatalkannya.
+*/
+var resolutionStatuses = map[string]string{
+	"uphold":   model.DepositStatusAcknowledged,
+	"withdraw": model.DepositStatusWithdrawn,
+}
+
+/*
+Struktur untuk layanan deposit.
+Struktur ini menyediakan logika bisnis untuk buku besar deposit booking, potongan kerusakan, dan tanggapan customer.
+*/
+type depositService struct {
+	repo DepositRepository
+}
+
+/*
+Metode untuk mengambil laporan deposit booking milik customer.
+Total deposit dan seluruh entri buku besar dikembalikan.
+*/
+func (s *depositService) GetCustomerStatement(ctx context.Context, bookingID string) (*model.DepositStatementModel, error) {
+	booking, err := s.customerBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	return s.statement(booking)
+}
+
+/*
+Metode untuk mengambil laporan deposit booking milik toko.
+Total deposit dan seluruh entri buku besar dikembalikan.
+*/
+func (s *depositService) GetStoreStatement(ctx context.Context, bookingID string) (*model.DepositStatementModel, error) {
+	booking, err := s.storeBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	return s.statement(booking)
+}
+
+/*
+Metode untuk mencatat potongan deposit oleh hoster.
+Potongan dengan alasan dan foto bukti dicatat menunggu tanggapan customer, tanpa melebihi sisa deposit.
+*/
+func (s *depositService) CreateDeduction(ctx context.Context, bookingID string, input *model.DepositEntryModel) (*model.DepositEntryModel, error) {
+	booking, err := s.storeBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(deductibleStatuses, booking.Status) {
+		return nil, errors.New(message.MsgDepositNotReturned)
+	}
+
+	input.Reason = strings.TrimSpace(input.Reason)
+	if input.Reason == "" {
+		return nil, errors.New(message.MsgDepositDeductionReasonRequired)
+	}
+	if input.Amount < 1 {
+		return nil, errors.New(message.MsgDepositDeductionAmountInvalid)
+	}
+	photos, err := cleanPhotos(input.Photos)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, _ := userFromContext(ctx)
+	entry := &model.DepositEntryModel{
+		BookingID: booking.ID,
+		EntryType: model.DepositEntryDeduction,
+		Amount:    input.Amount,
+		Reason:    input.Reason,
+		Photos:    photos,
+		Status:    model.DepositStatusPending,
+		CreatedBy: &userID,
+	}
+	if err := s.repo.CreateDeduction(entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+/*
+Metode untuk membatalkan potongan deposit oleh hoster.
+Potongan yang belum diakui customer dapat dibatalkan selama deposit belum dikembalikan.
+*/
+func (s *depositService) WithdrawDeduction(ctx context.Context, bookingID, id string) error {
+	booking, err := s.storeBooking(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	withdrawn, err := s.repo.WithdrawDeduction(booking.ID, strings.TrimSpace(id))
+	if err != nil {
+		return err
+	}
+	if !withdrawn {
+		return errors.New(message.MsgDepositDeductionNotFound)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk mengembalikan sisa deposit oleh hoster.
+Setelah semua potongan diakui atau dibatalkan, sisa deposit dicatat sebagai pengembalian dan laporan deposit terbaru dikembalikan.
+*/
+func (s *depositService) Release(ctx context.Context, bookingID string) (*model.DepositStatementModel, error) {
+	booking, err := s.storeBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(releasableStatuses, booking.Status) {
+		return nil, errors.New(message.MsgDepositNotReturned)
+	}
+
+	userID, _ := userFromContext(ctx)
+	entry := &model.DepositEntryModel{
+		BookingID: booking.ID,
+		EntryType: model.DepositEntryRelease,
+		Reason:    "Released by store",
+		Status:    model.DepositStatusPosted,
+		CreatedBy: &userID,
+	}
+	if err := s.repo.Release(entry); err != nil {
+		return nil, err
+	}
+
+	return s.statement(booking)
+}
+
+/*
+Metode untuk mengakui potongan deposit oleh customer.
+Potongan yang menunggu tanggapan atau yang sebelumnya disanggah ditandai acknowledged, sehingga sengketa dapat diselesaikan customer.
+*/
+func (s *depositService) AcknowledgeDeduction(ctx context.Context, bookingID, id string) error {
+	from := []string{model.DepositStatusPending, model.DepositStatusDisputed}
+	return s.respond(ctx, bookingID, id, from, model.DepositStatusAcknowledged, "")
+}
+
+/*
+Metode untuk menyanggah potongan deposit oleh customer.
+Potongan yang menunggu tanggapan ditandai disputed beserta catatan alasan customer.
+*/
+func (s *depositService) DisputeDeduction(ctx context.Context, bookingID, id, note string) error {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return errors.New(message.MsgDepositDisputeNoteRequired)
+	}
+	return s.respond(ctx, bookingID, id, []string{model.DepositStatusPending}, model.DepositStatusDisputed, note)
+}
+
+/*
+Metode untuk mengambil laporan deposit booking oleh admin.
+Total deposit dan seluruh entri buku besar booking mana pun dikembalikan untuk penyelesaian sengketa.
+*/
+func (s *depositService) GetAdminStatement(ctx context.Context, bookingID string) (*model.DepositStatementModel, error) {
+	booking, err := s.findBooking(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	return s.statement(booking)
+}
+
+/*
+Metode untuk memutuskan potongan deposit yang disengketakan oleh admin.
+Potongan dipertahankan atau dibatalkan beserta catatan keputusan, sehingga deposit dapat dikembalikan meskipun hoster dan customer tidak sepakat.
+*/
+func (s *depositService) ResolveDispute(ctx context.Context, bookingID, id, outcome, note string) error {
+	adminID, err := userFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	booking, err := s.findBooking(bookingID)
+	if err != nil {
+		return err
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return errors.New(message.MsgDepositDeductionIDRequired)
+	}
+	status, ok := resolutionStatuses[strings.TrimSpace(outcome)]
+	if !ok {
+		return errors.New(message.MsgDepositResolutionInvalid)
+	}
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return errors.New(message.MsgDepositResolutionNoteRequired)
+	}
+
+	resolved, err := s.repo.ResolveDispute(booking.ID, id, status, note, adminID)
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		return errors.New(message.MsgDepositDeductionNotFound)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk mencatat tanggapan customer atas potongan deposit.
+Hanya potongan dengan salah satu status asal pada booking milik customer yang deposit-nya belum dikembalikan yang dapat ditanggapi.
+*/
+func (s *depositService) respond(ctx context.Context, bookingID, id string, from []string, status, note string) error {
+	booking, err := s.customerBooking(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return errors.New(message.MsgDepositDeductionIDRequired)
+	}
+	updated, err := s.repo.UpdateDeductionStatus(booking.ID, id, from, status, note)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New(message.MsgDepositDeductionNotFound)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk menyusun laporan deposit booking.
+Total dari buku besar dilengkapi status booking dan sisa saldo yang masih ditahan.
+*/
+func (s *depositService) statement(booking *model.BookingModel) (*model.DepositStatementModel, error) {
+	statement, err := s.repo.GetStatement(booking.ID)
+	if err != nil {
+		return nil, err
+	}
+	statement.BookingID = booking.ID
+	statement.BookingStatus = booking.Status
+	statement.Balance = statement.Held - statement.Deducted - statement.Released
+	return statement, nil
+}
+
+/*
+Metode untuk mengambil booking milik customer yang sedang login.
+Booking milik customer lain diperlakukan sebagai tidak ditemukan.
+*/
+func (s *depositService) customerBooking(ctx context.Context, bookingID string) (*model.BookingModel, error) {
+	customerID, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := s.findBooking(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.CustomerID != customerID {
+		return nil, errors.New(message.MsgBookingNotFound)
+	}
+	return booking, nil
+}
+
+/*
+Metode untuk mengambil booking milik toko yang sedang login.
+Booking toko lain diperlakukan sebagai tidak ditemukan.
+*/
+func (s *depositService) storeBooking(ctx context.Context, bookingID string) (*model.BookingModel, error) {
+	claims := middleware.GetClaims(ctx)
+	if claims == nil || claims.Subject == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	booking, err := s.findBooking(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.StoreID != claims.StoreID() {
+		return nil, errors.New(message.MsgBookingNotFound)
+	}
+	return booking, nil
+}
+
+/*
+Metode untuk mencari booking berdasarkan ID.
+Booking dikembalikan, atau error jika ID kosong atau booking tidak ditemukan.
+*/
+func (s *depositService) findBooking(bookingID string) (*model.BookingModel, error) {
+	bookingID = strings.TrimSpace(bookingID)
+	if bookingID == "" {
+		return nil, errors.New(message.MsgBookingIDRequired)
+	}
+	booking, err := s.repo.FindBookingByID(bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking == nil {
+		return nil, errors.New(message.MsgBookingNotFound)
+	}
+	return booking, nil
+}
+
+/*
+Interface untuk operasi layanan deposit.
+Interface ini mendefinisikan metode untuk laporan, potongan, pengembalian, tanggapan, dan keputusan sengketa deposit.
+*/
+type DepositService interface {
+	GetCustomerStatement(ctx context.Context, bookingID string) (*model.DepositStatementModel, error)
+	GetStoreStatement(ctx context.Context, bookingID string) (*model.DepositStatementModel, error)
+	CreateDeduction(ctx context.Context, bookingID string, input *model.DepositEntryModel) (*model.DepositEntryModel, error)
+	WithdrawDeduction(ctx context.Context, bookingID, id string) error
+	Release(ctx context.Context, bookingID string) (*model.DepositStatementModel, error)
+	AcknowledgeDeduction(ctx context.Context, bookingID, id string) error
+	DisputeDeduction(ctx context.Context, bookingID, id, note string) error
+	GetAdminStatement(ctx context.Context, bookingID string) (*model.DepositStatementModel, error)
+	ResolveDispute(ctx context.Context, bookingID, id, outcome, note string) error
+}
+
+/*
+Fungsi untuk merapikan daftar foto bukti potongan.
+URL kosong dibuang, lalu error dikembalikan jika jumlah foto melebihi batas atau ada URL yang bukan http atau https.
+*/
+func cleanPhotos(photos []string) (pq.StringArray, error) {
+	cleaned := pq.StringArray{}
+	for _, photo := range photos {
+		photo = strings.TrimSpace(photo)
+		if photo == "" {
+			continue
+		}
+		u, err := url.Parse(photo)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errors.New(message.MsgDepositDeductionPhotosInvalid)
+		}
+		cleaned = append(cleaned, photo)
+	}
+	if len(cleaned) > maxDeductionPhotos {
+		return nil, errors.New(message.MsgDepositDeductionPhotosInvalid)
+	}
+	return cleaned, nil
+}
+
+/*
+Fungsi untuk mengambil ID pengguna dari konteks.
+ID pengguna dikembalikan, atau error jika klaim token tidak valid.
+*/
+func userFromContext(ctx context.Context) (string, error) {
+	claims := middleware.GetClaims(ctx)
+	if claims == nil || claims.Subject == "" {
+		return "", errors.New("invalid token claims")
+	}
+	return claims.Subject, nil
+}
+
+/*
+Fungsi untuk membuat instance baru dari DepositService.
+Instance layanan dikembalikan.
+*/
+func NewDepositService(repo DepositRepository) DepositService {
+	return &depositService{repo: repo}
+}
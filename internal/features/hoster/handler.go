@@ -2,6 +2,8 @@ package hoster
 
 import (
 	"encoding/json"
+	"errors"
+	"lalan-be/internal/auth"
 	"lalan-be/internal/model"
 	"lalan-be/internal/response"
 	"lalan-be/pkg/message"
@@ -48,113 +50,1051 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+/*
+Struktur untuk permintaan callback login OpenID Connect.
+Struktur ini berisi state dan kode otorisasi yang diterima frontend dari penyedia.
+*/
+type OIDCCallbackRequest struct {
+	State string `json:"state"`
+	Code  string `json:"code"`
+}
+
+/*
+Struktur untuk permintaan verifikasi MFA saat login.
+Struktur ini berisi token tantangan dan kode OTP atau kode pemulihan.
+*/
+type MFAVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+/*
+Struktur untuk permintaan yang berisi kode MFA.
+Struktur ini dipakai untuk konfirmasi, penonaktifan, dan pembuatan ulang kode pemulihan.
+*/
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+/*
+Struktur untuk permintaan refresh token hoster.
+Struktur ini berisi refresh token yang akan dirotasi atau dicabut.
+*/
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+/*
+Struktur untuk permintaan lupa password hoster.
+Struktur ini berisi email akun yang ingin dipulihkan.
+*/
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+/*
+Struktur untuk permintaan reset password hoster.
+Struktur ini berisi token reset dan password baru.
+*/
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+/*
+Struktur untuk permintaan ganti password hoster.
+Struktur ini berisi password saat ini dan password baru.
+*/
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+/*
+Struktur untuk permintaan penghapusan akun hoster.
+Struktur ini berisi password untuk konfirmasi ulang.
+*/
+type AccountDeletionRequest struct {
+	Password string `json:"password"`
+}
+
+/*
+Struktur untuk permintaan verifikasi email hoster.
+Struktur ini berisi token dari tautan verifikasi.
+*/
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+/*
+Struktur untuk permintaan undangan staf toko.
+Struktur ini berisi email, nama, dan role staf yang diundang.
+*/
+type StaffInviteRequest struct {
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	Role     string `json:"role"`
+}
+
+/*
+Struktur untuk permintaan penerimaan undangan staf.
+Struktur ini berisi token undangan, nama lengkap, dan password staf.
+*/
+type StaffAcceptRequest struct {
+	Token    string `json:"token"`
+	FullName string `json:"full_name"`
+	Password string `json:"password"`
+}
+
+/*
+Struktur untuk permintaan perubahan role staf.
+Struktur ini berisi role baru staf di toko.
+*/
+type StaffRoleRequest struct {
+	Role string `json:"role"`
+}
+
+/*
+Struktur untuk permintaan pembuatan API key.
+Struktur ini berisi nama API key dan scope yang diberikan.
+*/
+type APIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
 /*
 Metode untuk membuat hoster baru.
 Metode ini memvalidasi input dan membuat hoster melalui layanan.
 */
-func (h *HosterHandler) CreateHoster(w http.ResponseWriter, r *http.Request) {
-	log.Printf("CreateHoster: received request")
+func (h *HosterHandler) CreateHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CreateHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req HosterRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("CreateHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.FullName) == "" {
+		log.Printf("CreateAdmin: full name required")
+		response.BadRequest(w, "Full name is required")
+		return
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		log.Printf("CreateAdmin: email required")
+		response.BadRequest(w, "Email is required")
+		return
+	}
+	if strings.TrimSpace(req.Password) == "" {
+		log.Printf("CreateAdmin: password required")
+		response.BadRequest(w, "Password is required")
+		return
+	}
+	input := &model.HosterModel{
+		FullName:     req.FullName,
+		StoreName:    req.StoreName,
+		PhoneNumber:  req.PhoneNumber,
+		Email:        req.Email,
+		PasswordHash: req.Password,
+		Address:      req.Address,
+		ProfilePhoto: req.ProfilePhoto,
+		Description:  req.Description,
+		Tiktok:       req.Tiktok,
+		Instagram:    req.Instagram,
+		Website:      req.Website,
+	}
+	err := h.service.CreateHoster(input)
+	if err != nil {
+		log.Printf("CreateHoster: error creating hoster: %v", err)
+		response.BadRequest(w, err.Error())
+		return
+	}
+	response.Created(w, input, message.MsgSuccess)
+}
+
+/*
+Metode untuk login hoster.
+Metode ini memvalidasi kredensial dan mengembalikan token autentikasi.
+*/
+func (h *HosterHandler) LoginHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("LoginHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req LoginRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("LoginHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if req.Email == "" || req.Password == "" {
+		log.Printf("LoginHoster: email or password empty")
+		response.Error(w, http.StatusBadRequest, "Email and password are required")
+		return
+	}
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	if !emailRegex.MatchString(req.Email) {
+		log.Printf("LoginHoster: invalid email format: %s", req.Email)
+		response.Error(w, http.StatusBadRequest, "Invalid email format")
+		return
+	}
+	resp, err := h.service.LoginHoster(req.Email, req.Password, auth.ClientInfoFromRequest(r))
+	if err != nil {
+		log.Printf("LoginHoster: login failed: %v", err)
+		var blocked *auth.LoginBlockedError
+		if errors.As(err, &blocked) {
+			if blocked.Locked {
+				response.Locked(w, blocked.Error(), blocked.RetryAfter)
+				return
+			}
+			response.TooManyRequests(w, blocked.Error(), blocked.RetryAfter)
+			return
+		}
+		response.Error(w, http.StatusUnauthorized, "Invalid credentials")
+		return
+	}
+	// Login dengan MFA aktif menunggu kode OTP
+	if resp.MFARequired {
+		log.Printf("LoginHoster: MFA challenge issued for email %s", req.Email)
+		response.OK(w, map[string]interface{}{
+			"id":              resp.ID,
+			"mfa_required":    true,
+			"challenge_token": resp.ChallengeToken,
+			"expires_in":      resp.ExpiresIn,
+		}, message.MsgMFARequired)
+		return
+	}
+	log.Printf("LoginHoster: login successful for email %s", req.Email)
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+	userData := map[string]interface{}{
+		"id":            resp.ID,
+		"access_token":  resp.AccessToken,
+		"refresh_token": resp.RefreshToken,
+		"token_type":    resp.TokenType,
+		"expires_in":    resp.ExpiresIn,
+		"store_id":      resp.StoreID,
+		"store_role":    resp.StoreRole,
+	}
+	response.Success(w, 200, userData, "Login successful")
+}
+
+/*
+Metode untuk menyelesaikan login hoster dengan kode MFA.
+Metode ini menukar token tantangan dan kode valid dengan pasangan token.
+*/
+func (h *HosterHandler) VerifyMFAHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("VerifyMFAHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req MFAVerifyRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("VerifyMFAHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.ChallengeToken) == "" {
+		response.BadRequest(w, message.MsgMFAChallengeRequired)
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, message.MsgMFACodeRequired)
+		return
+	}
+
+	resp, err := h.service.VerifyMFAHoster(req.ChallengeToken, req.Code, auth.ClientInfoFromRequest(r))
+	if err != nil {
+		log.Printf("VerifyMFAHoster: verification failed: %v", err)
+		var blocked *auth.LoginBlockedError
+		if errors.As(err, &blocked) {
+			if blocked.Locked {
+				response.Locked(w, blocked.Error(), blocked.RetryAfter)
+				return
+			}
+			response.TooManyRequests(w, blocked.Error(), blocked.RetryAfter)
+			return
+		}
+		if errors.Is(err, auth.ErrMFAChallengeInvalid) || errors.Is(err, auth.ErrMFACodeInvalid) || errors.Is(err, auth.ErrMFANotEnabled) {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+	userData := map[string]interface{}{
+		"id":            resp.ID,
+		"access_token":  resp.AccessToken,
+		"refresh_token": resp.RefreshToken,
+		"token_type":    resp.TokenType,
+		"expires_in":    resp.ExpiresIn,
+		"store_id":      resp.StoreID,
+		"store_role":    resp.StoreRole,
+	}
+	response.Success(w, 200, userData, "Login successful")
+}
+
+/*
+Metode untuk memulai pendaftaran MFA hoster.
+Metode ini mengembalikan secret dan URI provisioning untuk kode QR.
+*/
+func (h *HosterHandler) EnrollMFAHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("EnrollMFAHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	enrollment, err := h.service.EnrollMFAHoster(r.Context())
+	if err != nil {
+		log.Printf("EnrollMFAHoster: error: %v", err)
+		if errors.Is(err, auth.ErrMFAAlreadyEnabled) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, enrollment, message.MsgMFAEnrollmentStarted)
+}
+
+/*
+Metode untuk mengonfirmasi pendaftaran MFA hoster.
+Metode ini mengaktifkan MFA dan mengembalikan kode pemulihan beserta token baru.
+*/
+func (h *HosterHandler) ConfirmMFAHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ConfirmMFAHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req MFACodeRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ConfirmMFAHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, message.MsgMFACodeRequired)
+		return
+	}
+
+	codes, resp, err := h.service.ConfirmMFAHoster(r.Context(), req.Code, auth.ClientInfoFromRequest(r))
+	if err != nil {
+		log.Printf("ConfirmMFAHoster: error: %v", err)
+		if errors.Is(err, auth.ErrMFACodeInvalid) || errors.Is(err, auth.ErrMFANotPending) || errors.Is(err, auth.ErrMFAAlreadyEnabled) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+	data := map[string]interface{}{
+		"recovery_codes": codes,
+		"id":             resp.ID,
+		"access_token":   resp.AccessToken,
+		"refresh_token":  resp.RefreshToken,
+		"token_type":     resp.TokenType,
+		"expires_in":     resp.ExpiresIn,
+	}
+	response.OK(w, data, message.MsgMFAEnabled)
+}
+
+/*
+Metode untuk menonaktifkan MFA hoster.
+Metode ini memerlukan kode OTP atau kode pemulihan yang valid.
+*/
+func (h *HosterHandler) DisableMFAHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("DisableMFAHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req MFACodeRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("DisableMFAHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, message.MsgMFACodeRequired)
+		return
+	}
+
+	if err := h.service.DisableMFAHoster(r.Context(), req.Code); err != nil {
+		log.Printf("DisableMFAHoster: error: %v", err)
+		if errors.Is(err, auth.ErrMFACodeInvalid) || errors.Is(err, auth.ErrMFANotEnabled) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgMFARequiredByPolicy {
+			response.Forbidden(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, nil, message.MsgMFADisabled)
+}
+
+/*
+Metode untuk membuat ulang kode pemulihan MFA hoster.
+Metode ini mengembalikan kode pemulihan baru jika kode OTP valid.
+*/
+func (h *HosterHandler) RegenerateRecoveryCodesHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RegenerateRecoveryCodesHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req MFACodeRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("RegenerateRecoveryCodesHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, message.MsgMFACodeRequired)
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodesHoster(r.Context(), req.Code)
+	if err != nil {
+		log.Printf("RegenerateRecoveryCodesHoster: error: %v", err)
+		if errors.Is(err, auth.ErrMFACodeInvalid) || errors.Is(err, auth.ErrMFANotEnabled) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, map[string]interface{}{"recovery_codes": codes}, message.MsgMFARecoveryCodes)
+}
+
+/*
+Metode untuk merotasi refresh token hoster.
+Metode ini mengembalikan pasangan token baru jika refresh token valid.
+*/
+func (h *HosterHandler) RefreshTokenHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RefreshTokenHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req RefreshRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("RefreshTokenHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		response.BadRequest(w, message.MsgRefreshTokenRequired)
+		return
+	}
+	resp, err := h.service.RefreshTokenHoster(req.RefreshToken)
+	if err != nil {
+		log.Printf("RefreshTokenHoster: refresh failed: %v", err)
+		if errors.Is(err, auth.ErrRefreshTokenInvalid) || errors.Is(err, auth.ErrRefreshTokenExpired) || errors.Is(err, auth.ErrRefreshTokenReused) {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+	userData := map[string]interface{}{
+		"id":            resp.ID,
+		"access_token":  resp.AccessToken,
+		"refresh_token": resp.RefreshToken,
+		"token_type":    resp.TokenType,
+		"expires_in":    resp.ExpiresIn,
+		"store_id":      resp.StoreID,
+		"store_role":    resp.StoreRole,
+	}
+	response.OK(w, userData, message.MsgTokenRefreshed)
+}
+
+/*
+Metode untuk logout hoster.
+Metode ini mencabut refresh token dan menghapus cookie autentikasi.
+*/
+func (h *HosterHandler) LogoutHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("LogoutHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req RefreshRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("LogoutHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		response.BadRequest(w, message.MsgRefreshTokenRequired)
+		return
+	}
+	if err := h.service.LogoutHoster(r.Context(), req.RefreshToken); err != nil {
+		log.Printf("LogoutHoster: logout failed: %v", err)
+		if errors.Is(err, auth.ErrRefreshTokenInvalid) || errors.Is(err, auth.ErrRefreshTokenExpired) || errors.Is(err, auth.ErrRefreshTokenReused) {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+	response.OK(w, nil, message.MsgLogoutSuccess)
+}
+
+/*
+Metode untuk logout hoster dari semua perangkat.
+Metode ini mencabut seluruh token milik hoster yang sedang login.
+*/
+func (h *HosterHandler) LogoutAllHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("LogoutAllHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	if err := h.service.LogoutAllHoster(r.Context()); err != nil {
+		log.Printf("LogoutAllHoster: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+	response.OK(w, nil, message.MsgLogoutAllSuccess)
+}
+
+/*
+Metode untuk mengganti password hoster yang sedang login.
+Metode ini memverifikasi password lama dan mengeluarkan hoster dari semua perangkat setelah berhasil.
+*/
+func (h *HosterHandler) ChangePasswordHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ChangePasswordHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req ChangePasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ChangePasswordHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if req.CurrentPassword == "" {
+		response.BadRequest(w, message.MsgPasswordCurrentRequired)
+		return
+	}
+	if strings.TrimSpace(req.NewPassword) == "" {
+		response.BadRequest(w, message.MsgPasswordRequired)
+		return
+	}
+	if err := h.service.ChangePasswordHoster(r.Context(), req.CurrentPassword, req.NewPassword); err != nil {
+		log.Printf("ChangePasswordHoster: error: %v", err)
+		var weak *auth.PasswordPolicyError
+		if errors.As(err, &weak) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgPasswordCurrentRequired || err.Error() == message.MsgPasswordCurrentInvalid || err.Error() == message.MsgPasswordUnchanged {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgUnauthorized {
+			response.Unauthorized(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   -1,
+	})
+	response.OK(w, nil, message.MsgPasswordChanged)
+}
+
+/*
+Metode untuk mengambil daftar sesi login hoster.
+Sesi aktif beserta perangkat dan waktu terakhir digunakan dikembalikan.
+*/
+func (h *HosterHandler) GetSessionsHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetSessionsHoster: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	sessions, err := h.service.GetSessionsHoster(r.Context())
+	if err != nil {
+		log.Printf("GetSessionsHoster: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, sessions, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengakhiri sesi login hoster tertentu.
+Sesi dicabut sehingga perangkat terkait harus login ulang.
+*/
+func (h *HosterHandler) TerminateSessionHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("TerminateSessionHoster: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgSessionIDRequired)
+		return
+	}
+
+	if err := h.service.TerminateSessionHoster(r.Context(), id); err != nil {
+		log.Printf("TerminateSessionHoster: error: %v", err)
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, nil, message.MsgSessionTerminated)
+}
+
+/*
+Metode untuk mengekspor data pribadi hoster.
+Data dikembalikan sebagai JSON, atau sebagai arsip ZIP jika format=zip.
+*/
+func (h *HosterHandler) ExportHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ExportHoster: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "zip" {
+		response.BadRequest(w, message.MsgAccountExportFormatInvalid)
+		return
+	}
+
+	export, err := h.service.ExportHoster(r.Context())
+	if err != nil {
+		log.Printf("ExportHoster: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	if format == "zip" {
+		if err := response.Archive(w, "lalan-hoster-export.zip", "hoster.json", export); err != nil {
+			log.Printf("ExportHoster: error writing archive: %v", err)
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+	response.OK(w, export, message.MsgAccountExported)
+}
+
+/*
+Metode untuk menjadwalkan penghapusan akun hoster.
+Akun dianonimkan setelah masa tenggang kecuali penghapusan dibatalkan.
+*/
+func (h *HosterHandler) RequestDeletionHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RequestDeletionHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req AccountDeletionRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("RequestDeletionHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if req.Password == "" {
+		response.BadRequest(w, message.MsgPasswordRequired)
+		return
+	}
+
+	deletion, err := h.service.RequestDeletionHoster(r.Context(), req.Password)
+	if err != nil {
+		log.Printf("RequestDeletionHoster: error: %v", err)
+		if err.Error() == message.MsgPasswordCurrentInvalid || errors.Is(err, auth.ErrAccountDeletionPending) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgAccountDeletionOwnerOnly {
+			response.Forbidden(w, err.Error())
+			return
+		}
+		if err.Error() == message.MsgAccountDeletionActiveRentals {
+			response.Conflict(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.Created(w, deletion, message.MsgAccountDeletionScheduled)
+}
+
+/*
+Metode untuk mengambil jadwal penghapusan akun hoster.
+Tanggal penghapusan yang masih menunggu dikembalikan.
+*/
+func (h *HosterHandler) GetDeletionHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetDeletionHoster: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	deletion, err := h.service.GetDeletionHoster(r.Context())
+	if err != nil {
+		log.Printf("GetDeletionHoster: error: %v", err)
+		if errors.Is(err, auth.ErrAccountDeletionNotFound) {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, deletion, message.MsgSuccess)
+}
+
+/*
+Metode untuk membatalkan penghapusan akun hoster.
+Akun tetap aktif jika dibatalkan sebelum masa tenggang berakhir.
+*/
+func (h *HosterHandler) CancelDeletionHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CancelDeletionHoster: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	if err := h.service.CancelDeletionHoster(r.Context()); err != nil {
+		log.Printf("CancelDeletionHoster: error: %v", err)
+		if errors.Is(err, auth.ErrAccountDeletionNotFound) {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, nil, message.MsgAccountDeletionCancelled)
+}
+
+/*
+Metode untuk memulai login hoster melalui penyedia OpenID Connect.
+URL otorisasi penyedia dikembalikan untuk dibuka oleh frontend.
+*/
+func (h *HosterHandler) OIDCAuthorizeHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("OIDCAuthorizeHoster: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	authorizationURL, err := h.service.OIDCAuthorizeHoster()
+	if err != nil {
+		log.Printf("OIDCAuthorizeHoster: error: %v", err)
+		switch {
+		case errors.Is(err, auth.ErrOIDCDisabled):
+			response.Error(w, http.StatusNotImplemented, err.Error())
+		case errors.Is(err, auth.ErrOIDCExchangeFailed):
+			response.Error(w, http.StatusBadGateway, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	response.OK(w, map[string]interface{}{"authorization_url": authorizationURL}, message.MsgSuccess)
+}
+
+/*
+Metode untuk menyelesaikan login hoster melalui penyedia OpenID Connect.
+Kode otorisasi ditukar dan pasangan token hoster dikembalikan.
+*/
+func (h *HosterHandler) OIDCCallbackHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("OIDCCallbackHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	var req OIDCCallbackRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("OIDCCallbackHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.State) == "" || strings.TrimSpace(req.Code) == "" {
+		response.BadRequest(w, message.MsgOIDCCodeRequired)
+		return
+	}
+
+	resp, err := h.service.LoginOIDCHoster(req.State, req.Code, auth.ClientInfoFromRequest(r))
+	if err != nil {
+		log.Printf("OIDCCallbackHoster: error: %v", err)
+		switch {
+		case errors.Is(err, auth.ErrOIDCDisabled):
+			response.Error(w, http.StatusNotImplemented, err.Error())
+		case errors.Is(err, auth.ErrOIDCStateInvalid), errors.Is(err, auth.ErrOIDCEmailUnverified), err.Error() == message.MsgHosterEmailExists:
+			response.BadRequest(w, err.Error())
+		case errors.Is(err, auth.ErrOIDCAccountUnverified):
+			response.Conflict(w, err.Error())
+		case errors.Is(err, auth.ErrOIDCExchangeFailed):
+			response.Unauthorized(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+	// Login dengan MFA aktif menunggu kode OTP
+	if resp.MFARequired {
+		response.OK(w, map[string]interface{}{
+			"id":              resp.ID,
+			"mfa_required":    true,
+			"challenge_token": resp.ChallengeToken,
+			"expires_in":      resp.ExpiresIn,
+		}, message.MsgMFARequired)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    resp.AccessToken,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		MaxAge:   3600,
+	})
+	userData := map[string]interface{}{
+		"id":            resp.ID,
+		"access_token":  resp.AccessToken,
+		"refresh_token": resp.RefreshToken,
+		"token_type":    resp.TokenType,
+		"expires_in":    resp.ExpiresIn,
+		"store_id":      resp.StoreID,
+		"store_role":    resp.StoreRole,
+	}
+	response.Success(w, http.StatusOK, userData, message.MsgOIDCLoginSuccess)
+}
+
+/*
+Metode untuk meminta tautan reset password hoster.
+Metode ini selalu mengembalikan respons yang sama agar email terdaftar tidak bocor.
+*/
+func (h *HosterHandler) ForgotPasswordHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ForgotPasswordHoster: received request")
 	if r.Method != http.MethodPost {
 		response.BadRequest(w, message.MsgNotAllowed)
 		return
 	}
-	var req HosterRequest
+	var req ForgotPasswordRequest
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&req); err != nil {
-		log.Printf("CreateHoster: invalid JSON: %v", err)
+		log.Printf("ForgotPasswordHoster: invalid JSON: %v", err)
 		response.BadRequest(w, message.MsgBadRequest)
 		return
 	}
-	if strings.TrimSpace(req.FullName) == "" {
-		log.Printf("CreateAdmin: full name required")
-		response.BadRequest(w, "Full name is required")
+	if strings.TrimSpace(req.Email) == "" {
+		response.BadRequest(w, message.MsgEmailRequired)
 		return
 	}
-	if strings.TrimSpace(req.Email) == "" {
-		log.Printf("CreateAdmin: email required")
-		response.BadRequest(w, "Email is required")
+	if err := h.service.ForgotPasswordHoster(req.Email); err != nil {
+		log.Printf("ForgotPasswordHoster: error: %v", err)
+	}
+	response.OK(w, nil, message.MsgPasswordResetRequested)
+}
+
+/*
+Metode untuk mengatur ulang password hoster.
+Metode ini memvalidasi token reset dan menyimpan password baru.
+*/
+func (h *HosterHandler) ResetPasswordHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ResetPasswordHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
 		return
 	}
-	if strings.TrimSpace(req.Password) == "" {
-		log.Printf("CreateAdmin: password required")
-		response.BadRequest(w, "Password is required")
+	var req ResetPasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ResetPasswordHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
 		return
 	}
-	input := &model.HosterModel{
-		FullName:     req.FullName,
-		StoreName:    req.StoreName,
-		PhoneNumber:  req.PhoneNumber,
-		Email:        req.Email,
-		PasswordHash: req.Password,
-		Address:      req.Address,
-		ProfilePhoto: req.ProfilePhoto,
-		Description:  req.Description,
-		Tiktok:       req.Tiktok,
-		Instagram:    req.Instagram,
-		Website:      req.Website,
+	if strings.TrimSpace(req.Token) == "" {
+		response.BadRequest(w, message.MsgPasswordResetTokenMissing)
+		return
 	}
-	err := h.service.CreateHoster(input)
-	if err != nil {
-		log.Printf("CreateHoster: error creating hoster: %v", err)
-		response.BadRequest(w, err.Error())
+	if strings.TrimSpace(req.Password) == "" {
+		response.BadRequest(w, message.MsgPasswordRequired)
 		return
 	}
-	response.Created(w, input, message.MsgSuccess)
+	if err := h.service.ResetPasswordHoster(req.Token, req.Password); err != nil {
+		log.Printf("ResetPasswordHoster: error: %v", err)
+		var weak *auth.PasswordPolicyError
+		if errors.Is(err, auth.ErrResetTokenInvalid) || errors.As(err, &weak) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+	response.OK(w, nil, message.MsgPasswordResetSuccess)
 }
 
 /*
-Metode untuk login hoster.
-Metode ini memvalidasi kredensial dan mengembalikan token autentikasi.
+Metode untuk memverifikasi email hoster.
+Metode ini memvalidasi token dari tautan verifikasi.
 */
-func (h *HosterHandler) LoginHoster(w http.ResponseWriter, r *http.Request) {
-	log.Printf("LoginHoster: received request")
+func (h *HosterHandler) VerifyEmailHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("VerifyEmailHoster: received request")
 	if r.Method != http.MethodPost {
 		response.BadRequest(w, message.MsgNotAllowed)
 		return
 	}
-	var req LoginRequest
+	var req VerifyEmailRequest
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&req); err != nil {
-		log.Printf("LoginHoster: invalid JSON: %v", err)
+		log.Printf("VerifyEmailHoster: invalid JSON: %v", err)
 		response.BadRequest(w, message.MsgBadRequest)
 		return
 	}
-	if req.Email == "" || req.Password == "" {
-		log.Printf("LoginHoster: email or password empty")
-		response.Error(w, http.StatusBadRequest, "Email and password are required")
+	if strings.TrimSpace(req.Token) == "" {
+		response.BadRequest(w, message.MsgEmailVerificationMissing)
 		return
 	}
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	if !emailRegex.MatchString(req.Email) {
-		log.Printf("LoginHoster: invalid email format: %s", req.Email)
-		response.Error(w, http.StatusBadRequest, "Invalid email format")
+	if err := h.service.VerifyEmailHoster(req.Token); err != nil {
+		log.Printf("VerifyEmailHoster: error: %v", err)
+		if errors.Is(err, auth.ErrVerificationTokenInvalid) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
 		return
 	}
-	resp, err := h.service.LoginHoster(req.Email, req.Password)
-	if err != nil {
-		log.Printf("LoginHoster: login failed: %v", err)
-		response.Error(w, http.StatusUnauthorized, "Invalid credentials")
+	response.OK(w, nil, message.MsgEmailVerified)
+}
+
+/*
+Metode untuk mengirim ulang email verifikasi hoster.
+Metode ini selalu mengembalikan respons yang sama agar status akun tidak bocor.
+*/
+func (h *HosterHandler) ResendVerificationHoster(w http.ResponseWriter, r *http.Request) {
+	log.Printf("ResendVerificationHoster: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
 		return
 	}
-	log.Printf("LoginHoster: login successful for email %s", req.Email)
-	http.SetCookie(w, &http.Cookie{
-		Name:     "auth_token",
-		Value:    resp.AccessToken,
-		HttpOnly: true,
-		Secure:   true,
-		Path:     "/",
-		MaxAge:   3600,
-	})
-	userData := map[string]interface{}{
-		"id":            resp.ID,
-		"access_token":  resp.AccessToken,
-		"refresh_token": resp.RefreshToken,
-		"token_type":    resp.TokenType,
-		"expires_in":    resp.ExpiresIn,
+	var req ForgotPasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("ResendVerificationHoster: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
 	}
-	response.Success(w, 200, userData, "Login successful")
+	if strings.TrimSpace(req.Email) == "" {
+		response.BadRequest(w, message.MsgEmailRequired)
+		return
+	}
+	if err := h.service.ResendVerificationHoster(req.Email); err != nil {
+		log.Printf("ResendVerificationHoster: error: %v", err)
+	}
+	response.OK(w, nil, message.MsgEmailVerificationSent)
 }
 
 /*
@@ -205,6 +1145,10 @@ func (h *HosterHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 	item, err := h.service.CreateItem(ctx, &req)
 	if err != nil {
 		log.Printf("CreateItem: error creating item: %v", err)
+		if err.Error() == message.MsgEmailNotVerified {
+			response.Forbidden(w, err.Error())
+			return
+		}
 		response.BadRequest(w, err.Error())
 		return
 	}
@@ -283,6 +1227,10 @@ func (h *HosterHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 	item, err := h.service.UpdateItem(ctx, id, &req)
 	if err != nil {
 		log.Printf("UpdateItem: error: %v", err)
+		if err.Error() == message.MsgStoreAccessDenied {
+			response.Forbidden(w, err.Error())
+			return
+		}
 		response.BadRequest(w, err.Error())
 		return
 	}
@@ -309,6 +1257,10 @@ func (h *HosterHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 	err := h.service.DeleteItem(ctx, id)
 	if err != nil {
 		log.Printf("DeleteItem: error: %v", err)
+		if err.Error() == message.MsgStoreAccessDenied {
+			response.Forbidden(w, err.Error())
+			return
+		}
 		response.BadRequest(w, err.Error())
 		return
 	}
@@ -414,6 +1366,10 @@ func (h *HosterHandler) UpdateTermsAndConditions(w http.ResponseWriter, r *http.
 	tac, err := h.service.UpdateTermsAndConditions(ctx, id, &req)
 	if err != nil {
 		log.Printf("UpdateTermsAndConditions: error: %v", err)
+		if err.Error() == message.MsgStoreAccessDenied {
+			response.Forbidden(w, err.Error())
+			return
+		}
 		response.BadRequest(w, err.Error())
 		return
 	}
@@ -439,12 +1395,292 @@ func (h *HosterHandler) DeleteTermsAndConditions(w http.ResponseWriter, r *http.
 	err := h.service.DeleteTermsAndConditions(ctx, id)
 	if err != nil {
 		log.Printf("DeleteTermsAndConditions: error: %v", err)
+		if err.Error() == message.MsgStoreAccessDenied {
+			response.Forbidden(w, err.Error())
+			return
+		}
 		response.BadRequest(w, err.Error())
 		return
 	}
 	response.OK(w, nil, "Terms and conditions deleted successfully")
 }
 
+/*
+Metode untuk mengundang staf ke toko.
+Metode ini memvalidasi email dan role lalu mengirim undangan melalui layanan.
+*/
+func (h *HosterHandler) InviteStaff(w http.ResponseWriter, r *http.Request) {
+	log.Printf("InviteStaff: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req StaffInviteRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("InviteStaff: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	// Validasi email
+	if strings.TrimSpace(req.Email) == "" {
+		response.BadRequest(w, message.MsgEmailRequired)
+		return
+	}
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	if !emailRegex.MatchString(strings.TrimSpace(req.Email)) {
+		response.BadRequest(w, "Invalid email format")
+		return
+	}
+
+	staff, err := h.service.InviteStaff(r.Context(), req.Email, req.FullName, req.Role)
+	if err != nil {
+		log.Printf("InviteStaff: error: %v", err)
+		switch err.Error() {
+		case message.MsgStaffOwnerOnly:
+			response.Forbidden(w, err.Error())
+		case message.MsgStaffRoleInvalid, message.MsgStaffEmailExists:
+			response.BadRequest(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	log.Printf("InviteStaff: invited %s to store %s", staff.Email, staff.StoreID)
+	response.Created(w, staff, message.MsgStaffInvited)
+}
+
+/*
+Metode untuk menerima undangan staf toko.
+Metode ini mengaktifkan akun staf dengan password yang dipilih sendiri.
+*/
+func (h *HosterHandler) AcceptStaffInvitation(w http.ResponseWriter, r *http.Request) {
+	log.Printf("AcceptStaffInvitation: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	var req StaffAcceptRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("AcceptStaffInvitation: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	// Validasi input
+	if strings.TrimSpace(req.Token) == "" {
+		response.BadRequest(w, message.MsgAdminInvitationMissing)
+		return
+	}
+	if strings.TrimSpace(req.Password) == "" {
+		response.BadRequest(w, message.MsgPasswordRequired)
+		return
+	}
+
+	staff, err := h.service.AcceptStaffInvitation(req.Token, req.FullName, req.Password)
+	if err != nil {
+		log.Printf("AcceptStaffInvitation: error: %v", err)
+		var weak *auth.PasswordPolicyError
+		if err.Error() == message.MsgStaffInvitationInvalid || errors.As(err, &weak) {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.Created(w, staff, message.MsgStaffInvitationAccept)
+}
+
+/*
+Metode untuk mengambil daftar staf toko.
+Daftar staf aktif dan undangan yang menunggu dikembalikan.
+*/
+func (h *HosterHandler) GetStaff(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetStaff: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	staff, err := h.service.GetStaff(r.Context())
+	if err != nil {
+		log.Printf("GetStaff: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, staff, message.MsgSuccess)
+}
+
+/*
+Metode untuk mengubah role staf toko.
+Metode ini memperbarui role staf berdasarkan ID melalui layanan.
+*/
+func (h *HosterHandler) UpdateStaffRole(w http.ResponseWriter, r *http.Request) {
+	log.Printf("UpdateStaffRole: received request")
+	if r.Method != http.MethodPut {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgStaffIDRequired)
+		return
+	}
+
+	var req StaffRoleRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("UpdateStaffRole: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	if err := h.service.UpdateStaffRole(r.Context(), id, req.Role); err != nil {
+		log.Printf("UpdateStaffRole: error: %v", err)
+		switch err.Error() {
+		case message.MsgStaffOwnerOnly:
+			response.Forbidden(w, err.Error())
+		case message.MsgStaffNotFound:
+			response.Error(w, http.StatusNotFound, err.Error())
+		case message.MsgStaffIDRequired, message.MsgStaffRoleInvalid:
+			response.BadRequest(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	response.OK(w, nil, message.MsgStaffUpdated)
+}
+
+/*
+Metode untuk menghapus staf dari toko.
+Metode ini menghapus akun staf berdasarkan ID dan mencabut sesinya.
+*/
+func (h *HosterHandler) RemoveStaff(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RemoveStaff: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgStaffIDRequired)
+		return
+	}
+
+	if err := h.service.RemoveStaff(r.Context(), id); err != nil {
+		log.Printf("RemoveStaff: error: %v", err)
+		switch err.Error() {
+		case message.MsgStaffOwnerOnly:
+			response.Forbidden(w, err.Error())
+		case message.MsgStaffNotFound:
+			response.Error(w, http.StatusNotFound, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	response.OK(w, nil, message.MsgStaffRemoved)
+}
+
+/*
+Metode untuk membuat API key toko.
+Kunci mentah dikembalikan sekali untuk disimpan oleh skrip hoster.
+*/
+func (h *HosterHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	log.Printf("CreateAPIKey: received request")
+	if r.Method != http.MethodPost {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	var req APIKeyRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		log.Printf("CreateAPIKey: invalid JSON: %v", err)
+		response.BadRequest(w, message.MsgBadRequest)
+		return
+	}
+
+	key, err := h.service.CreateAPIKey(r.Context(), req.Name, req.Scopes)
+	if err != nil {
+		log.Printf("CreateAPIKey: error: %v", err)
+		switch err.Error() {
+		case message.MsgAPIKeyNameRequired, message.MsgAPIKeyScopesRequired, message.MsgAPIKeyScopeInvalid:
+			response.BadRequest(w, err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		}
+		return
+	}
+
+	response.Created(w, key, message.MsgAPIKeyCreated)
+}
+
+/*
+Metode untuk mengambil daftar API key toko.
+API key aktif beserta scope dan waktu penggunaan terakhir dikembalikan.
+*/
+func (h *HosterHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetAPIKeys: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	keys, err := h.service.GetAPIKeys(r.Context())
+	if err != nil {
+		log.Printf("GetAPIKeys: error: %v", err)
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, keys, message.MsgSuccess)
+}
+
+/*
+Metode untuk mencabut API key toko.
+API key yang dicabut langsung ditolak pada permintaan berikutnya.
+*/
+func (h *HosterHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	log.Printf("RevokeAPIKey: received request")
+	if r.Method != http.MethodDelete {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if strings.TrimSpace(id) == "" {
+		response.BadRequest(w, message.MsgAPIKeyIDRequired)
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(r.Context(), id); err != nil {
+		log.Printf("RevokeAPIKey: error: %v", err)
+		if err.Error() == message.MsgAPIKeyNotFound {
+			response.Error(w, http.StatusNotFound, err.Error())
+			return
+		}
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+		return
+	}
+
+	response.OK(w, nil, message.MsgAPIKeyRevoked)
+}
+
 /*
 Fungsi untuk membuat instance baru dari HosterHandler.
 Fungsi ini menginisialisasi handler dengan layanan yang diberikan.
@@ -3,10 +3,14 @@ package hoster
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"lalan-be/internal/ledger"
 	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
 	"log"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 /*
@@ -64,6 +68,7 @@ func (r *hosterRespository) FindByEmailHosterForLogin(email string) (*model.Host
 			tiktok,
 			instagram,
 			website,
+			email_verified_at,
 			created_at,
 			updated_at
 		FROM hoster
@@ -102,6 +107,7 @@ func (r *hosterRespository) GetDetailHoster(id string) (*model.HosterModel, erro
             tiktok,
             instagram,
             website,
+            email_verified_at,
             created_at,
             updated_at
         FROM hoster
@@ -141,14 +147,19 @@ func (r *hosterRespository) CreateItem(item *model.ItemModel) error {
 			price_per_day,
 			deposit,
 			discount,
+			price_per_week,
+			price_per_month,
+			weekend_surcharge,
+			delivery_fee,
 			category_id,
 			user_id,
 			created_at,
 			updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NOW(), NOW())
 	`
 	_, err = r.db.Exec(query, item.ID, item.Name, item.Description, photosJSON,
 		item.Stock, item.PickupType, item.PricePerDay, item.Deposit, item.Discount,
+		item.PricePerWeek, item.PricePerMonth, item.WeekendSurcharge, item.DeliveryFee,
 		item.CategoryID, item.UserID)
 	if err != nil {
 		log.Printf("CreateItem: error inserting item: %v", err)
@@ -173,6 +184,10 @@ func (r *hosterRespository) FindItemNameByID(id string) (*model.ItemModel, error
 			price_per_day,
 			deposit,
 			discount,
+			price_per_week,
+			price_per_month,
+			weekend_surcharge,
+			delivery_fee,
 			category_id,
 			user_id,
 			created_at,
@@ -185,7 +200,7 @@ func (r *hosterRespository) FindItemNameByID(id string) (*model.ItemModel, error
 	var photosJSON []byte
 	err := r.db.QueryRow(query, id).Scan(
 		&item.ID, &item.Name, &item.Description, &photosJSON, &item.Stock,
-		&item.PickupType, &item.PricePerDay, &item.Deposit, &item.Discount,
+		&item.PickupType, &item.PricePerDay, &item.Deposit, &item.Discount, &item.PricePerWeek, &item.PricePerMonth, &item.WeekendSurcharge, &item.DeliveryFee,
 		&item.CategoryID, &item.UserID, &item.CreatedAt, &item.UpdatedAt)
 
 	if err == sql.ErrNoRows {
@@ -220,6 +235,10 @@ func (r *hosterRespository) FindItemNameByUserID(name string, userId string) (*m
 			price_per_day,
 			deposit,
 			discount,
+			price_per_week,
+			price_per_month,
+			weekend_surcharge,
+			delivery_fee,
 			category_id,
 			user_id,
 			created_at,
@@ -232,7 +251,7 @@ func (r *hosterRespository) FindItemNameByUserID(name string, userId string) (*m
 	var photosJSON []byte
 	err := r.db.QueryRow(query, name, userId).Scan(
 		&item.ID, &item.Name, &item.Description, &photosJSON, &item.Stock,
-		&item.PickupType, &item.PricePerDay, &item.Deposit, &item.Discount,
+		&item.PickupType, &item.PricePerDay, &item.Deposit, &item.Discount, &item.PricePerWeek, &item.PricePerMonth, &item.WeekendSurcharge, &item.DeliveryFee,
 		&item.CategoryID, &item.UserID, &item.CreatedAt, &item.UpdatedAt)
 
 	if err == sql.ErrNoRows {
@@ -267,6 +286,10 @@ func (r *hosterRespository) GetAllItems() ([]*model.ItemModel, error) {
 			price_per_day,
 			deposit,
 			discount,
+			price_per_week,
+			price_per_month,
+			weekend_surcharge,
+			delivery_fee,
 			category_id,
 			user_id,
 			created_at,
@@ -283,7 +306,7 @@ func (r *hosterRespository) GetAllItems() ([]*model.ItemModel, error) {
 	for rows.Next() {
 		var item model.ItemModel
 		var photosJSON []byte
-		err := rows.Scan(&item.ID, &item.Name, &item.Description, &photosJSON, &item.Stock, &item.PickupType, &item.PricePerDay, &item.Deposit, &item.Discount, &item.CategoryID, &item.UserID, &item.CreatedAt, &item.UpdatedAt)
+		err := rows.Scan(&item.ID, &item.Name, &item.Description, &photosJSON, &item.Stock, &item.PickupType, &item.PricePerDay, &item.Deposit, &item.Discount, &item.PricePerWeek, &item.PricePerMonth, &item.WeekendSurcharge, &item.DeliveryFee, &item.CategoryID, &item.UserID, &item.CreatedAt, &item.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -295,6 +318,57 @@ func (r *hosterRespository) GetAllItems() ([]*model.ItemModel, error) {
 	return items, nil
 }
 
+/*
+Metode untuk mengambil semua item milik seorang hoster.
+Daftar model item milik hoster dikembalikan.
+*/
+func (r *hosterRespository) GetItemsByUserID(userID string) ([]*model.ItemModel, error) {
+	query := `
+		SELECT
+			id,
+			name,
+			description,
+			photos,
+			stock,
+			pickup_type,
+			price_per_day,
+			deposit,
+			discount,
+			price_per_week,
+			price_per_month,
+			weekend_surcharge,
+			delivery_fee,
+			category_id,
+			user_id,
+			created_at,
+			updated_at
+		FROM item
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+	items := []*model.ItemModel{}
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		log.Printf("GetItemsByUserID: error querying items for %s: %v", userID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item model.ItemModel
+		var photosJSON []byte
+		err := rows.Scan(&item.ID, &item.Name, &item.Description, &photosJSON, &item.Stock, &item.PickupType, &item.PricePerDay, &item.Deposit, &item.Discount, &item.PricePerWeek, &item.PricePerMonth, &item.WeekendSurcharge, &item.DeliveryFee, &item.CategoryID, &item.UserID, &item.CreatedAt, &item.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(photosJSON, &item.Photos); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
+
 /*
 Metode untuk memperbarui item di database.
 Item diperbarui berdasarkan ID.
@@ -311,16 +385,20 @@ func (r *hosterRespository) UpdateItem(item *model.ItemModel) error {
 			price_per_day = $6,
 			deposit = $7,
 			discount = $8,
-			category_id = $9,
-			updated_at = $10
-		WHERE id = $11
+			price_per_week = $9,
+			price_per_month = $10,
+			weekend_surcharge = $11,
+			delivery_fee = $12,
+			category_id = $13,
+			updated_at = $14
+		WHERE id = $15
 	`
 	photosJSON, err := json.Marshal(item.Photos)
 	if err != nil {
 		log.Printf("UpdateItem: error marshaling photos: %v", err)
 		return err
 	}
-	_, err = r.db.Exec(query, item.Name, item.Description, photosJSON, item.Stock, item.PickupType, item.PricePerDay, item.Deposit, item.Discount, item.CategoryID, item.UpdatedAt, item.ID)
+	_, err = r.db.Exec(query, item.Name, item.Description, photosJSON, item.Stock, item.PickupType, item.PricePerDay, item.Deposit, item.Discount, item.PricePerWeek, item.PricePerMonth, item.WeekendSurcharge, item.DeliveryFee, item.CategoryID, item.UpdatedAt, item.ID)
 	if err != nil {
 		log.Printf("UpdateItem: error updating item: %v", err)
 		return err
@@ -406,6 +484,42 @@ func (r *hosterRespository) FindTermsAndConditionsByID(id string) (*model.TermsA
 	return &tac, nil
 }
 
+/*
+Metode untuk mencari terms and conditions milik seorang hoster.
+Model terms and conditions dikembalikan, atau nil jika hoster belum membuatnya.
+*/
+func (r *hosterRespository) FindTermsAndConditionsByUserID(userID string) (*model.TermsAndConditionsModel, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			description,
+			created_at,
+			updated_at
+		FROM tnc
+		WHERE user_id = $1
+		LIMIT 1
+	`
+	var tac model.TermsAndConditionsModel
+	var descriptionJSON []byte
+	err := r.db.QueryRow(query, userID).Scan(
+		&tac.ID, &tac.UserID, &descriptionJSON, &tac.CreatedAt, &tac.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindTermsAndConditionsByUserID: error querying tnc for %s: %v", userID, err)
+		return nil, err
+	}
+
+	if err := json.Unmarshal(descriptionJSON, &tac.Description); err != nil {
+		return nil, err
+	}
+
+	return &tac, nil
+}
+
 /*
 Metode untuk mengambil semua terms and conditions dari database.
 Daftar model terms and conditions dikembalikan.
@@ -481,25 +595,796 @@ func (r *hosterRespository) DeleteTermsAndConditions(id string) error {
 	return nil
 }
 
+/*
+Metode untuk memperbarui hash password hoster di database.
+Password diperbarui berdasarkan ID.
+*/
+func (r *hosterRespository) UpdatePasswordHoster(id string, passwordHash string) error {
+	query := `
+		UPDATE hoster
+		SET
+			password_hash = $1,
+			updated_at = NOW()
+		WHERE id = $2
+	`
+	_, err := r.db.Exec(query, passwordHash, id)
+	if err != nil {
+		log.Printf("UpdatePasswordHoster: error updating password for %s: %v", id, err)
+		return err
+	}
+	log.Printf("UpdatePasswordHoster: updated password for ID %s", id)
+	return nil
+}
+
+/*
+Metode untuk menandai email hoster sudah terverifikasi.
+Nilai true dikembalikan jika email cocok dan belum pernah diverifikasi.
+*/
+func (r *hosterRespository) MarkEmailVerifiedHoster(id string, email string) (bool, error) {
+	query := `
+		UPDATE hoster
+		SET
+			email_verified_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND LOWER(email) = LOWER($2) AND email_verified_at IS NULL
+	`
+	res, err := r.db.Exec(query, id, email)
+	if err != nil {
+		log.Printf("MarkEmailVerifiedHoster: error verifying %s: %v", id, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	log.Printf("MarkEmailVerifiedHoster: verified %d row(s) for ID %s", affected, id)
+	return affected > 0, nil
+}
+
+/*
+Metode untuk mencari staf toko berdasarkan email.
+Model staf termasuk hash password dikembalikan atau nil jika tidak ditemukan.
+*/
+func (r *hosterRespository) FindStaffByEmail(email string) (*model.HosterStaffModel, error) {
+	var staff model.HosterStaffModel
+	query := `
+		SELECT
+			id,
+			store_id,
+			email,
+			COALESCE(full_name, '') AS full_name,
+			COALESCE(password_hash, '') AS password_hash,
+			role,
+			invited_by,
+			invite_expires_at,
+			accepted_at,
+			created_at,
+			updated_at
+		FROM hoster_staff
+		WHERE LOWER(email) = LOWER($1)
+	`
+	err := r.db.Get(&staff, query, email)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindStaffByEmail: error querying email %s: %v", email, err)
+		return nil, err
+	}
+	return &staff, nil
+}
+
+/*
+Metode untuk mencari staf toko berdasarkan ID.
+Model staf dikembalikan atau nil jika tidak ditemukan.
+*/
+func (r *hosterRespository) FindStaffByID(id string) (*model.HosterStaffModel, error) {
+	var staff model.HosterStaffModel
+	query := `
+		SELECT
+			id,
+			store_id,
+			email,
+			COALESCE(full_name, '') AS full_name,
+			COALESCE(password_hash, '') AS password_hash,
+			role,
+			invited_by,
+			invite_expires_at,
+			accepted_at,
+			created_at,
+			updated_at
+		FROM hoster_staff
+		WHERE id = $1
+	`
+	err := r.db.Get(&staff, query, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindStaffByID: error querying staff %s: %v", id, err)
+		return nil, err
+	}
+	return &staff, nil
+}
+
+/*
+Metode untuk mencari undangan staf yang masih berlaku berdasarkan hash token.
+Data staf dikembalikan tanpa menerima undangan, atau nil jika token tidak valid.
+*/
+func (r *hosterRespository) FindStaffByInviteHash(hash string) (*model.HosterStaffModel, error) {
+	var staff model.HosterStaffModel
+	query := `
+		SELECT
+			id,
+			store_id,
+			email,
+			COALESCE(full_name, '') AS full_name,
+			role,
+			invited_by,
+			invite_expires_at,
+			accepted_at,
+			created_at,
+			updated_at
+		FROM hoster_staff
+		WHERE invite_token_hash = $1 AND accepted_at IS NULL AND invite_expires_at > NOW()
+	`
+	err := r.db.Get(&staff, query, hash)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindStaffByInviteHash: error querying invitation: %v", err)
+		return nil, err
+	}
+	return &staff, nil
+}
+
+/*
+Metode untuk mengambil semua staf sebuah toko.
+Daftar staf aktif dan undangan yang masih menunggu dikembalikan.
+*/
+func (r *hosterRespository) GetStaffByStore(storeID string) ([]*model.HosterStaffModel, error) {
+	var staff []*model.HosterStaffModel
+	query := `
+		SELECT
+			id,
+			store_id,
+			email,
+			COALESCE(full_name, '') AS full_name,
+			role,
+			invited_by,
+			invite_expires_at,
+			accepted_at,
+			created_at,
+			updated_at
+		FROM hoster_staff
+		WHERE store_id = $1
+		ORDER BY created_at
+	`
+	if err := r.db.Select(&staff, query, storeID); err != nil {
+		log.Printf("GetStaffByStore: error querying staff for store %s: %v", storeID, err)
+		return nil, err
+	}
+	return staff, nil
+}
+
+/*
+Metode untuk menyimpan undangan staf toko.
+Undangan yang belum diterima untuk email dan toko yang sama diperbarui; nilai false dikembalikan jika email sudah dipakai.
+*/
+func (r *hosterRespository) CreateStaffInvitation(staff *model.HosterStaffModel) (bool, error) {
+	query := `
+		INSERT INTO hoster_staff (
+			store_id,
+			email,
+			full_name,
+			role,
+			invited_by,
+			invite_token_hash,
+			invite_expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (email) DO UPDATE SET
+			full_name = EXCLUDED.full_name,
+			role = EXCLUDED.role,
+			invited_by = EXCLUDED.invited_by,
+			invite_token_hash = EXCLUDED.invite_token_hash,
+			invite_expires_at = EXCLUDED.invite_expires_at
+		WHERE hoster_staff.accepted_at IS NULL AND hoster_staff.store_id = EXCLUDED.store_id
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRow(query, staff.StoreID, staff.Email, staff.FullName, staff.Role, staff.InvitedBy, staff.InviteTokenHash, staff.InviteExpiresAt).Scan(&staff.ID, &staff.CreatedAt, &staff.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		log.Printf("CreateStaffInvitation: error inviting %s to store %s: %v", staff.Email, staff.StoreID, err)
+		return false, err
+	}
+	log.Printf("CreateStaffInvitation: invited %s to store %s as %s", staff.Email, staff.StoreID, staff.Role)
+	return true, nil
+}
+
+/*
+Metode untuk menerima undangan staf dan mengaktifkan akunnya dalam satu transaksi.
+Model staf dikembalikan atau nil jika undangan tidak valid atau kedaluwarsa.
+*/
+func (r *hosterRespository) AcceptStaffInvitation(hash, fullName, passwordHash string) (*model.HosterStaffModel, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var staff model.HosterStaffModel
+	accept := `
+		UPDATE hoster_staff
+		SET
+			full_name = COALESCE(NULLIF($2, ''), full_name),
+			password_hash = $3,
+			accepted_at = NOW(),
+			invite_token_hash = NULL,
+			invite_expires_at = NULL
+		WHERE invite_token_hash = $1 AND accepted_at IS NULL AND invite_expires_at > NOW()
+		RETURNING id, store_id, email, COALESCE(full_name, '') AS full_name, role, invited_by, accepted_at, created_at, updated_at
+	`
+	if err := tx.Get(&staff, accept, hash, fullName, passwordHash); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Printf("AcceptStaffInvitation: error accepting invitation: %v", err)
+		return nil, err
+	}
+
+	if err := assignStaffRole(tx, staff.ID, staff.Role); err != nil {
+		log.Printf("AcceptStaffInvitation: error assigning role to staff %s: %v", staff.ID, err)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	log.Printf("AcceptStaffInvitation: activated staff %s for store %s", staff.ID, staff.StoreID)
+	return &staff, nil
+}
+
+/*
+Metode untuk mengubah role staf di sebuah toko.
+Nilai false dikembalikan jika staf tidak ditemukan di toko tersebut.
+*/
+func (r *hosterRespository) UpdateStaffRole(storeID, id, role string) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var accepted bool
+	update := `
+		UPDATE hoster_staff
+		SET role = $1
+		WHERE id = $2 AND store_id = $3
+		RETURNING accepted_at IS NOT NULL
+	`
+	if err := tx.Get(&accepted, update, role, id, storeID); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		log.Printf("UpdateStaffRole: error updating staff %s: %v", id, err)
+		return false, err
+	}
+
+	// Role otorisasi baru diberikan saat undangan diterima
+	if accepted {
+		if err := assignStaffRole(tx, id, role); err != nil {
+			log.Printf("UpdateStaffRole: error replacing role for staff %s: %v", id, err)
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+/*
+Metode untuk menghapus staf dari sebuah toko.
+Nilai false dikembalikan jika staf tidak ditemukan di toko tersebut.
+*/
+func (r *hosterRespository) DeleteStaff(storeID, id string) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`DELETE FROM hoster_staff WHERE id = $1 AND store_id = $2`, id, storeID)
+	if err != nil {
+		log.Printf("DeleteStaff: error deleting staff %s: %v", id, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(`DELETE FROM user_roles WHERE user_id = $1 AND user_type = 'hoster'`, id); err != nil {
+		log.Printf("DeleteStaff: error removing roles for staff %s: %v", id, err)
+		return false, err
+	}
+
+	// API key yang dibuat staf ikut dicabut agar tidak bertahan setelah staf keluar
+	if _, err := tx.Exec(`UPDATE hoster_api_keys SET revoked_at = NOW() WHERE created_by = $1 AND revoked_at IS NULL`, id); err != nil {
+		log.Printf("DeleteStaff: error revoking api keys for staff %s: %v", id, err)
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	log.Printf("DeleteStaff: removed staff %s from store %s", id, storeID)
+	return true, nil
+}
+
+/*
+Metode untuk mengambil booking item milik toko untuk ekspor data.
+Daftar booking beserta nama item dan customer dikembalikan dari tanggal mulai terbaru.
+*/
+func (r *hosterRespository) GetBookingsByStore(storeID string) ([]*model.BookingModel, error) {
+	bookings := []*model.BookingModel{}
+	query := `
+		SELECT
+			b.id,
+			b.item_id,
+			i.name AS item_name,
+			b.customer_id,
+			COALESCE(c.full_name, '') AS customer_name,
+			b.store_id,
+			b.order_id,
+			b.quantity,
+			b.start_date,
+			b.end_date,
+			b.days,
+			b.price_per_day,
+			b.discount,
+			b.delivery_fee,
+			b.total_price,
+			b.deposit,
+			b.status,
+			b.notes,
+			b.cancellation_policy_id,
+			b.cancellation_rules,
+			b.refund_percent,
+			b.refund_amount,
+			b.created_at,
+			b.updated_at,
+			b.confirmed_at,
+			b.rejected_at,
+			b.picked_up_at,
+			b.returned_at,
+			b.completed_at,
+			b.cancelled_at
+		FROM bookings b
+		JOIN item i ON i.id = b.item_id
+		LEFT JOIN customers c ON c.id = b.customer_id
+		WHERE b.store_id = $1
+		ORDER BY b.start_date DESC, b.created_at DESC
+	`
+	if err := r.db.Select(&bookings, query, storeID); err != nil {
+		log.Printf("GetBookingsByStore: error querying store %s: %v", storeID, err)
+		return nil, err
+	}
+	return bookings, nil
+}
+
+/*
+Metode untuk mengambil order toko untuk ekspor data.
+Daftar order dikembalikan dari yang terbaru, baris booking-nya diekspor terpisah.
+*/
+func (r *hosterRespository) GetOrdersByStore(storeID string) ([]*model.OrderModel, error) {
+	orders := []*model.OrderModel{}
+	query := `
+		SELECT
+			o.id,
+			o.customer_id,
+			o.store_id,
+			h.store_name,
+			o.start_date,
+			o.end_date,
+			o.total_price,
+			o.deposit,
+			o.notes,
+			o.created_at
+		FROM orders o
+		JOIN hoster h ON h.id = o.store_id
+		WHERE o.store_id = $1
+		ORDER BY o.created_at DESC
+	`
+	if err := r.db.Select(&orders, query, storeID); err != nil {
+		log.Printf("GetOrdersByStore: error querying store %s: %v", storeID, err)
+		return nil, err
+	}
+	for _, order := range orders {
+		order.AmountDue = order.TotalPrice + order.Deposit
+	}
+	return orders, nil
+}
+
+/*
+Metode untuk mengambil hold stok item milik toko untuk ekspor data.
+Semua hold, termasuk yang sudah dilepas atau kedaluwarsa, dikembalikan dari yang terbaru.
+*/
+func (r *hosterRespository) GetStockHoldsByStore(storeID string) ([]*model.StockHoldModel, error) {
+	holds := []*model.StockHoldModel{}
+	query := `
+		SELECT
+			s.id,
+			s.customer_id,
+			s.item_id,
+			i.name AS item_name,
+			s.store_id,
+			s.quantity,
+			s.start_date,
+			s.end_date,
+			s.status,
+			s.booking_id,
+			s.expires_at,
+			s.created_at
+		FROM stock_holds s
+		JOIN item i ON i.id = s.item_id
+		WHERE s.store_id = $1
+		ORDER BY s.created_at DESC
+	`
+	if err := r.db.Select(&holds, query, storeID); err != nil {
+		log.Printf("GetStockHoldsByStore: error querying store %s: %v", storeID, err)
+		return nil, err
+	}
+	return holds, nil
+}
+
+/*
+Metode untuk mengambil entri deposit booking toko untuk ekspor data.
+Entri buku besar semua booking toko dikembalikan urut booking dan waktu pencatatan.
+*/
+func (r *hosterRespository) GetDepositEntriesByStore(storeID string) ([]*model.DepositEntryModel, error) {
+	entries := []*model.DepositEntryModel{}
+	query := `
+		SELECT
+			d.id,
+			d.booking_id,
+			d.entry_type,
+			d.amount,
+			d.reason,
+			d.photos,
+			d.status,
+			d.customer_note,
+			d.created_by,
+			d.created_at,
+			d.responded_at
+		FROM deposit_entries d
+		JOIN bookings b ON b.id = d.booking_id
+		WHERE b.store_id = $1
+		ORDER BY d.booking_id, d.created_at, d.id
+	`
+	if err := r.db.Select(&entries, query, storeID); err != nil {
+		log.Printf("GetDepositEntriesByStore: error querying store %s: %v", storeID, err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+/*
+Metode untuk mengambil kebijakan pembatalan toko untuk ekspor data.
+Daftar kebijakan dikembalikan dengan kebijakan bawaan lebih dulu.
+*/
+func (r *hosterRespository) GetCancellationPoliciesByStore(storeID string) ([]*model.CancellationPolicyModel, error) {
+	policies := []*model.CancellationPolicyModel{}
+	query := `
+		SELECT
+			id,
+			store_id,
+			name,
+			description,
+			rules,
+			is_default,
+			created_at,
+			updated_at
+		FROM cancellation_policies
+		WHERE store_id = $1
+		ORDER BY is_default DESC, name
+	`
+	if err := r.db.Select(&policies, query, storeID); err != nil {
+		log.Printf("GetCancellationPoliciesByStore: error querying store %s: %v", storeID, err)
+		return nil, err
+	}
+	return policies, nil
+}
+
+/*
+Metode untuk menghitung booking toko yang barangnya masih di tangan customer.
+Booking yang sedang disewa atau sudah dikembalikan tetapi deposit-nya belum selesai dihitung.
+*/
+func (r *hosterRespository) CountActiveRentalsByStore(storeID string) (int, error) {
+	return countActiveRentals(r.db, storeID)
+}
+
+/*
+Metode untuk menganonimkan data pribadi pemilik toko yang dihapus.
+Booking yang belum diambil dibatalkan dengan refund penuh dan deposit-nya dikembalikan, hold stok dilepas, staf toko dihapus, API key dicabut, dan syarat sewa dihapus, sedangkan item tetap ada agar riwayat sewa tetap utuh.
+Penghapusan ditolak selama masih ada barang yang disewa; staf yang dihapus dikembalikan agar data autentikasinya ikut dibersihkan.
+*/
+func (r *hosterRespository) AnonymizeHoster(id string) ([]*model.HosterStaffModel, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Baris toko dikunci agar pengecekan dan pembatalan booking konsisten
+	if _, err := tx.Exec(`SELECT id FROM hoster WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return nil, err
+	}
+	rentals, err := countActiveRentals(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rentals > 0 {
+		return nil, errors.New(message.MsgAccountDeletionActiveRentals)
+	}
+
+	cancel := `
+		UPDATE bookings
+		SET status = $2, cancelled_at = NOW(), refund_percent = 100, refund_amount = total_price
+		WHERE store_id = $1 AND status = ANY($3)
+		RETURNING id
+	`
+	upcoming := pq.StringArray{model.BookingStatusPending, model.BookingStatusConfirmed}
+	cancelled := []string{}
+	if err := tx.Select(&cancelled, cancel, id, model.BookingStatusCancelled, upcoming); err != nil {
+		log.Printf("AnonymizeHoster: error cancelling bookings of %s: %v", id, err)
+		return nil, err
+	}
+	for _, bookingID := range cancelled {
+		if err := ledger.ReleaseDeposit(tx, bookingID, "Store deleted"); err != nil {
+			return nil, err
+		}
+	}
+	release := `UPDATE stock_holds SET status = $2 WHERE store_id = $1 AND status = $3`
+	if _, err := tx.Exec(release, id, model.StockHoldStatusReleased, model.StockHoldStatusActive); err != nil {
+		log.Printf("AnonymizeHoster: error releasing holds of %s: %v", id, err)
+		return nil, err
+	}
+
+	staff := []*model.HosterStaffModel{}
+	if err := tx.Select(&staff, `DELETE FROM hoster_staff WHERE store_id = $1 RETURNING id, store_id, email, role`, id); err != nil {
+		log.Printf("AnonymizeHoster: error removing staff of %s: %v", id, err)
+		return nil, err
+	}
+	if _, err := tx.Exec(`UPDATE hoster_api_keys SET revoked_at = NOW() WHERE store_id = $1 AND revoked_at IS NULL`, id); err != nil {
+		log.Printf("AnonymizeHoster: error revoking api keys of %s: %v", id, err)
+		return nil, err
+	}
+	if _, err := tx.Exec(`DELETE FROM tnc WHERE user_id = $1`, id); err != nil {
+		log.Printf("AnonymizeHoster: error deleting tnc of %s: %v", id, err)
+		return nil, err
+	}
+
+	query := `
+		UPDATE hoster
+		SET
+			full_name = 'Deleted hoster',
+			store_name = 'Deleted store',
+			email = 'deleted-' || id || '@deleted.invalid',
+			phone_number = NULL,
+			address = '',
+			profile_photo = NULL,
+			description = NULL,
+			website = NULL,
+			instagram = NULL,
+			tiktok = NULL,
+			password_hash = '!',
+			email_verified_at = NULL,
+			deleted_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	if _, err := tx.Exec(query, id); err != nil {
+		log.Printf("AnonymizeHoster: error anonymizing hoster %s: %v", id, err)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	log.Printf("AnonymizeHoster: anonymized hoster %s, cancelled %d booking(s) and removed %d staff", id, len(cancelled), len(staff))
+	return staff, nil
+}
+
+/*
+Metode untuk memperbarui hash password staf di database.
+Password diperbarui berdasarkan ID.
+*/
+func (r *hosterRespository) UpdatePasswordStaff(id string, passwordHash string) error {
+	query := `
+		UPDATE hoster_staff
+		SET password_hash = $1
+		WHERE id = $2 AND accepted_at IS NOT NULL
+	`
+	if _, err := r.db.Exec(query, passwordHash, id); err != nil {
+		log.Printf("UpdatePasswordStaff: error updating password for %s: %v", id, err)
+		return err
+	}
+	log.Printf("UpdatePasswordStaff: updated password for ID %s", id)
+	return nil
+}
+
+/*
+Metode untuk menyimpan API key baru toko di database.
+ID dan timestamp API key dikembalikan setelah penyisipan.
+*/
+func (r *hosterRespository) CreateAPIKey(key *model.APIKeyModel) error {
+	query := `
+		INSERT INTO hoster_api_keys (
+			store_id,
+			created_by,
+			name,
+			prefix,
+			key_hash,
+			scopes
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRow(query, key.StoreID, key.CreatedBy, key.Name, key.Prefix, key.KeyHash, key.Scopes).Scan(&key.ID, &key.CreatedAt, &key.UpdatedAt)
+	if err != nil {
+		log.Printf("CreateAPIKey: error inserting api key for store %s: %v", key.StoreID, err)
+		return err
+	}
+	log.Printf("CreateAPIKey: created api key %s for store %s", key.ID, key.StoreID)
+	return nil
+}
+
+/*
+Metode untuk mengambil API key aktif milik toko.
+Daftar API key tanpa hash dikembalikan, terbaru lebih dulu.
+*/
+func (r *hosterRespository) GetAPIKeysByStore(storeID string) ([]*model.APIKeyModel, error) {
+	var keys []*model.APIKeyModel
+	query := `
+		SELECT
+			id,
+			store_id,
+			created_by,
+			name,
+			prefix,
+			scopes,
+			last_used_at,
+			revoked_at,
+			created_at,
+			updated_at
+		FROM hoster_api_keys
+		WHERE store_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+	if err := r.db.Select(&keys, query, storeID); err != nil {
+		log.Printf("GetAPIKeysByStore: error querying api keys for store %s: %v", storeID, err)
+		return nil, err
+	}
+	return keys, nil
+}
+
+/*
+Metode untuk mencabut API key milik toko.
+Nilai false dikembalikan jika API key tidak ditemukan di toko tersebut.
+*/
+func (r *hosterRespository) RevokeAPIKey(storeID, id string) (bool, error) {
+	query := `
+		UPDATE hoster_api_keys
+		SET revoked_at = NOW()
+		WHERE id = $1 AND store_id = $2 AND revoked_at IS NULL
+	`
+	res, err := r.db.Exec(query, id, storeID)
+	if err != nil {
+		log.Printf("RevokeAPIKey: error revoking api key %s: %v", id, err)
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected > 0 {
+		log.Printf("RevokeAPIKey: revoked api key %s of store %s", id, storeID)
+	}
+	return affected > 0, nil
+}
+
 /*
 Antarmuka untuk operasi repositori hoster.
-Mendefinisikan metode untuk CRUD hoster.
+Mendefinisikan metode untuk CRUD hoster, staf toko, dan API key toko.
 */
 type HosterRepository interface {
 	CreateHoster(hoster *model.HosterModel) error
 	FindByEmailHosterForLogin(email string) (*model.HosterModel, error)
+	UpdatePasswordHoster(id string, passwordHash string) error
+	MarkEmailVerifiedHoster(id string, email string) (bool, error)
 	GetDetailHoster(id string) (*model.HosterModel, error)
 	CreateItem(item *model.ItemModel) error
 	FindItemNameByUserID(name string, userId string) (*model.ItemModel, error)
 	FindItemNameByID(id string) (*model.ItemModel, error)
 	GetAllItems() ([]*model.ItemModel, error)
+	GetItemsByUserID(userID string) ([]*model.ItemModel, error)
 	UpdateItem(item *model.ItemModel) error
 	DeleteItem(id string) error
 	CreateTermsAndConditions(tac *model.TermsAndConditionsModel) error
 	FindTermsAndConditionsByID(id string) (*model.TermsAndConditionsModel, error)
+	FindTermsAndConditionsByUserID(userID string) (*model.TermsAndConditionsModel, error)
 	GetAllTermsAndConditions() ([]*model.TermsAndConditionsModel, error)
 	UpdateTermsAndConditions(tac *model.TermsAndConditionsModel) error
 	DeleteTermsAndConditions(id string) error
+	FindStaffByEmail(email string) (*model.HosterStaffModel, error)
+	FindStaffByID(id string) (*model.HosterStaffModel, error)
+	FindStaffByInviteHash(hash string) (*model.HosterStaffModel, error)
+	GetStaffByStore(storeID string) ([]*model.HosterStaffModel, error)
+	CreateStaffInvitation(staff *model.HosterStaffModel) (bool, error)
+	AcceptStaffInvitation(hash, fullName, passwordHash string) (*model.HosterStaffModel, error)
+	UpdateStaffRole(storeID, id, role string) (bool, error)
+	DeleteStaff(storeID, id string) (bool, error)
+	UpdatePasswordStaff(id string, passwordHash string) error
+	GetBookingsByStore(storeID string) ([]*model.BookingModel, error)
+	GetOrdersByStore(storeID string) ([]*model.OrderModel, error)
+	GetStockHoldsByStore(storeID string) ([]*model.StockHoldModel, error)
+	GetDepositEntriesByStore(storeID string) ([]*model.DepositEntryModel, error)
+	GetCancellationPoliciesByStore(storeID string) ([]*model.CancellationPolicyModel, error)
+	CountActiveRentalsByStore(storeID string) (int, error)
+	AnonymizeHoster(id string) ([]*model.HosterStaffModel, error)
+	CreateAPIKey(key *model.APIKeyModel) error
+	GetAPIKeysByStore(storeID string) ([]*model.APIKeyModel, error)
+	RevokeAPIKey(storeID, id string) (bool, error)
+}
+
+/*
+Fungsi untuk memberikan role otorisasi staf di dalam transaksi.
+Role lama staf dihapus lalu diganti dengan role yang sesuai jabatannya di toko.
+*/
+func assignStaffRole(tx *sqlx.Tx, staffID, storeRole string) error {
+	if _, err := tx.Exec(`DELETE FROM user_roles WHERE user_id = $1 AND user_type = 'hoster'`, staffID); err != nil {
+		return err
+	}
+	insert := `
+		INSERT INTO user_roles (user_id, user_type, role_id)
+		SELECT $1, 'hoster', id FROM roles WHERE name = 'hoster_' || $2::text
+	`
+	_, err := tx.Exec(insert, staffID, storeRole)
+	return err
+}
+
+/*
+Fungsi untuk menghitung booking toko yang barangnya masih di tangan customer.
+Booking yang sudah dikembalikan hanya dihitung jika deposit-nya ditahan dan belum dikembalikan; query dijalankan di database atau di dalam transaksi penghapusan.
+*/
+func countActiveRentals(q sqlx.Queryer, storeID string) (int, error) {
+	var count int
+	query := `
+		SELECT COUNT(*)
+		FROM bookings b
+		WHERE b.store_id = $1
+			AND (
+				b.status = $2
+				OR (
+					b.status = $3
+					AND EXISTS (SELECT 1 FROM deposit_entries d WHERE d.booking_id = b.id AND d.entry_type = $4)
+					AND NOT EXISTS (SELECT 1 FROM deposit_entries d WHERE d.booking_id = b.id AND d.entry_type = $5)
+				)
+			)
+	`
+	err := sqlx.Get(q, &count, query, storeID, model.BookingStatusPickedUp, model.BookingStatusReturned, model.DepositEntryHold, model.DepositEntryRelease)
+	if err != nil {
+		log.Printf("countActiveRentals: error counting rentals of %s: %v", storeID, err)
+		return 0, err
+	}
+	return count, nil
 }
 
 /*
@@ -2,6 +2,9 @@ package hoster
 
 import (
 	"github.com/gorilla/mux"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/middleware"
 )
 
 /*
@@ -12,15 +15,60 @@ func SetupHosterRoutes(router *mux.Router, handler *HosterHandler) {
 	hoster := router.PathPrefix("/api/v1/hoster").Subrouter()
 	hoster.HandleFunc("/register", handler.CreateHoster).Methods("POST")
 	hoster.HandleFunc("/login", handler.LoginHoster).Methods("POST")
-	hoster.HandleFunc("/detail", handler.GetDetailHoster).Methods("GET")
-	hoster.HandleFunc("/items", handler.CreateItem).Methods("POST")
-	hoster.HandleFunc("/items/{id}", handler.GetItemByID).Methods("GET")
-	hoster.HandleFunc("/items", handler.GetAllItems).Methods("GET")
-	hoster.HandleFunc("/items/{id}", handler.UpdateItem).Methods("PUT")
-	hoster.HandleFunc("/items/{id}", handler.DeleteItem).Methods("DELETE")
-	hoster.HandleFunc("/terms", handler.CreateTermsAndConditions).Methods("POST")
-	hoster.HandleFunc("/terms/{id}", handler.FindTermsAndConditionsByID).Methods("GET")
-	hoster.HandleFunc("/terms", handler.GetAllTermsAndConditions).Methods("GET")
-	hoster.HandleFunc("/terms", handler.UpdateTermsAndConditions).Methods("PUT")
-	hoster.HandleFunc("/terms", handler.DeleteTermsAndConditions).Methods("DELETE")
+	hoster.HandleFunc("/auth/refresh", handler.RefreshTokenHoster).Methods("POST")
+	hoster.HandleFunc("/auth/oidc/authorize", handler.OIDCAuthorizeHoster).Methods("GET")
+	hoster.HandleFunc("/auth/oidc/callback", handler.OIDCCallbackHoster).Methods("POST")
+	hoster.HandleFunc("/auth/forgot-password", handler.ForgotPasswordHoster).Methods("POST")
+	hoster.HandleFunc("/auth/reset-password", handler.ResetPasswordHoster).Methods("POST")
+	hoster.HandleFunc("/auth/verify-email", handler.VerifyEmailHoster).Methods("POST")
+	hoster.HandleFunc("/auth/resend-verification", handler.ResendVerificationHoster).Methods("POST")
+	hoster.HandleFunc("/auth/mfa/verify", handler.VerifyMFAHoster).Methods("POST")
+	hoster.HandleFunc("/staff/accept", handler.AcceptStaffInvitation).Methods("POST")
+
+	optional := hoster.PathPrefix("").Subrouter()
+	optional.Use(middleware.OptionalJWTMiddleware)
+	optional.HandleFunc("/auth/logout", handler.LogoutHoster).Methods("POST")
+
+	// Rute inventaris menerima API key hoster selain JWT, dibatasi scope API key
+	inventory := hoster.PathPrefix("").Subrouter()
+	inventory.Use(middleware.APIKeyOrJWTMiddleware)
+	inventory.Use(middleware.Hoster)
+	inventory.Handle("/items", middleware.RequireFunc(handler.CreateItem, auth.PermItemWrite)).Methods("POST")
+	inventory.Handle("/items/{id}", middleware.RequireFunc(handler.GetItemByID, auth.PermItemRead)).Methods("GET")
+	inventory.Handle("/items", middleware.RequireFunc(handler.GetAllItems, auth.PermItemRead)).Methods("GET")
+	inventory.Handle("/items/{id}", middleware.RequireFunc(handler.UpdateItem, auth.PermItemWrite)).Methods("PUT")
+	inventory.Handle("/items/{id}", middleware.RequireFunc(handler.DeleteItem, auth.PermItemWrite)).Methods("DELETE")
+
+	protected := hoster.PathPrefix("").Subrouter()
+	protected.Use(middleware.JWTMiddleware)
+	protected.Use(middleware.Hoster)
+	protected.HandleFunc("/account/deletion", handler.GetDeletionHoster).Methods("GET")
+	protected.HandleFunc("/auth/sessions", handler.GetSessionsHoster).Methods("GET")
+	protected.Handle("/detail", middleware.RequireFunc(handler.GetDetailHoster, auth.PermProfileRead)).Methods("GET")
+	protected.Handle("/terms", middleware.RequireFunc(handler.CreateTermsAndConditions, auth.PermTermsWrite)).Methods("POST")
+	protected.Handle("/terms/{id}", middleware.RequireFunc(handler.FindTermsAndConditionsByID, auth.PermTermsRead)).Methods("GET")
+	protected.Handle("/terms", middleware.RequireFunc(handler.GetAllTermsAndConditions, auth.PermTermsRead)).Methods("GET")
+	protected.Handle("/terms", middleware.RequireFunc(handler.UpdateTermsAndConditions, auth.PermTermsWrite)).Methods("PUT")
+	protected.Handle("/terms", middleware.RequireFunc(handler.DeleteTermsAndConditions, auth.PermTermsWrite)).Methods("DELETE")
+	protected.Handle("/staff", middleware.RequireFunc(handler.GetStaff, auth.PermStaffRead)).Methods("GET")
+	protected.Handle("/api-keys", middleware.RequireFunc(handler.GetAPIKeys, auth.PermAPIKeyRead)).Methods("GET")
+
+	// Tindakan sensitif tidak boleh dilakukan admin yang sedang impersonasi
+	sensitive := protected.PathPrefix("").Subrouter()
+	sensitive.Use(middleware.NoImpersonation)
+	sensitive.HandleFunc("/auth/logout-all", handler.LogoutAllHoster).Methods("POST")
+	sensitive.HandleFunc("/auth/change-password", handler.ChangePasswordHoster).Methods("POST")
+	sensitive.HandleFunc("/account/export", handler.ExportHoster).Methods("GET")
+	sensitive.HandleFunc("/account/deletion", handler.RequestDeletionHoster).Methods("POST")
+	sensitive.HandleFunc("/account/deletion", handler.CancelDeletionHoster).Methods("DELETE")
+	sensitive.HandleFunc("/auth/sessions", handler.TerminateSessionHoster).Methods("DELETE")
+	sensitive.HandleFunc("/auth/mfa/enroll", handler.EnrollMFAHoster).Methods("POST")
+	sensitive.HandleFunc("/auth/mfa/confirm", handler.ConfirmMFAHoster).Methods("POST")
+	sensitive.HandleFunc("/auth/mfa/disable", handler.DisableMFAHoster).Methods("POST")
+	sensitive.HandleFunc("/auth/mfa/recovery-codes", handler.RegenerateRecoveryCodesHoster).Methods("POST")
+	sensitive.Handle("/staff", middleware.RequireFunc(handler.InviteStaff, auth.PermStaffWrite)).Methods("POST")
+	sensitive.Handle("/staff", middleware.RequireFunc(handler.UpdateStaffRole, auth.PermStaffWrite)).Methods("PUT")
+	sensitive.Handle("/staff", middleware.RequireFunc(handler.RemoveStaff, auth.PermStaffWrite)).Methods("DELETE")
+	sensitive.Handle("/api-keys", middleware.RequireFunc(handler.CreateAPIKey, auth.PermAPIKeyWrite)).Methods("POST")
+	sensitive.Handle("/api-keys", middleware.RequireFunc(handler.RevokeAPIKey, auth.PermAPIKeyWrite)).Methods("DELETE")
 }
@@ -3,396 +3,1482 @@ package hoster
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"net/url"
 	"strings"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
+	"lalan-be/internal/auth"
 	"lalan-be/internal/config"
 	"lalan-be/internal/middleware"
 	"lalan-be/internal/model"
+	"lalan-be/pkg/mailer"
 	"lalan-be/pkg/message"
 )
 
+/*
+Struktur untuk ekspor data pribadi hoster.
+Struktur ini berisi profil, item, syarat sewa, kebijakan pembatalan, booking, order, hold stok, entri deposit, staf, dan API key untuk pemilik toko, atau data keanggotaan untuk staf.
+*/
+type HosterExport struct {
+	ExportedAt           time.Time                        `json:"exported_at"`
+	Profile              *model.HosterModel               `json:"profile,omitempty"`
+	Membership           *model.HosterStaffModel          `json:"membership,omitempty"`
+	Items                []*model.ItemModel               `json:"items,omitempty"`
+	TermsAndConditions   *model.TermsAndConditionsModel   `json:"terms_and_conditions,omitempty"`
+	CancellationPolicies []*model.CancellationPolicyModel `json:"cancellation_policies,omitempty"`
+	Bookings             []*model.BookingModel            `json:"bookings,omitempty"`
+	Orders               []*model.OrderModel              `json:"orders,omitempty"`
+	StockHolds           []*model.StockHoldModel          `json:"stock_holds,omitempty"`
+	DepositEntries       []*model.DepositEntryModel       `json:"deposit_entries,omitempty"`
+	Staff                []*model.HosterStaffModel        `json:"staff,omitempty"`
+	APIKeys              []*model.APIKeyModel             `json:"api_keys,omitempty"`
+	Sessions             []*model.SessionModel            `json:"sessions"`
+	Identities           []*model.OIDCIdentityModel       `json:"linked_identities"`
+	PendingDeletion      *model.AccountDeletionModel      `json:"pending_deletion,omitempty"`
+}
+
 /*
 Struktur untuk layanan hoster.
 Struktur ini menyediakan logika bisnis untuk operasi hoster.
 */
 type hosterService struct {
-	repo HosterRepository
+	repo        HosterRepository
+	tokens      auth.TokenIssuer
+	revocation  auth.RevocationStore
+	sessions    auth.SessionStore
+	reset       auth.PasswordResetService
+	passwords   auth.PasswordPolicy
+	guard       auth.LoginGuard
+	mfa         auth.MFAService
+	permissions auth.PermissionStore
+	apiKeys     auth.APIKeyStore
+	verifier    auth.EmailVerifier
+	oidc        auth.OIDCProvider
+	deletion    auth.AccountDeletionService
+	mailer      mailer.Mailer
 }
 
 /*
-Metode untuk menghasilkan token JWT untuk hoster.
-Respons token dikembalikan jika berhasil.
+Metode untuk menghasilkan access token JWT untuk pemilik atau staf toko.
+Respons token dengan klaim toko dan sesi tanpa refresh token dikembalikan jika berhasil.
 */
-func (s *hosterService) generateTokenHoster(userID string) (*HosterResponse, error) {
-	exp := time.Now().Add(1 * time.Hour)
-
-	claims := middleware.Claims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   userID,
-			ExpiresAt: jwt.NewNumericDate(exp),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-		Role: "hoster",
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	accessToken, err := token.SignedString(config.GetJWTSecret())
+func (s *hosterService) generateAccessTokenHoster(member *model.HosterStaffModel, sessionID string, mfa bool) (*HosterResponse, error) {
+	claims := auth.NewClaims(member.ID, "hoster")
+	claims.MFA = mfa
+	claims.SessionID = sessionID
+	claims.Store = member.StoreID
+	claims.StoreRole = member.Role
+	accessToken, expiresIn, err := s.tokens.IssueAccessToken(claims)
 	if err != nil {
 		return nil, err
 	}
 
 	return &HosterResponse{
-		ID:           userID,
-		AccessToken:  accessToken,
-		RefreshToken: uuid.New().String(),
-		TokenType:    "Bearer",
-		ExpiresIn:    3600,
+		ID:          member.ID,
+		StoreID:     member.StoreID,
+		StoreRole:   member.Role,
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   expiresIn,
 	}, nil
 }
 
 /*
-Metode untuk mengautentikasi hoster dengan email dan password.
-Respons token dikembalikan jika berhasil.
+Metode untuk menghasilkan pasangan token untuk pemilik atau staf toko dalam sesi baru.
+Respons access token dan refresh token tersimpan dikembalikan jika berhasil.
 */
-func (s *hosterService) LoginHoster(email, password string) (*HosterResponse, error) {
-	hoster, err := s.repo.FindByEmailHosterForLogin(email)
-	if err != nil || hoster == nil {
-		return nil, errors.New("invalid credentials")
+func (s *hosterService) generateTokenHoster(member *model.HosterStaffModel, mfa bool, client auth.ClientInfo) (*HosterResponse, error) {
+	refreshToken, sessionID, err := s.tokens.IssueRefreshToken(member.ID, "hoster", client)
+	if err != nil {
+		return nil, err
 	}
 
-	if bcrypt.CompareHashAndPassword([]byte(hoster.PasswordHash), []byte(password)) != nil {
-		return nil, errors.New("invalid credentials")
+	resp, err := s.generateAccessTokenHoster(member, sessionID, mfa)
+	if err != nil {
+		return nil, err
 	}
-
-	return s.generateTokenHoster(hoster.ID)
+	resp.RefreshToken = refreshToken
+	return resp, nil
 }
 
 /*
-Metode untuk membuat hoster baru dengan hashing password.
-Hoster berhasil dibuat atau error dikembalikan.
+Metode untuk mendapatkan keanggotaan toko dari ID akun hoster.
+Pemilik toko dikembalikan sebagai anggota dengan role owner, atau nil jika akun tidak aktif.
 */
-func (s *hosterService) CreateHoster(hoster *model.HosterModel) error {
-	hash, err := bcrypt.GenerateFromPassword([]byte(hoster.PasswordHash), bcrypt.DefaultCost)
+func (s *hosterService) membership(userID string) (*model.HosterStaffModel, error) {
+	hoster, err := s.repo.GetDetailHoster(userID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if hoster != nil {
+		return ownerMembership(hoster), nil
 	}
-	hoster.PasswordHash = string(hash)
-	hoster.CreatedAt = time.Now()
-	hoster.UpdatedAt = time.Now()
 
-	err = s.repo.CreateHoster(hoster)
+	staff, err := s.repo.FindStaffByID(userID)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate") {
-			return errors.New(message.MsgHosterEmailExists)
-		}
-		return err
+		return nil, err
 	}
-
-	return nil
+	if staff == nil || staff.AcceptedAt == nil {
+		return nil, nil
+	}
+	return staff, nil
 }
 
 /*
-Metode untuk mengambil detail hoster dari konteks.
-Model hoster dikembalikan jika ditemukan.
+Metode untuk mencari akun pemilik atau staf toko berdasarkan email.
+Keanggotaan beserta hash password dikembalikan, atau nil jika tidak ada akun aktif.
 */
-func (s *hosterService) GetDetailHoster(ctx context.Context) (*model.HosterModel, error) {
-	id, ok := ctx.Value(middleware.UserIDKey).(string)
-	if !ok {
-		return nil, errors.New("invalid token claims")
+func (s *hosterService) accountByEmail(email string) (*model.HosterStaffModel, error) {
+	hoster, err := s.repo.FindByEmailHosterForLogin(email)
+	if err != nil {
+		return nil, err
+	}
+	if hoster != nil {
+		return ownerMembership(hoster), nil
 	}
 
-	hoster, err := s.repo.GetDetailHoster(id)
+	staff, err := s.repo.FindStaffByEmail(email)
 	if err != nil {
 		return nil, err
 	}
-	if hoster == nil {
-		return nil, errors.New("hoster not found")
+	// Staf yang belum menerima undangan belum memiliki password
+	if staff == nil || staff.AcceptedAt == nil || staff.PasswordHash == "" {
+		return nil, nil
 	}
-
-	return hoster, nil
+	return staff, nil
 }
 
 /*
-Metode untuk membuat item baru untuk hoster.
-Item divalidasi, dicek duplikasi nama, dan dibuat di database.
+Metode untuk mengautentikasi hoster dengan email dan password.
+Respons token dikembalikan jika berhasil.
 */
-func (s *hosterService) CreateItem(ctx context.Context, input *model.ItemModel) (*model.ItemModel, error) {
-	userID, ok := ctx.Value(middleware.UserIDKey).(string)
-	if !ok {
-		return nil, errors.New("invalid token claims")
-	}
-
-	input.Name = strings.TrimSpace(input.Name)
-	input.Description = strings.TrimSpace(input.Description)
-
-	if input.Name == "" {
-		return nil, errors.New(message.MsgItemNameRequired)
-	}
-
-	if input.CategoryID == "" {
-		return nil, errors.New("category ID is required")
-	}
-
-	if input.Stock < 0 {
-		return nil, errors.New(message.MsgItemStockInvalid)
-	}
-
-	if input.PricePerDay < 0 {
-		return nil, errors.New(message.MsgItemPricePerDayInvalid)
-	}
-
-	if input.Deposit < 0 {
-		return nil, errors.New(message.MsgItemDepositInvalid)
+func (s *hosterService) LoginHoster(email, password string, client auth.ClientInfo) (*HosterResponse, error) {
+	// Tolak lebih awal jika email atau IP sedang diblokir
+	if err := s.guard.Check("hoster", email, client); err != nil {
+		return nil, err
 	}
 
-	existing, err := s.repo.FindItemNameByUserID(input.Name, userID)
+	// Pemilik toko dan staf login melalui endpoint yang sama
+	member, err := s.accountByEmail(email)
 	if err != nil {
 		return nil, err
 	}
-	if existing != nil {
-		return nil, errors.New(message.MsgItemNameExists)
+	if member == nil || bcrypt.CompareHashAndPassword([]byte(member.PasswordHash), []byte(password)) != nil {
+		s.guard.RecordFailure("hoster", email, client)
+		return nil, errors.New("invalid credentials")
 	}
 
-	input.ID = uuid.New().String()
-	input.UserID = userID
-
-	if err := s.repo.CreateItem(input); err != nil {
+	resp, err := s.completeLoginHoster(member, client)
+	if err != nil {
 		return nil, err
 	}
-
-	return s.repo.FindItemNameByID(input.ID)
+	if !resp.MFARequired {
+		s.guard.RecordSuccess("hoster", email)
+	}
+	return resp, nil
 }
 
 /*
-Metode untuk mengambil item berdasarkan ID.
-Model item dikembalikan jika ditemukan.
+Metode untuk menyelesaikan login hoster setelah identitas terbukti.
+Token tantangan dikembalikan jika MFA aktif, selain itu pasangan token sesi baru.
 */
-func (s *hosterService) GetItemByID(id string) (*model.ItemModel, error) {
-	if id == "" {
-		return nil, errors.New(message.MsgItemIDRequired)
-	}
-
-	item, err := s.repo.FindItemNameByID(id)
+func (s *hosterService) completeLoginHoster(member *model.HosterStaffModel, client auth.ClientInfo) (*HosterResponse, error) {
+	// Login dengan MFA aktif dilanjutkan melalui token tantangan
+	enabled, err := s.mfa.IsEnabled(member.ID, "hoster")
 	if err != nil {
 		return nil, err
 	}
-	if item == nil {
-		return nil, errors.New(message.MsgItemNotFound)
+	if enabled {
+		challenge, expiresIn, err := s.mfa.IssueChallenge(member.ID, "hoster", member.Email)
+		if err != nil {
+			return nil, err
+		}
+		return &HosterResponse{ID: member.ID, MFARequired: true, ChallengeToken: challenge, ExpiresIn: expiresIn}, nil
 	}
 
-	return item, nil
+	return s.generateTokenHoster(member, false, client)
 }
 
 /*
-Metode untuk mengambil semua item.
-Daftar model item dikembalikan.
+Metode untuk membuat URL login hoster melalui penyedia OpenID Connect.
+URL otorisasi dengan state dan PKCE dikembalikan.
 */
-func (s *hosterService) GetAllItems() ([]*model.ItemModel, error) {
-	return s.repo.GetAllItems()
+func (s *hosterService) OIDCAuthorizeHoster() (string, error) {
+	return s.oidc.AuthorizationURL("hoster")
 }
 
 /*
-Metode untuk memperbarui item berdasarkan ID.
-Item diperbarui jika ditemukan.
+Metode untuk menyelesaikan login hoster melalui penyedia OpenID Connect.
+Akun ditautkan atau dibuat berdasarkan email terverifikasi, lalu token atau tantangan MFA dikembalikan.
 */
-func (s *hosterService) UpdateItem(ctx context.Context, id string, input *model.ItemModel) (*model.ItemModel, error) {
-	userID, ok := ctx.Value(middleware.UserIDKey).(string)
-	if !ok {
-		return nil, errors.New("invalid token claims")
+func (s *hosterService) LoginOIDCHoster(state, code string, client auth.ClientInfo) (*HosterResponse, error) {
+	identity, err := s.oidc.Exchange("hoster", state, code)
+	if err != nil {
+		return nil, err
 	}
 
-	existing, err := s.repo.FindItemNameByID(id)
+	userID, err := s.oidc.LinkedUserID(identity, "hoster")
 	if err != nil {
 		return nil, err
 	}
-	if existing == nil {
-		return nil, errors.New(message.MsgItemNotFound)
+	var member *model.HosterStaffModel
+	if userID != "" {
+		if member, err = s.membership(userID); err != nil {
+			return nil, err
+		}
 	}
-	if existing.UserID != userID {
-		return nil, errors.New("unauthorized")
+	if member == nil {
+		if member, err = s.linkOIDCHoster(identity); err != nil {
+			return nil, err
+		}
 	}
 
-	input.Name = strings.TrimSpace(input.Name)
-	input.Description = strings.TrimSpace(input.Description)
+	return s.completeLoginHoster(member, client)
+}
 
-	if input.Name == "" {
-		return nil, errors.New(message.MsgItemNameRequired)
+/*
+Metode untuk menautkan identitas OpenID Connect ke akun hoster berdasarkan email.
+Akun pemilik terverifikasi atau staf yang ada ditautkan tanpa mengubah password dan sesinya, pemilik yang emailnya belum terverifikasi ditolak, atau akun pemilik toko baru dibuat jika email belum terdaftar.
+*/
+func (s *hosterService) linkOIDCHoster(identity *auth.OIDCIdentity) (*model.HosterStaffModel, error) {
+	if !identity.EmailVerified || identity.Email == "" {
+		return nil, auth.ErrOIDCEmailUnverified
 	}
 
-	if input.Stock < 0 {
-		return nil, errors.New(message.MsgItemStockInvalid)
+	member, err := s.accountByEmail(identity.Email)
+	if err != nil {
+		return nil, err
 	}
+	if member != nil {
+		// Staf sudah membuktikan email saat menerima undangan, pemilik harus sudah verifikasi email
+		if member.Role == model.StoreRoleOwner {
+			hoster, err := s.repo.GetDetailHoster(member.ID)
+			if err != nil {
+				return nil, err
+			}
+			if hoster == nil || hoster.EmailVerifiedAt == nil {
+				return nil, auth.ErrOIDCAccountUnverified
+			}
+		}
+	} else {
+		// Undangan staf yang belum diterima tetap memakai email tersebut
+		staff, err := s.repo.FindStaffByEmail(identity.Email)
+		if err != nil {
+			return nil, err
+		}
+		if staff != nil {
+			return nil, errors.New(message.MsgHosterEmailExists)
+		}
 
-	if input.PricePerDay < 0 {
-		return nil, errors.New(message.MsgItemPricePerDayInvalid)
-	}
+		hoster, err := newOIDCHoster(identity)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.CreateHoster(hoster); err != nil {
+			if strings.Contains(err.Error(), "duplicate") {
+				return nil, errors.New(message.MsgHosterEmailExists)
+			}
+			return nil, err
+		}
+		member = ownerMembership(hoster)
 
-	if input.Deposit < 0 {
-		return nil, errors.New(message.MsgItemDepositInvalid)
+		// Penyedia sudah memverifikasi email sehingga akun pemilik baru ikut ditandai terverifikasi
+		if _, err := s.repo.MarkEmailVerifiedHoster(member.ID, member.Email); err != nil {
+			return nil, err
+		}
 	}
 
-	input.ID = id
-	input.UserID = userID
-	input.UpdatedAt = time.Now()
-
-	if err := s.repo.UpdateItem(input); err != nil {
+	if err := s.oidc.Link(identity, "hoster", member.ID); err != nil {
 		return nil, err
 	}
-
-	return s.repo.FindItemNameByID(id)
+	return member, nil
 }
 
 /*
-Metode untuk menghapus item berdasarkan ID.
-Item dihapus jika ditemukan dan milik user.
+Metode untuk menyelesaikan login hoster dengan kode MFA.
+Pasangan token dikembalikan jika token tantangan dan kode OTP atau kode pemulihan valid.
 */
-func (s *hosterService) DeleteItem(ctx context.Context, id string) error {
-	userID, ok := ctx.Value(middleware.UserIDKey).(string)
-	if !ok {
-		return errors.New("invalid token claims")
+func (s *hosterService) VerifyMFAHoster(challengeToken, code string, client auth.ClientInfo) (*HosterResponse, error) {
+	challenge, err := s.mfa.ParseChallenge(challengeToken, "hoster")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.guard.Check("hoster", challenge.Email, client); err != nil {
+		return nil, err
 	}
 
-	existing, err := s.repo.FindItemNameByID(id)
-	if err != nil {
-		return err
+	if err := s.mfa.Verify(challenge.UserID, "hoster", code); err != nil {
+		if errors.Is(err, auth.ErrMFACodeInvalid) {
+			s.guard.RecordFailure("hoster", challenge.Email, client)
+		}
+		return nil, err
 	}
-	if existing == nil {
-		return errors.New(message.MsgItemNotFound)
+
+	member, err := s.membership(challenge.UserID)
+	if err != nil {
+		return nil, err
 	}
-	if existing.UserID != userID {
-		return errors.New("unauthorized")
+	if member == nil {
+		return nil, auth.ErrMFAChallengeInvalid
 	}
 
-	return s.repo.DeleteItem(id)
+	s.guard.RecordSuccess("hoster", challenge.Email)
+	return s.generateTokenHoster(member, true, client)
 }
 
 /*
-Metode untuk membuat terms and conditions baru untuk hoster.
-Terms and conditions divalidasi dan dibuat di database.
+Metode untuk merotasi refresh token hoster.
+Pasangan token baru dikembalikan jika refresh token valid.
 */
-func (s *hosterService) CreateTermsAndConditions(ctx context.Context, input *model.TermsAndConditionsModel) (*model.TermsAndConditionsModel, error) {
-	userID, ok := ctx.Value(middleware.UserIDKey).(string)
-	if !ok {
-		return nil, errors.New("invalid token claims")
+func (s *hosterService) RefreshTokenHoster(refreshToken string) (*HosterResponse, error) {
+	next, raw, err := s.tokens.RotateRefreshToken(refreshToken, "hoster")
+	if err != nil {
+		return nil, err
 	}
 
-	input.ID = uuid.New().String()
-	input.UserID = userID
-
-	if err := s.repo.CreateTermsAndConditions(input); err != nil {
+	// Klaim toko dibaca ulang agar perubahan role atau penghapusan staf langsung berlaku
+	member, err := s.membership(next.UserID)
+	if err != nil {
 		return nil, err
 	}
+	if member == nil {
+		return nil, auth.ErrRefreshTokenInvalid
+	}
 
-	return s.repo.FindTermsAndConditionsByID(input.ID)
+	// Status MFA mengikuti pengguna karena semua sesi dicabut saat MFA diaktifkan
+	mfa, err := s.mfa.IsEnabled(next.UserID, "hoster")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.generateAccessTokenHoster(member, next.FamilyID, mfa)
+	if err != nil {
+		return nil, err
+	}
+	resp.RefreshToken = raw
+	return resp, nil
 }
 
 /*
-Metode untuk mengambil terms and conditions berdasarkan ID.
-Model terms and conditions dikembalikan jika ditemukan.
+Metode untuk logout hoster.
+Refresh token beserta keluarganya dicabut.
 */
-func (s *hosterService) FindTermsAndConditionsByID(id string) (*model.TermsAndConditionsModel, error) {
-	if id == "" {
-		return nil, errors.New("ID is required")
+func (s *hosterService) LogoutHoster(ctx context.Context, refreshToken string) error {
+	if _, err := s.tokens.RevokeRefreshToken(refreshToken, "hoster"); err != nil {
+		return err
 	}
 
-	tac, err := s.repo.FindTermsAndConditionsByID(id)
-	if err != nil {
-		return nil, err
-	}
-	if tac == nil {
-		return nil, errors.New("terms and conditions not found")
+	// Cabut access token yang sedang dipakai jika disertakan
+	claims := middleware.GetClaims(ctx)
+	if claims != nil && claims.Role == "hoster" && claims.ExpiresAt != nil {
+		return s.revocation.RevokeToken(claims.ID, claims.Subject, claims.Role, claims.ExpiresAt.Time)
 	}
-
-	return tac, nil
+	return nil
 }
 
 /*
-Metode untuk mengambil semua terms and conditions.
-Daftar model terms and conditions dikembalikan.
+Metode untuk meminta tautan reset password hoster.
+Tautan dikirim jika email terdaftar tanpa membocorkan keberadaan akun.
 */
-func (s *hosterService) GetAllTermsAndConditions() ([]*model.TermsAndConditionsModel, error) {
-	return s.repo.GetAllTermsAndConditions()
+func (s *hosterService) ForgotPasswordHoster(email string) error {
+	member, err := s.accountByEmail(strings.TrimSpace(email))
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return nil
+	}
+	return s.reset.SendResetLink(member.ID, "hoster", member.Email, member.FullName)
 }
 
 /*
-Metode untuk memperbarui terms and conditions berdasarkan ID.
-Terms and conditions diperbarui jika ditemukan.
+Metode untuk mengatur ulang password hoster dengan token reset.
+Password diperbarui dan semua sesi hoster dicabut jika token valid.
 */
-func (s *hosterService) UpdateTermsAndConditions(ctx context.Context, id string, input *model.TermsAndConditionsModel) (*model.TermsAndConditionsModel, error) {
-	userID, ok := ctx.Value(middleware.UserIDKey).(string)
-	if !ok {
-		return nil, errors.New("invalid token claims")
+func (s *hosterService) ResetPasswordHoster(token, password string) error {
+	userID, err := s.reset.Peek(token, "hoster")
+	if err != nil {
+		return err
 	}
 
-	existing, err := s.repo.FindTermsAndConditionsByID(id)
+	member, err := s.membership(userID)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if existing == nil {
-		return nil, errors.New("terms and conditions not found")
+	if member == nil {
+		return auth.ErrResetTokenInvalid
+	}
+	if err := s.passwords.Validate(password, member.Email, member.FullName); err != nil {
+		return err
 	}
-	if existing.UserID != userID {
-		return nil, errors.New("unauthorized")
+	if _, err := s.reset.Consume(token, "hoster"); err != nil {
+		return err
 	}
 
-	input.ID = id
-	input.UserID = userID
-
-	if err := s.repo.UpdateTermsAndConditions(input); err != nil {
-		return nil, err
+	if err := s.updatePassword(member, password); err != nil {
+		return err
 	}
 
-	return s.repo.FindTermsAndConditionsByID(id)
+	return s.revocation.RevokeAllForUser(userID, "hoster")
 }
 
 /*
-Metode untuk menghapus terms and conditions berdasarkan ID.
-Terms and conditions dihapus jika ditemukan dan milik user.
+Metode untuk mengganti password hoster atau staf yang sedang login.
+Password lama diverifikasi, password baru divalidasi terhadap kebijakan, lalu semua sesi dicabut.
 */
-func (s *hosterService) DeleteTermsAndConditions(ctx context.Context, id string) error {
+func (s *hosterService) ChangePasswordHoster(ctx context.Context, currentPassword, newPassword string) error {
 	userID, ok := ctx.Value(middleware.UserIDKey).(string)
-	if !ok {
+	if !ok || userID == "" {
 		return errors.New("invalid token claims")
 	}
-
-	existing, err := s.repo.FindTermsAndConditionsByID(id)
+	if currentPassword == "" {
+		return errors.New(message.MsgPasswordCurrentRequired)
+	}
+	member, err := s.membership(userID)
 	if err != nil {
 		return err
 	}
-	if existing == nil {
-		return errors.New("terms and conditions not found")
+	if member == nil {
+		return errors.New(message.MsgUnauthorized)
 	}
-	if existing.UserID != userID {
-		return errors.New("unauthorized")
+	if bcrypt.CompareHashAndPassword([]byte(member.PasswordHash), []byte(currentPassword)) != nil {
+		return errors.New(message.MsgPasswordCurrentInvalid)
+	}
+	if currentPassword == newPassword {
+		return errors.New(message.MsgPasswordUnchanged)
+	}
+	if err := s.passwords.Validate(newPassword, member.Email, member.FullName); err != nil {
+		return err
 	}
 
-	return s.repo.DeleteTermsAndConditions(id)
+	if err := s.updatePassword(member, newPassword); err != nil {
+		return err
+	}
+
+	return s.revocation.RevokeAllForUser(userID, "hoster")
 }
 
 /*
-Struktur untuk respons hoster.
-Struktur ini berisi data token dan informasi pengguna.
+Metode untuk menyimpan password baru pemilik atau staf toko.
+Password di-hash lalu disimpan ke tabel sesuai peran anggota.
 */
-type HosterResponse struct {
-	ID           string `json:"id"`
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	TokenType    string `json:"token_type"`
-	ExpiresIn    int    `json:"expires_in"`
+func (s *hosterService) updatePassword(member *model.HosterStaffModel, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New(message.MsgFailedToHashPassword)
+	}
+	if member.Role == model.StoreRoleOwner {
+		return s.repo.UpdatePasswordHoster(member.ID, string(hash))
+	}
+	return s.repo.UpdatePasswordStaff(member.ID, string(hash))
 }
 
 /*
-Antarmuka untuk layanan hoster.
-Antarmuka ini mendefinisikan metode untuk operasi hoster.
+Metode untuk memulai pendaftaran MFA hoster.
+Secret TOTP dan URI provisioning untuk kode QR dikembalikan.
+*/
+func (s *hosterService) EnrollMFAHoster(ctx context.Context) (*auth.MFAEnrollment, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	member, err := s.membership(userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, errors.New(message.MsgUnauthorized)
+	}
+	return s.mfa.BeginEnrollment(userID, "hoster", member.Email)
+}
+
+/*
+Metode untuk mengonfirmasi pendaftaran MFA hoster.
+Kode pemulihan dan pasangan token baru dikembalikan setelah semua sesi lama dicabut.
+*/
+func (s *hosterService) ConfirmMFAHoster(ctx context.Context, code string, client auth.ClientInfo) ([]string, *HosterResponse, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, nil, errors.New("invalid token claims")
+	}
+	member, err := s.membership(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if member == nil {
+		return nil, nil, errors.New(message.MsgUnauthorized)
+	}
+	codes, err := s.mfa.ConfirmEnrollment(userID, "hoster", code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sesi yang dibuat hanya dengan password tidak boleh naik level menjadi sesi MFA
+	if err := s.revocation.RevokeAllForUser(userID, "hoster"); err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.generateTokenHoster(member, true, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return codes, resp, nil
+}
+
+/*
+Metode untuk menonaktifkan MFA hoster.
+MFA dinonaktifkan jika kode OTP atau kode pemulihan valid.
+*/
+func (s *hosterService) DisableMFAHoster(ctx context.Context, code string) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	return s.mfa.Disable(userID, "hoster", code)
+}
+
+/*
+Metode untuk membuat ulang kode pemulihan MFA hoster.
+Kode pemulihan baru dikembalikan jika kode OTP valid.
+*/
+func (s *hosterService) RegenerateRecoveryCodesHoster(ctx context.Context, code string) ([]string, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	return s.mfa.RegenerateRecoveryCodes(userID, "hoster", code)
+}
+
+/*
+Metode untuk logout hoster dari semua perangkat.
+Semua access token dan refresh token milik hoster dicabut.
+*/
+func (s *hosterService) LogoutAllHoster(ctx context.Context) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	return s.revocation.RevokeAllForUser(userID, "hoster")
+}
+
+/*
+Metode untuk mengambil sesi login aktif milik hoster.
+Daftar sesi dikembalikan dengan sesi permintaan saat ini ditandai.
+*/
+func (s *hosterService) GetSessionsHoster(ctx context.Context) ([]*model.SessionModel, error) {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid token claims")
+	}
+	var currentSessionID string
+	if claims := middleware.GetClaims(ctx); claims != nil {
+		currentSessionID = claims.SessionID
+	}
+	return s.sessions.List(userID, "hoster", currentSessionID)
+}
+
+/*
+Metode untuk mengakhiri satu sesi login milik hoster dari jarak jauh.
+Refresh token sesi dicabut dan access token sesi tersebut ditolak.
+*/
+func (s *hosterService) TerminateSessionHoster(ctx context.Context, sessionID string) error {
+	userID, ok := ctx.Value(middleware.UserIDKey).(string)
+	if !ok || userID == "" {
+		return errors.New("invalid token claims")
+	}
+	return s.sessions.Terminate(userID, "hoster", sessionID)
+}
+
+/*
+Metode untuk membuat hoster baru dengan hashing password.
+Hoster berhasil dibuat atau error dikembalikan.
+*/
+func (s *hosterService) CreateHoster(hoster *model.HosterModel) error {
+	// Email staf toko juga dipakai untuk login hoster sehingga tidak boleh bentrok
+	staff, err := s.repo.FindStaffByEmail(hoster.Email)
+	if err != nil {
+		return err
+	}
+	if staff != nil {
+		return errors.New(message.MsgHosterEmailExists)
+	}
+	if err := s.passwords.Validate(hoster.PasswordHash, hoster.Email, hoster.FullName, hoster.StoreName); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(hoster.PasswordHash), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	hoster.PasswordHash = string(hash)
+	hoster.CreatedAt = time.Now()
+	hoster.UpdatedAt = time.Now()
+
+	err = s.repo.CreateHoster(hoster)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate") {
+			return errors.New(message.MsgHosterEmailExists)
+		}
+		return err
+	}
+
+	// Kirim tautan verifikasi tanpa menggagalkan pendaftaran
+	if err := s.verifier.SendVerification(hoster.ID, "hoster", hoster.Email, hoster.FullName); err != nil {
+		log.Printf("CreateHoster: failed to send verification email to %s: %v", hoster.Email, err)
+	}
+
+	return nil
+}
+
+/*
+Metode untuk memverifikasi email hoster dengan token dari tautan.
+Email ditandai terverifikasi jika token valid.
+*/
+func (s *hosterService) VerifyEmailHoster(token string) error {
+	claims, err := s.verifier.Verify(token, "hoster")
+	if err != nil {
+		return err
+	}
+	verified, err := s.repo.MarkEmailVerifiedHoster(claims.UserID, claims.Email)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		existing, err := s.repo.GetDetailHoster(claims.UserID)
+		if err != nil {
+			return err
+		}
+		// Tautan lama tetap dianggap berhasil jika email sudah terverifikasi
+		if existing == nil || existing.EmailVerifiedAt == nil || !strings.EqualFold(existing.Email, claims.Email) {
+			return auth.ErrVerificationTokenInvalid
+		}
+	}
+	return nil
+}
+
+/*
+Metode untuk mengirim ulang email verifikasi hoster.
+Email dikirim hanya jika akun ada dan belum terverifikasi.
+*/
+func (s *hosterService) ResendVerificationHoster(email string) error {
+	hoster, err := s.repo.FindByEmailHosterForLogin(strings.TrimSpace(email))
+	if err != nil {
+		return err
+	}
+	if hoster == nil || hoster.EmailVerifiedAt != nil {
+		return nil
+	}
+	return s.verifier.SendVerification(hoster.ID, "hoster", hoster.Email, hoster.FullName)
+}
+
+/*
+Metode untuk mengambil detail toko hoster dari konteks.
+Model hoster pemilik toko dikembalikan jika ditemukan, termasuk untuk staf.
+*/
+func (s *hosterService) GetDetailHoster(ctx context.Context) (*model.HosterModel, error) {
+	_, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hoster, err := s.repo.GetDetailHoster(storeID)
+	if err != nil {
+		return nil, err
+	}
+	if hoster == nil {
+		return nil, errors.New("hoster not found")
+	}
+
+	return hoster, nil
+}
+
+/*
+Metode untuk membuat item baru untuk hoster.
+Item divalidasi, dicek duplikasi nama, dan dibuat di database.
+*/
+func (s *hosterService) CreateItem(ctx context.Context, input *model.ItemModel) (*model.ItemModel, error) {
+	_, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.HosterRequiresVerifiedEmail() {
+		hoster, err := s.repo.GetDetailHoster(storeID)
+		if err != nil {
+			return nil, err
+		}
+		if hoster == nil {
+			return nil, errors.New(message.MsgHosterNotFound)
+		}
+		if hoster.EmailVerifiedAt == nil {
+			return nil, errors.New(message.MsgEmailNotVerified)
+		}
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+
+	if input.Name == "" {
+		return nil, errors.New(message.MsgItemNameRequired)
+	}
+
+	if input.CategoryID == "" {
+		return nil, errors.New("category ID is required")
+	}
+
+	if input.Stock < 0 {
+		return nil, errors.New(message.MsgItemStockInvalid)
+	}
+
+	if input.PricePerDay < 0 {
+		return nil, errors.New(message.MsgItemPricePerDayInvalid)
+	}
+
+	if input.Deposit < 0 {
+		return nil, errors.New(message.MsgItemDepositInvalid)
+	}
+
+	if err := validateItemPricing(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindItemNameByUserID(input.Name, storeID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New(message.MsgItemNameExists)
+	}
+
+	// Item dimiliki toko, bukan akun yang membuatnya
+	input.ID = uuid.New().String()
+	input.UserID = storeID
+
+	if err := s.repo.CreateItem(input); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindItemNameByID(input.ID)
+}
+
+/*
+Metode untuk mengambil item berdasarkan ID.
+Model item dikembalikan jika ditemukan.
+*/
+func (s *hosterService) GetItemByID(id string) (*model.ItemModel, error) {
+	if id == "" {
+		return nil, errors.New(message.MsgItemIDRequired)
+	}
+
+	item, err := s.repo.FindItemNameByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, errors.New(message.MsgItemNotFound)
+	}
+
+	return item, nil
+}
+
+/*
+Metode untuk mengambil semua item.
+Daftar model item dikembalikan.
+*/
+func (s *hosterService) GetAllItems() ([]*model.ItemModel, error) {
+	return s.repo.GetAllItems()
+}
+
+/*
+Metode untuk memperbarui item berdasarkan ID.
+Item diperbarui jika ditemukan.
+*/
+func (s *hosterService) UpdateItem(ctx context.Context, id string, input *model.ItemModel) (*model.ItemModel, error) {
+	_, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindItemNameByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, errors.New(message.MsgItemNotFound)
+	}
+	if existing.UserID != storeID {
+		return nil, errors.New(message.MsgStoreAccessDenied)
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+
+	if input.Name == "" {
+		return nil, errors.New(message.MsgItemNameRequired)
+	}
+
+	if input.Stock < 0 {
+		return nil, errors.New(message.MsgItemStockInvalid)
+	}
+
+	if input.PricePerDay < 0 {
+		return nil, errors.New(message.MsgItemPricePerDayInvalid)
+	}
+
+	if input.Deposit < 0 {
+		return nil, errors.New(message.MsgItemDepositInvalid)
+	}
+
+	if err := validateItemPricing(input); err != nil {
+		return nil, err
+	}
+
+	input.ID = id
+	input.UserID = storeID
+	input.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateItem(input); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindItemNameByID(id)
+}
+
+/*
+Metode untuk menghapus item berdasarkan ID.
+Item dihapus jika ditemukan dan milik user.
+*/
+func (s *hosterService) DeleteItem(ctx context.Context, id string) error {
+	_, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindItemNameByID(id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.New(message.MsgItemNotFound)
+	}
+	if existing.UserID != storeID {
+		return errors.New(message.MsgStoreAccessDenied)
+	}
+
+	return s.repo.DeleteItem(id)
+}
+
+/*
+Metode untuk membuat terms and conditions baru untuk hoster.
+Terms and conditions divalidasi dan dibuat di database.
+*/
+func (s *hosterService) CreateTermsAndConditions(ctx context.Context, input *model.TermsAndConditionsModel) (*model.TermsAndConditionsModel, error) {
+	_, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.ID = uuid.New().String()
+	input.UserID = storeID
+
+	if err := s.repo.CreateTermsAndConditions(input); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindTermsAndConditionsByID(input.ID)
+}
+
+/*
+Metode untuk mengambil terms and conditions berdasarkan ID.
+Model terms and conditions dikembalikan jika ditemukan.
+*/
+func (s *hosterService) FindTermsAndConditionsByID(id string) (*model.TermsAndConditionsModel, error) {
+	if id == "" {
+		return nil, errors.New("ID is required")
+	}
+
+	tac, err := s.repo.FindTermsAndConditionsByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if tac == nil {
+		return nil, errors.New("terms and conditions not found")
+	}
+
+	return tac, nil
+}
+
+/*
+Metode untuk mengambil semua terms and conditions.
+Daftar model terms and conditions dikembalikan.
+*/
+func (s *hosterService) GetAllTermsAndConditions() ([]*model.TermsAndConditionsModel, error) {
+	return s.repo.GetAllTermsAndConditions()
+}
+
+/*
+Metode untuk memperbarui terms and conditions berdasarkan ID.
+Terms and conditions diperbarui jika ditemukan.
+*/
+func (s *hosterService) UpdateTermsAndConditions(ctx context.Context, id string, input *model.TermsAndConditionsModel) (*model.TermsAndConditionsModel, error) {
+	_, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindTermsAndConditionsByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, errors.New("terms and conditions not found")
+	}
+	if existing.UserID != storeID {
+		return nil, errors.New(message.MsgStoreAccessDenied)
+	}
+
+	input.ID = id
+	input.UserID = storeID
+
+	if err := s.repo.UpdateTermsAndConditions(input); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindTermsAndConditionsByID(id)
+}
+
+/*
+Metode untuk menghapus terms and conditions berdasarkan ID.
+Terms and conditions dihapus jika ditemukan dan milik user.
+*/
+func (s *hosterService) DeleteTermsAndConditions(ctx context.Context, id string) error {
+	_, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindTermsAndConditionsByID(id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return errors.New("terms and conditions not found")
+	}
+	if existing.UserID != storeID {
+		return errors.New(message.MsgStoreAccessDenied)
+	}
+
+	return s.repo.DeleteTermsAndConditions(id)
+}
+
+/*
+Metode untuk mengundang staf ke toko hoster.
+Tautan undangan dikirim ke email staf dan data undangan dikembalikan.
+*/
+func (s *hosterService) InviteStaff(ctx context.Context, email, fullName, role string) (*model.HosterStaffModel, error) {
+	userID, storeID, storeRole, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if storeRole != model.StoreRoleOwner {
+		return nil, errors.New(message.MsgStaffOwnerOnly)
+	}
+	if role != model.StoreRoleManager && role != model.StoreRoleCounter {
+		return nil, errors.New(message.MsgStaffRoleInvalid)
+	}
+
+	// Email staf tidak boleh sama dengan akun pemilik toko mana pun
+	email = strings.TrimSpace(email)
+	hoster, err := s.repo.FindByEmailHosterForLogin(email)
+	if err != nil {
+		return nil, err
+	}
+	if hoster != nil {
+		return nil, errors.New(message.MsgStaffEmailExists)
+	}
+
+	raw, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	ttl := config.GetStaffInvitationTTL()
+	expiresAt := time.Now().Add(ttl)
+	staff := &model.HosterStaffModel{
+		StoreID:         storeID,
+		Email:           email,
+		FullName:        strings.TrimSpace(fullName),
+		Role:            role,
+		InvitedBy:       userID,
+		InviteTokenHash: auth.HashToken(raw),
+		InviteExpiresAt: &expiresAt,
+	}
+	created, err := s.repo.CreateStaffInvitation(staff)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, errors.New(message.MsgStaffEmailExists)
+	}
+
+	store, err := s.repo.GetDetailHoster(storeID)
+	if err != nil {
+		return nil, err
+	}
+	storeName := "a store"
+	if store != nil && store.StoreName != "" {
+		storeName = store.StoreName
+	}
+
+	link := fmt.Sprintf("%s/hoster/accept-invitation?token=%s", config.GetFrontendURL(), url.QueryEscape(raw))
+	body := fmt.Sprintf("Hi,\n\nYou have been invited to join %s on Lalan as %s staff.\nOpen the link below within %d hours to set up your account:\n\n%s\n\nIf you did not expect this invitation, you can ignore this email.\n",
+		storeName, role, int(ttl.Hours()), link)
+	if err := s.mailer.Send(mailer.Message{
+		To:      email,
+		Subject: "You are invited to join " + storeName + " on Lalan",
+		Body:    body,
+	}); err != nil {
+		return nil, err
+	}
+
+	return staff, nil
+}
+
+/*
+Metode untuk menerima undangan staf toko.
+Akun staf diaktifkan dengan password sendiri jika token undangan valid.
+*/
+func (s *hosterService) AcceptStaffInvitation(token, fullName, password string) (*model.HosterStaffModel, error) {
+	if strings.TrimSpace(token) == "" {
+		return nil, errors.New(message.MsgStaffInvitationInvalid)
+	}
+	invited, err := s.repo.FindStaffByInviteHash(auth.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if invited == nil {
+		return nil, errors.New(message.MsgStaffInvitationInvalid)
+	}
+	if err := s.passwords.Validate(password, invited.Email, fullName); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.New(message.MsgFailedToHashPassword)
+	}
+
+	staff, err := s.repo.AcceptStaffInvitation(auth.HashToken(token), strings.TrimSpace(fullName), string(hash))
+	if err != nil {
+		return nil, err
+	}
+	if staff == nil {
+		return nil, errors.New(message.MsgStaffInvitationInvalid)
+	}
+
+	return staff, nil
+}
+
+/*
+Metode untuk mengambil daftar staf toko dari konteks.
+Daftar staf aktif dan undangan yang menunggu dikembalikan.
+*/
+func (s *hosterService) GetStaff(ctx context.Context) ([]*model.HosterStaffModel, error) {
+	_, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetStaffByStore(storeID)
+}
+
+/*
+Metode untuk mengubah role staf toko.
+Sesi staf dicabut agar klaim role baru langsung berlaku.
+*/
+func (s *hosterService) UpdateStaffRole(ctx context.Context, id, role string) error {
+	_, storeID, storeRole, err := storeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if storeRole != model.StoreRoleOwner {
+		return errors.New(message.MsgStaffOwnerOnly)
+	}
+	if strings.TrimSpace(id) == "" {
+		return errors.New(message.MsgStaffIDRequired)
+	}
+	if role != model.StoreRoleManager && role != model.StoreRoleCounter {
+		return errors.New(message.MsgStaffRoleInvalid)
+	}
+
+	updated, err := s.repo.UpdateStaffRole(storeID, id, role)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return errors.New(message.MsgStaffNotFound)
+	}
+
+	s.invalidatePermissions(id)
+	if s.apiKeys != nil {
+		s.apiKeys.InvalidateAll()
+	}
+	return s.revocation.RevokeAllForUser(id, "hoster")
+}
+
+/*
+Metode untuk membuat API key toko dengan scope tertentu.
+Kunci mentah hanya dikembalikan sekali bersama data API key.
+*/
+func (s *hosterService) CreateAPIKey(ctx context.Context, name string, scopes []string) (*APIKeyResponse, error) {
+	userID, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New(message.MsgAPIKeyNameRequired)
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New(message.MsgAPIKeyScopesRequired)
+	}
+
+	// Scope harus termasuk daftar API key dan dimiliki pembuatnya
+	granted := middleware.GetPermissions(ctx)
+	unique := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !auth.IsAPIKeyScope(scope) || !granted.Has(scope) {
+			return nil, errors.New(message.MsgAPIKeyScopeInvalid)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	raw, prefix, keyHash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	key := &model.APIKeyModel{
+		StoreID:   storeID,
+		CreatedBy: userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    unique,
+	}
+	if err := s.repo.CreateAPIKey(key); err != nil {
+		return nil, err
+	}
+	return &APIKeyResponse{APIKeyModel: key, Key: raw}, nil
+}
+
+/*
+Metode untuk mengambil API key aktif milik toko.
+Daftar API key tanpa kunci mentah dikembalikan.
+*/
+func (s *hosterService) GetAPIKeys(ctx context.Context) ([]*model.APIKeyModel, error) {
+	_, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetAPIKeysByStore(storeID)
+}
+
+/*
+Metode untuk mencabut API key milik toko.
+API key langsung ditolak pada permintaan berikutnya.
+*/
+func (s *hosterService) RevokeAPIKey(ctx context.Context, id string) error {
+	_, storeID, _, err := storeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(id) == "" {
+		return errors.New(message.MsgAPIKeyIDRequired)
+	}
+
+	revoked, err := s.repo.RevokeAPIKey(storeID, id)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New(message.MsgAPIKeyNotFound)
+	}
+
+	if s.apiKeys != nil {
+		s.apiKeys.Invalidate(id)
+	}
+	return nil
+}
+
+/*
+Metode untuk menghapus staf dari toko.
+Akun staf dihapus dan semua sesinya dicabut.
+*/
+func (s *hosterService) RemoveStaff(ctx context.Context, id string) error {
+	_, storeID, storeRole, err := storeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if storeRole != model.StoreRoleOwner {
+		return errors.New(message.MsgStaffOwnerOnly)
+	}
+	if strings.TrimSpace(id) == "" {
+		return errors.New(message.MsgStaffIDRequired)
+	}
+
+	removed, err := s.repo.DeleteStaff(storeID, id)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errors.New(message.MsgStaffNotFound)
+	}
+
+	s.invalidatePermissions(id)
+	return s.revocation.RevokeAllForUser(id, "hoster")
+}
+
+/*
+Metode untuk mengosongkan cache permission staf setelah perubahan role.
+Permission baru dibaca ulang dari database pada permintaan berikutnya.
+*/
+func (s *hosterService) invalidatePermissions(userID string) {
+	if s.permissions != nil {
+		s.permissions.Invalidate(userID, "hoster")
+	}
+}
+
+/*
+Struktur untuk respons pembuatan API key.
+Struktur ini berisi data API key beserta kunci mentah yang hanya ditampilkan sekali.
+*/
+type APIKeyResponse struct {
+	*model.APIKeyModel
+	Key string `json:"key"`
+}
+
+/*
+Struktur untuk respons hoster.
+Struktur ini berisi data token dan informasi pengguna.
+*/
+type HosterResponse struct {
+	ID           string `json:"id"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	StoreID      string `json:"store_id,omitempty"`
+	StoreRole    string `json:"store_role,omitempty"`
+	// Terisi jika login masih menunggu kode MFA
+	MFARequired    bool   `json:"mfa_required,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+}
+
+/*
+Metode untuk mengekspor semua data pribadi hoster atau staf yang sedang login.
+Pemilik toko menerima profil beserta item, syarat sewa, kebijakan pembatalan, booking termasuk yang akan datang, order, hold stok, entri deposit, staf, dan API key, sedangkan staf menerima data keanggotaannya.
+*/
+func (s *hosterService) ExportHoster(ctx context.Context) (*HosterExport, error) {
+	userID, storeID, role, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &HosterExport{ExportedAt: time.Now()}
+	if role == model.StoreRoleOwner {
+		hoster, err := s.repo.GetDetailHoster(userID)
+		if err != nil {
+			return nil, err
+		}
+		if hoster == nil {
+			return nil, errors.New(message.MsgHosterNotFound)
+		}
+		export.Profile = hoster
+		if export.Items, err = s.repo.GetItemsByUserID(userID); err != nil {
+			return nil, err
+		}
+		if export.TermsAndConditions, err = s.repo.FindTermsAndConditionsByUserID(userID); err != nil {
+			return nil, err
+		}
+		if export.CancellationPolicies, err = s.repo.GetCancellationPoliciesByStore(storeID); err != nil {
+			return nil, err
+		}
+		if export.Bookings, err = s.repo.GetBookingsByStore(storeID); err != nil {
+			return nil, err
+		}
+		if export.Orders, err = s.repo.GetOrdersByStore(storeID); err != nil {
+			return nil, err
+		}
+		if export.StockHolds, err = s.repo.GetStockHoldsByStore(storeID); err != nil {
+			return nil, err
+		}
+		if export.DepositEntries, err = s.repo.GetDepositEntriesByStore(storeID); err != nil {
+			return nil, err
+		}
+		if export.Staff, err = s.repo.GetStaffByStore(storeID); err != nil {
+			return nil, err
+		}
+		if export.APIKeys, err = s.repo.GetAPIKeysByStore(storeID); err != nil {
+			return nil, err
+		}
+	} else {
+		staff, err := s.repo.FindStaffByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		if staff == nil {
+			return nil, errors.New(message.MsgStaffNotFound)
+		}
+		export.Membership = staff
+	}
+
+	if export.Sessions, err = s.sessions.List(userID, "hoster", ""); err != nil {
+		return nil, err
+	}
+	if export.Identities, err = s.oidc.Identities(userID, "hoster"); err != nil {
+		return nil, err
+	}
+	if export.PendingDeletion, err = s.deletion.Pending(userID, "hoster"); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+/*
+Metode untuk menjadwalkan penghapusan akun pemilik toko yang sedang login.
+Password diverifikasi ulang dan jadwal penghapusan setelah masa tenggang dikembalikan, staf tidak dapat menghapus toko dan penghapusan ditolak selama masih ada barang yang disewa.
+*/
+func (s *hosterService) RequestDeletionHoster(ctx context.Context, password string) (*model.AccountDeletionModel, error) {
+	userID, _, role, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if role != model.StoreRoleOwner {
+		return nil, errors.New(message.MsgAccountDeletionOwnerOnly)
+	}
+	hoster, err := s.repo.GetDetailHoster(userID)
+	if err != nil {
+		return nil, err
+	}
+	if hoster == nil {
+		return nil, errors.New(message.MsgHosterNotFound)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hoster.PasswordHash), []byte(password)) != nil {
+		return nil, errors.New(message.MsgPasswordCurrentInvalid)
+	}
+	rentals, err := s.repo.CountActiveRentalsByStore(userID)
+	if err != nil {
+		return nil, err
+	}
+	if rentals > 0 {
+		return nil, errors.New(message.MsgAccountDeletionActiveRentals)
+	}
+	return s.deletion.Schedule(userID, "hoster")
+}
+
+/*
+Metode untuk mengambil jadwal penghapusan akun hoster yang sedang login.
+ErrAccountDeletionNotFound dikembalikan jika akun tidak sedang dijadwalkan untuk dihapus.
+*/
+func (s *hosterService) GetDeletionHoster(ctx context.Context) (*model.AccountDeletionModel, error) {
+	userID, _, _, err := storeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deletion, err := s.deletion.Pending(userID, "hoster")
+	if err != nil {
+		return nil, err
+	}
+	if deletion == nil {
+		return nil, auth.ErrAccountDeletionNotFound
+	}
+	return deletion, nil
+}
+
+/*
+Metode untuk membatalkan penghapusan akun pemilik toko selama masa tenggang.
+Toko tetap aktif seperti semula jika pembatalan berhasil.
+*/
+func (s *hosterService) CancelDeletionHoster(ctx context.Context) error {
+	userID, _, _, err := storeFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.deletion.Cancel(userID, "hoster")
+}
+
+/*
+Metode untuk memproses penghapusan akun pemilik toko yang sudah jatuh tempo.
+Booking yang belum diambil dibatalkan dengan refund penuh, data pribadi dianonimkan, staf dan API key dicabut, dan data autentikasi dihapus, sedangkan item dan riwayat sewa tetap tersimpan; toko yang barangnya masih disewa ditunda ke putaran berikutnya.
+*/
+func (s *hosterService) ProcessDeletionsHoster() {
+	deletions, err := s.deletion.Due("hoster")
+	if err != nil {
+		log.Printf("ProcessDeletionsHoster: error fetching due deletions: %v", err)
+		return
+	}
+	for _, deletion := range deletions {
+		hoster, err := s.repo.GetDetailHoster(deletion.UserID)
+		if err != nil {
+			log.Printf("ProcessDeletionsHoster: error fetching hoster %s: %v", deletion.UserID, err)
+			continue
+		}
+		var email string
+		if hoster != nil {
+			email = hoster.Email
+			staff, err := s.repo.AnonymizeHoster(deletion.UserID)
+			if err != nil {
+				log.Printf("ProcessDeletionsHoster: error anonymizing hoster %s: %v", deletion.UserID, err)
+				continue
+			}
+			s.apiKeys.InvalidateAll()
+			for _, member := range staff {
+				if err := s.deletion.Erase(member.ID, "hoster", member.Email); err != nil {
+					log.Printf("ProcessDeletionsHoster: error erasing staff %s: %v", member.ID, err)
+				}
+			}
+		}
+		if err := s.deletion.Finish(deletion, email); err != nil {
+			log.Printf("ProcessDeletionsHoster: error finishing deletion %s: %v", deletion.ID, err)
+		}
+	}
+}
+
+/*
+Antarmuka untuk layanan hoster.
+Antarmuka ini mendefinisikan metode untuk operasi hoster.
 */
 type HosterService interface {
 	CreateHoster(*model.HosterModel) error
-	LoginHoster(email, password string) (*HosterResponse, error)
+	LoginHoster(email, password string, client auth.ClientInfo) (*HosterResponse, error)
+	OIDCAuthorizeHoster() (string, error)
+	LoginOIDCHoster(state, code string, client auth.ClientInfo) (*HosterResponse, error)
+	RefreshTokenHoster(refreshToken string) (*HosterResponse, error)
+	VerifyMFAHoster(challengeToken, code string, client auth.ClientInfo) (*HosterResponse, error)
+	EnrollMFAHoster(ctx context.Context) (*auth.MFAEnrollment, error)
+	ConfirmMFAHoster(ctx context.Context, code string, client auth.ClientInfo) ([]string, *HosterResponse, error)
+	DisableMFAHoster(ctx context.Context, code string) error
+	RegenerateRecoveryCodesHoster(ctx context.Context, code string) ([]string, error)
+	LogoutHoster(ctx context.Context, refreshToken string) error
+	LogoutAllHoster(ctx context.Context) error
+	ExportHoster(ctx context.Context) (*HosterExport, error)
+	RequestDeletionHoster(ctx context.Context, password string) (*model.AccountDeletionModel, error)
+	GetDeletionHoster(ctx context.Context) (*model.AccountDeletionModel, error)
+	CancelDeletionHoster(ctx context.Context) error
+	ProcessDeletionsHoster()
+	ChangePasswordHoster(ctx context.Context, currentPassword, newPassword string) error
+	GetSessionsHoster(ctx context.Context) ([]*model.SessionModel, error)
+	TerminateSessionHoster(ctx context.Context, sessionID string) error
+	ForgotPasswordHoster(email string) error
+	ResetPasswordHoster(token, password string) error
+	VerifyEmailHoster(token string) error
+	ResendVerificationHoster(email string) error
 	GetDetailHoster(ctx context.Context) (*model.HosterModel, error)
 	CreateItem(ctx context.Context, input *model.ItemModel) (*model.ItemModel, error)
 	GetItemByID(id string) (*model.ItemModel, error)
@@ -404,12 +1490,99 @@ type HosterService interface {
 	GetAllTermsAndConditions() ([]*model.TermsAndConditionsModel, error)
 	UpdateTermsAndConditions(ctx context.Context, id string, input *model.TermsAndConditionsModel) (*model.TermsAndConditionsModel, error)
 	DeleteTermsAndConditions(ctx context.Context, id string) error
+	InviteStaff(ctx context.Context, email, fullName, role string) (*model.HosterStaffModel, error)
+	AcceptStaffInvitation(token, fullName, password string) (*model.HosterStaffModel, error)
+	GetStaff(ctx context.Context) ([]*model.HosterStaffModel, error)
+	UpdateStaffRole(ctx context.Context, id, role string) error
+	RemoveStaff(ctx context.Context, id string) error
+	CreateAPIKey(ctx context.Context, name string, scopes []string) (*APIKeyResponse, error)
+	GetAPIKeys(ctx context.Context) ([]*model.APIKeyModel, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+}
+
+/*
+Fungsi untuk membentuk keanggotaan pemilik dari data hoster.
+Pemilik toko dikembalikan sebagai anggota dengan ID toko sama dengan ID akunnya.
+*/
+func ownerMembership(hoster *model.HosterModel) *model.HosterStaffModel {
+	return &model.HosterStaffModel{
+		ID:           hoster.ID,
+		StoreID:      hoster.ID,
+		Email:        hoster.Email,
+		FullName:     hoster.FullName,
+		PasswordHash: hoster.PasswordHash,
+		Role:         model.StoreRoleOwner,
+		AcceptedAt:   &hoster.CreatedAt,
+		CreatedAt:    hoster.CreatedAt,
+		UpdatedAt:    hoster.UpdatedAt,
+	}
+}
+
+/*
+Fungsi untuk membaca akun dan toko dari klaim token di konteks.
+ID akun, ID toko, dan role di toko dikembalikan.
+*/
+func storeFromContext(ctx context.Context) (string, string, string, error) {
+	claims := middleware.GetClaims(ctx)
+	if claims == nil || claims.Subject == "" {
+		return "", "", "", errors.New("invalid token claims")
+	}
+	// Token yang diterbitkan sebelum ada staf toko selalu milik pemilik toko
+	if claims.Store == "" {
+		return claims.Subject, claims.Subject, model.StoreRoleOwner, nil
+	}
+	return claims.Subject, claims.Store, claims.StoreRole, nil
+}
+
+/*
+Fungsi untuk memvalidasi aturan harga item.
+Error dikembalikan jika diskon atau biaya akhir pekan di luar 0-100 persen atau tarif bertingkat dan ongkos antar bernilai negatif.
+*/
+func validateItemPricing(item *model.ItemModel) error {
+	if item.Discount < 0 || item.Discount > 100 {
+		return errors.New(message.MsgItemDiscountInvalid)
+	}
+	if item.PricePerWeek < 0 || item.PricePerMonth < 0 {
+		return errors.New(message.MsgItemTierPriceInvalid)
+	}
+	if item.WeekendSurcharge < 0 || item.WeekendSurcharge > 100 {
+		return errors.New(message.MsgItemWeekendSurchargeInvalid)
+	}
+	if item.DeliveryFee < 0 {
+		return errors.New(message.MsgItemDeliveryFeeInvalid)
+	}
+	return nil
+}
+
+/*
+Fungsi untuk membentuk akun pemilik toko baru dari identitas OpenID Connect.
+Password acak yang tidak diketahui siapa pun dipasang sampai pemilik mengatur password lewat reset.
+*/
+func newOIDCHoster(identity *auth.OIDCIdentity) (*model.HosterModel, error) {
+	hash, err := auth.UnusablePasswordHash()
+	if err != nil {
+		return nil, err
+	}
+
+	name := identity.Name
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+	now := time.Now()
+	return &model.HosterModel{
+		FullName:     name,
+		StoreName:    name,
+		Email:        identity.Email,
+		PasswordHash: hash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
 }
 
 /*
 Fungsi untuk membuat instance baru dari HosterService.
 Instance layanan dikembalikan.
 */
-func NewHosterService(repo HosterRepository) HosterService {
-	return &hosterService{repo: repo}
+func NewHosterService(repo HosterRepository, tokens auth.TokenIssuer, revocation auth.RevocationStore, sessions auth.SessionStore, reset auth.PasswordResetService, passwords auth.PasswordPolicy, guard auth.LoginGuard, mfa auth.MFAService, permissions auth.PermissionStore, apiKeys auth.APIKeyStore, verifier auth.EmailVerifier, oidc auth.OIDCProvider, deletion auth.AccountDeletionService, m mailer.Mailer) HosterService {
+	return &hosterService{repo: repo, tokens: tokens, revocation: revocation, sessions: sessions, reset: reset, passwords: passwords, guard: guard, mfa: mfa, permissions: permissions, apiKeys: apiKeys, verifier: verifier, oidc: oidc, deletion: deletion, mailer: m}
 }
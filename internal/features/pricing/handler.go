@@ -0,0 +1,103 @@
+package pricing
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk format tanggal penawaran.
+Konstanta ini digunakan untuk membaca dan menulis tanggal mulai dan selesai sewa.
+*/
+const dateLayout = "2006-01-02"
+
+/*
+Struktur untuk handler harga.
+Struktur ini menangani permintaan penawaran harga sewa.
+*/
+type PricingHandler struct {
+	service PricingService
+}
+
+/*
+Metode untuk menghitung penawaran harga sewa item.
+Rincian biaya untuk parameter quantity, start_date, dan end_date dikembalikan.
+*/
+func (h *PricingHandler) GetItemQuote(w http.ResponseWriter, r *http.Request) {
+	log.Printf("GetItemQuote: received request")
+	if r.Method != http.MethodGet {
+		response.BadRequest(w, message.MsgNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+
+	// Validasi jumlah unit, bawaan satu unit
+	quantity := 1
+	if raw := strings.TrimSpace(query.Get("quantity")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			response.BadRequest(w, message.MsgBookingQuantityInvalid)
+			return
+		}
+		quantity = parsed
+	}
+
+	// Validasi format tanggal
+	start, err := time.Parse(dateLayout, strings.TrimSpace(query.Get("start_date")))
+	if err != nil {
+		response.BadRequest(w, message.MsgBookingDateInvalid)
+		return
+	}
+	end, err := time.Parse(dateLayout, strings.TrimSpace(query.Get("end_date")))
+	if err != nil {
+		response.BadRequest(w, message.MsgBookingDateInvalid)
+		return
+	}
+
+	item, err := h.service.FindItem(mux.Vars(r)["id"])
+	if err != nil {
+		log.Printf("GetItemQuote: error: %v", err)
+		writeQuoteError(w, err)
+		return
+	}
+	quote, err := h.service.Quote(item, quantity, start, end)
+	if err != nil {
+		log.Printf("GetItemQuote: error: %v", err)
+		writeQuoteError(w, err)
+		return
+	}
+
+	response.OK(w, quote, message.MsgSuccess)
+}
+
+/*
+Fungsi untuk menulis respons error penawaran harga.
+Pesan error layanan dipetakan ke status HTTP yang sesuai.
+*/
+func writeQuoteError(w http.ResponseWriter, err error) {
+	switch err.Error() {
+	case message.MsgItemIDRequired, message.MsgBookingQuantityInvalid, message.MsgBookingDateRangeInvalid,
+		message.MsgBookingQuantityAboveStock, message.MsgBookingPeriodTooLong:
+		response.BadRequest(w, err.Error())
+	case message.MsgItemNotFound:
+		response.Error(w, http.StatusNotFound, err.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+	}
+}
+
+/*
+Fungsi untuk membuat instance baru dari PricingHandler.
+Instance handler dikembalikan.
+*/
+func NewPricingHandler(s PricingService) *PricingHandler {
+	return &PricingHandler{service: s}
+}
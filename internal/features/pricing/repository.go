@@ -0,0 +1,123 @@
+package pricing
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Konstanta untuk kolom aturan harga item yang dipilih.
+Konstanta ini dipakai bersama oleh pencarian satu item dan beberapa item sekaligus agar urutan kolom sesuai scanItem.
+*/
+const itemColumns = `
+	i.id,
+	i.name,
+	i.stock,
+	i.pickup_type,
+	i.price_per_day,
+	i.price_per_week,
+	i.price_per_month,
+	i.weekend_surcharge,
+	i.delivery_fee,
+	i.deposit,
+	COALESCE(i.discount, 0),
+	i.user_id
+`
+
+/*
+Struktur untuk repositori harga.
+Struktur ini menyediakan akses ke data harga item.
+*/
+type pricingRepository struct {
+	db *sqlx.DB
+}
+
+/*
+Metode untuk mencari aturan harga item.
+Model item beserta stok, tarif, diskon, biaya akhir pekan, ongkos antar, dan deposit dikembalikan jika item ditemukan dan tokonya belum dihapus.
+*/
+func (r *pricingRepository) FindItemByID(id string) (*model.ItemModel, error) {
+	query := `
+		SELECT ` + itemColumns + `
+		FROM item i
+		JOIN hoster h ON h.id = i.user_id
+		WHERE i.id = $1 AND h.deleted_at IS NULL
+		LIMIT 1
+	`
+	item, err := scanItem(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("FindItemByID: error querying item %s: %v", id, err)
+		return nil, err
+	}
+	return item, nil
+}
+
+/*
+Metode untuk mencari aturan harga beberapa item sekaligus.
+Item yang ditemukan dan tokonya belum dihapus dikembalikan dalam satu query; item yang tidak ditemukan tidak disertakan.
+*/
+func (r *pricingRepository) FindItemsByIDs(ids []string) ([]*model.ItemModel, error) {
+	query := `
+		SELECT ` + itemColumns + `
+		FROM item i
+		JOIN hoster h ON h.id = i.user_id
+		WHERE i.id = ANY($1) AND h.deleted_at IS NULL
+	`
+	rows, err := r.db.Query(query, pq.StringArray(ids))
+	if err != nil {
+		log.Printf("FindItemsByIDs: error querying %d items: %v", len(ids), err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*model.ItemModel{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+/*
+Interface untuk operasi repositori harga.
+Interface ini mendefinisikan metode untuk membaca aturan harga item.
+*/
+type PricingRepository interface {
+	FindItemByID(id string) (*model.ItemModel, error)
+	FindItemsByIDs(ids []string) ([]*model.ItemModel, error)
+}
+
+/*
+Fungsi untuk membaca satu baris aturan harga item.
+Kolom dibaca sesuai urutan itemColumns.
+*/
+func scanItem(row interface{ Scan(...any) error }) (*model.ItemModel, error) {
+	var item model.ItemModel
+	err := row.Scan(
+		&item.ID, &item.Name, &item.Stock, &item.PickupType,
+		&item.PricePerDay, &item.PricePerWeek, &item.PricePerMonth, &item.WeekendSurcharge,
+		&item.DeliveryFee, &item.Deposit, &item.Discount, &item.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+/*
+Fungsi untuk membuat instance baru dari PricingRepository.
+Instance repositori dikembalikan.
+*/
+func NewPricingRepository(db *sqlx.DB) PricingRepository {
+	return &pricingRepository{db: db}
+}
@@ -0,0 +1,13 @@
+package pricing
+
+import (
+	"github.com/gorilla/mux"
+)
+
+/*
+Fungsi untuk mengatur rute fitur harga.
+Router dikonfigurasi dengan rute penawaran harga publik.
+*/
+func SetupPricingRoutes(router *mux.Router, h *PricingHandler) {
+	router.HandleFunc("/api/v1/public/item/{id}/quote", h.GetItemQuote).Methods("GET")
+}
@@ -0,0 +1,226 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"lalan-be/internal/model"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk panjang periode tarif bertingkat.
+Konstanta ini menentukan jumlah hari yang dihitung sebagai satu minggu dan satu bulan sewa.
+*/
+const (
+	daysPerWeek  = 7
+	daysPerMonth = 30
+)
+
+/*
+Konstanta untuk rentang kalender sewa terpanjang.
+Konstanta ini membatasi rentang tanggal penawaran, booking, dan keranjang termasuk tanggal mulai dan selesai.
+*/
+const MaxRentalDays = 366
+
+/*
+Struktur untuk layanan harga.
+Struktur ini menghitung penawaran harga sewa berdasarkan aturan harga item.
+*/
+type pricingService struct {
+	repo    PricingRepository
+	dayRule string
+}
+
+/*
+Metode untuk mencari item beserta aturan harganya.
+Item dikembalikan, atau error jika ID kosong atau item tidak ditemukan; layanan lain memakai metode ini agar item hanya dicari di satu tempat.
+*/
+func (s *pricingService) FindItem(itemID string) (*model.ItemModel, error) {
+	itemID = strings.TrimSpace(itemID)
+	if itemID == "" {
+		return nil, errors.New(message.MsgItemIDRequired)
+	}
+
+	item, err := s.repo.FindItemByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, errors.New(message.MsgItemNotFound)
+	}
+
+	return item, nil
+}
+
+/*
+Metode untuk mencari beberapa item beserta aturan harganya sekaligus.
+Item dikembalikan per ID dalam satu query; item yang tidak ditemukan tidak ada di map.
+*/
+func (s *pricingService) FindItems(itemIDs []string) (map[string]*model.ItemModel, error) {
+	found := map[string]*model.ItemModel{}
+	if len(itemIDs) == 0 {
+		return found, nil
+	}
+	items, err := s.repo.FindItemsByIDs(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		found[item.ID] = item
+	}
+	return found, nil
+}
+
+/*
+Metode untuk menghitung penawaran harga dari aturan harga item yang sudah dimuat.
+Jumlah unit dibatasi stok item dan rentang tanggal dibatasi MaxRentalDays; hari sewa dibagi ke tarif bulanan, mingguan, lalu harian, biaya akhir pekan dikenakan pada hari bertarif harian, diskon persentase dipotong dari subtotal, lalu ongkos antar dan deposit ditambahkan.
+*/
+func (s *pricingService) Quote(item *model.ItemModel, quantity int, start, end time.Time) (*model.QuoteModel, error) {
+	if quantity < 1 {
+		return nil, errors.New(message.MsgBookingQuantityInvalid)
+	}
+	if quantity > item.Stock {
+		return nil, errors.New(message.MsgBookingQuantityAboveStock)
+	}
+	if end.Before(start) {
+		return nil, errors.New(message.MsgBookingDateRangeInvalid)
+	}
+	if SpanDays(start, end) > MaxRentalDays {
+		return nil, errors.New(message.MsgBookingPeriodTooLong)
+	}
+
+	days := rentalDays(start, end, s.dayRule)
+	quote := &model.QuoteModel{
+		ItemID:    item.ID,
+		Quantity:  quantity,
+		StartDate: start.Format(dateLayout),
+		EndDate:   end.Format(dateLayout),
+		DayRule:   s.dayRule,
+		Days:      days,
+		Lines:     []*model.QuoteLineModel{},
+	}
+
+	// Tarif bertingkat dipakai lebih dulu, sisa hari memakai tarif harian
+	remaining := days
+	if item.PricePerMonth > 0 && remaining >= daysPerMonth {
+		months := remaining / daysPerMonth
+		remaining -= months * daysPerMonth
+		addLine(quote, model.QuoteLineMonthly, fmt.Sprintf("Monthly rate x %d", months), months, item.PricePerMonth*quantity)
+	}
+	if item.PricePerWeek > 0 && remaining >= daysPerWeek {
+		weeks := remaining / daysPerWeek
+		remaining -= weeks * daysPerWeek
+		addLine(quote, model.QuoteLineWeekly, fmt.Sprintf("Weekly rate x %d", weeks), weeks, item.PricePerWeek*quantity)
+	}
+	if remaining > 0 {
+		addLine(quote, model.QuoteLineDaily, fmt.Sprintf("Daily rate x %d", remaining), remaining, item.PricePerDay*quantity)
+	}
+
+	// Biaya akhir pekan hanya untuk hari bertarif harian, yaitu hari-hari terakhir masa sewa
+	if item.WeekendSurcharge > 0 && remaining > 0 {
+		weekend := weekendDays(start.AddDate(0, 0, days-remaining), remaining)
+		if weekend > 0 {
+			surcharge := percentOf(item.PricePerDay, item.WeekendSurcharge) * quantity
+			addLine(quote, model.QuoteLineWeekendSurcharge, fmt.Sprintf("Weekend surcharge %d%% x %d", item.WeekendSurcharge, weekend), weekend, surcharge)
+		}
+	}
+	for _, line := range quote.Lines {
+		quote.Subtotal += line.Amount
+	}
+
+	if item.Discount > 0 {
+		quote.Discount = percentOf(quote.Subtotal, item.Discount)
+		addLine(quote, model.QuoteLineDiscount, fmt.Sprintf("Discount %d%%", item.Discount), 1, -quote.Discount)
+	}
+	if item.PickupType == model.PickupMethodDelivery && item.DeliveryFee > 0 {
+		quote.DeliveryFee = item.DeliveryFee
+		addLine(quote, model.QuoteLineDeliveryFee, "Delivery fee", 1, item.DeliveryFee)
+	}
+	quote.Total = quote.Subtotal - quote.Discount + quote.DeliveryFee
+
+	if item.Deposit > 0 {
+		quote.Deposit = item.Deposit * quantity
+		addLine(quote, model.QuoteLineDeposit, "Refundable deposit", 1, quote.Deposit)
+	}
+	quote.AmountDue = quote.Total + quote.Deposit
+
+	return quote, nil
+}
+
+/*
+Interface untuk operasi layanan harga.
+Interface ini mendefinisikan metode untuk mencari aturan harga item dan menghitung penawaran harga sewa.
+*/
+type PricingService interface {
+	FindItem(itemID string) (*model.ItemModel, error)
+	FindItems(itemIDs []string) (map[string]*model.ItemModel, error)
+	Quote(item *model.ItemModel, quantity int, start, end time.Time) (*model.QuoteModel, error)
+}
+
+/*
+Fungsi untuk menambahkan baris ke penawaran harga.
+Jumlah baris dihitung dari jumlah periode dikali harga satuan.
+*/
+func addLine(quote *model.QuoteModel, code, description string, periods, unitPrice int) {
+	quote.Lines = append(quote.Lines, &model.QuoteLineModel{
+		Code:        code,
+		Description: description,
+		Periods:     periods,
+		UnitPrice:   unitPrice,
+		Amount:      periods * unitPrice,
+	})
+}
+
+/*
+Fungsi untuk menghitung jumlah hari sewa yang ditagih.
+Aturan inclusive menghitung tanggal mulai dan selesai, aturan nights menghitung jumlah malam dengan minimal satu hari.
+*/
+func rentalDays(start, end time.Time, rule string) int {
+	nights := int(end.Sub(start).Hours() / 24)
+	if rule == model.RentalDayRuleNights {
+		return max(nights, 1)
+	}
+	return nights + 1
+}
+
+/*
+Fungsi untuk menghitung jumlah hari kalender dari tanggal mulai sampai selesai.
+Tanggal mulai dan selesai sama-sama dihitung, tidak bergantung pada aturan hari sewa.
+*/
+func SpanDays(start, end time.Time) int {
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+/*
+Fungsi untuk menghitung persentase dari sebuah nominal.
+Hasil dibulatkan ke satuan terdekat dengan pembulatan setengah ke atas, bukan dipotong.
+*/
+func percentOf(amount, percent int) int {
+	return (amount*percent + 50) / 100
+}
+
+/*
+Fungsi untuk menghitung jumlah hari Sabtu dan Minggu.
+Jumlah hari akhir pekan dalam sejumlah hari mulai tanggal tertentu dikembalikan.
+*/
+func weekendDays(from time.Time, days int) int {
+	count := 0
+	for i := 0; i < days; i++ {
+		switch from.AddDate(0, 0, i).Weekday() {
+		case time.Saturday, time.Sunday:
+			count++
+		}
+	}
+	return count
+}
+
+/*
+Fungsi untuk membuat instance baru dari PricingService.
+Instance layanan dikembalikan dengan aturan batas hari sewa dari konfigurasi.
+*/
+func NewPricingService(repo PricingRepository, dayRule string) PricingService {
+	return &pricingService{repo: repo, dayRule: dayRule}
+}
@@ -34,7 +34,7 @@ func (r *publicRepository) GetAllCategory() ([]*model.CategoryModel, error) {
 
 /*
 Metode untuk mendapatkan semua item.
-Daftar model item dikembalikan.
+Daftar model item dikembalikan, tanpa item milik hoster yang akunnya sudah dihapus.
 */
 func (r *publicRepository) GetAllItems() ([]*model.ItemModel, error) {
 	query := `
@@ -48,11 +48,16 @@ func (r *publicRepository) GetAllItems() ([]*model.ItemModel, error) {
 			price_per_day,
 			deposit,
 			discount,
+			price_per_week,
+			price_per_month,
+			weekend_surcharge,
+			delivery_fee,
 			category_id,
 			user_id,
 			created_at,
 			updated_at
 		FROM item
+		WHERE user_id NOT IN (SELECT id FROM hoster WHERE deleted_at IS NOT NULL)
 	`
 	var items []*model.ItemModel
 	rows, err := r.db.Query(query)
@@ -64,7 +69,7 @@ func (r *publicRepository) GetAllItems() ([]*model.ItemModel, error) {
 	for rows.Next() {
 		var item model.ItemModel
 		var photosJSON []byte
-		err := rows.Scan(&item.ID, &item.Name, &item.Description, &photosJSON, &item.Stock, &item.PickupType, &item.PricePerDay, &item.Deposit, &item.Discount, &item.CategoryID, &item.UserID, &item.CreatedAt, &item.UpdatedAt)
+		err := rows.Scan(&item.ID, &item.Name, &item.Description, &photosJSON, &item.Stock, &item.PickupType, &item.PricePerDay, &item.Deposit, &item.Discount, &item.PricePerWeek, &item.PricePerMonth, &item.WeekendSurcharge, &item.DeliveryFee, &item.CategoryID, &item.UserID, &item.CreatedAt, &item.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
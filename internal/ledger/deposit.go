@@ -0,0 +1,38 @@
+package ledger
+
+import (
+	"log"
+
+	"github.com/jmoiron/sqlx"
+
+	"lalan-be/internal/model"
+)
+
+/*
+Fungsi untuk mengembalikan deposit booking dalam transaksi.
+Sisa deposit setelah potongan yang tidak dibatalkan dicatat sebagai pengembalian; booking tanpa deposit atau yang deposit-nya sudah dikembalikan diabaikan.
+*/
+func ReleaseDeposit(tx *sqlx.Tx, bookingID, reason string) error {
+	insert := `
+		INSERT INTO deposit_entries (booking_id, entry_type, amount, reason)
+		SELECT
+			$1::uuid,
+			$2::text,
+			COALESCE(SUM(amount) FILTER (WHERE entry_type = $3), 0)
+				- COALESCE(SUM(amount) FILTER (WHERE entry_type = $4 AND status <> $5), 0),
+			$6::text
+		FROM deposit_entries
+		WHERE booking_id = $1
+		HAVING COUNT(*) FILTER (WHERE entry_type = $3) > 0
+		ON CONFLICT DO NOTHING
+	`
+	res, err := tx.Exec(insert, bookingID, model.DepositEntryRelease, model.DepositEntryHold, model.DepositEntryDeduction, model.DepositStatusWithdrawn, reason)
+	if err != nil {
+		log.Printf("ReleaseDeposit: error releasing deposit of booking %s: %v", bookingID, err)
+		return err
+	}
+	if rows, _ := res.RowsAffected(); rows > 0 {
+		log.Printf("ReleaseDeposit: deposit of booking %s released: %s", bookingID, reason)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
+)
+
+/*
+Variabel untuk penyimpanan API key.
+Variabel ini diisi saat startup agar rute inventaris dapat menerima API key hoster.
+*/
+var apiKeyStore auth.APIKeyStore
+
+/*
+Fungsi untuk mengatur penyimpanan API key.
+Penyimpanan digunakan oleh APIKeyOrJWTMiddleware untuk setiap permintaan.
+*/
+func SetAPIKeyStore(store auth.APIKeyStore) {
+	apiKeyStore = store
+}
+
+/*
+Fungsi untuk middleware yang menerima API key hoster atau JWT.
+Permintaan dengan header X-API-Key atau Bearer berawalan API key diautentikasi dengan API key, selain itu diteruskan ke JWTMiddleware.
+*/
+func APIKeyOrJWTMiddleware(next http.Handler) http.Handler {
+	jwt := JWTMiddleware(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw := requestAPIKey(r)
+		if raw == "" {
+			jwt.ServeHTTP(w, r)
+			return
+		}
+		if apiKeyStore == nil || permissionStore == nil {
+			log.Printf("APIKeyOrJWTMiddleware: api key or permission store is not configured")
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+			return
+		}
+
+		key, err := apiKeyStore.Authenticate(raw)
+		if err != nil {
+			if !errors.Is(err, auth.ErrAPIKeyInvalid) {
+				log.Printf("APIKeyOrJWTMiddleware: api key check failed: %v", err)
+			}
+			response.Unauthorized(w, message.MsgAPIKeyInvalid)
+			return
+		}
+
+		// Scope API key dibatasi lagi oleh permission pembuatnya saat ini
+		owner, err := permissionStore.Permissions(key.CreatedBy, "hoster")
+		if err != nil {
+			log.Printf("APIKeyOrJWTMiddleware: failed to load permissions: %v", err)
+			response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+			return
+		}
+		granted := make(auth.PermissionSet, len(key.Scopes))
+		for _, scope := range key.Scopes {
+			if owner.Has(scope) {
+				granted[scope] = struct{}{}
+			}
+		}
+
+		claims := auth.NewClaims(key.CreatedBy, "hoster")
+		claims.Store = key.StoreID
+		claims.APIKeyID = key.ID
+		ctx := withClaims(r.Context(), claims)
+		ctx = context.WithValue(ctx, PermissionsKey, granted)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+/*
+Fungsi untuk membaca API key dari permintaan.
+API key mentah dikembalikan atau string kosong jika permintaan tidak memakai API key.
+*/
+func requestAPIKey(r *http.Request) string {
+	if key := strings.TrimSpace(r.Header.Get("X-API-Key")); key != "" {
+		return key
+	}
+	if token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found && strings.HasPrefix(token, auth.APIKeyPrefix) {
+		return token
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/model"
+	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
+)
+
+/*
+Variabel untuk layanan impersonasi.
+Variabel ini diisi saat startup agar setiap permintaan dengan token impersonasi dicatat ke jejak audit.
+*/
+var impersonationService auth.ImpersonationService
+
+/*
+Struktur untuk pencatat status respons.
+Struktur ini menyimpan kode status yang ditulis handler agar dapat dicatat ke jejak audit.
+*/
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+/*
+Metode untuk menulis kode status respons.
+Kode status disimpan sebelum diteruskan ke ResponseWriter asli.
+*/
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+/*
+Fungsi untuk mengatur layanan impersonasi.
+Layanan digunakan middleware JWT untuk mencatat permintaan yang dilakukan admin atas nama hoster.
+*/
+func SetImpersonationService(service auth.ImpersonationService) {
+	impersonationService = service
+}
+
+/*
+Fungsi untuk middleware yang menolak permintaan dengan token impersonasi.
+Middleware ini melindungi tindakan sensitif seperti ganti password, MFA, dan penghapusan akun.
+*/
+func NoImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Cek klaim act pada token
+		if GetClaims(r.Context()).Impersonating() {
+			response.Forbidden(w, message.MsgImpersonationForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+/*
+Fungsi untuk menjalankan handler dengan claims di konteks.
+Permintaan dengan token impersonasi diberi header penanda untuk banner frontend dan dicatat ke jejak audit beserta status responsnya.
+*/
+func serveWithClaims(next http.Handler, w http.ResponseWriter, r *http.Request, claims *auth.Claims) {
+	r = r.WithContext(withClaims(r.Context(), claims))
+	if !claims.Impersonating() {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("X-Impersonated-By", claims.Act.Subject)
+	if claims.ExpiresAt != nil {
+		w.Header().Set("X-Impersonation-Expires-At", claims.ExpiresAt.Time.UTC().Format(time.RFC3339))
+	}
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	next.ServeHTTP(recorder, r)
+
+	log.Printf("Impersonation: admin %s as hoster %s %s %s -> %d", claims.Act.Subject, claims.Subject, r.Method, r.URL.RequestURI(), recorder.status)
+	if impersonationService == nil {
+		return
+	}
+	client := auth.ClientInfoFromRequest(r)
+	entry := &model.ImpersonationLogModel{
+		ImpersonationID: claims.ID,
+		AdminID:         claims.Act.Subject,
+		HosterID:        claims.Subject,
+		Method:          r.Method,
+		Path:            r.URL.RequestURI(),
+		Status:          recorder.status,
+		IPAddress:       client.IP,
+		UserAgent:       client.UserAgent,
+	}
+	if err := impersonationService.Record(entry); err != nil {
+		log.Printf("Impersonation: failed to record audit log for %s: %v", claims.ID, err)
+	}
+}
@@ -2,38 +2,41 @@ package middleware
 
 import (
 	"context"
+	"log"
 	"net/http"
 	"strings"
+	"time"
 
-	"github.com/golang-jwt/jwt/v5"
-
-	"lalan-be/internal/config"
+	"lalan-be/internal/auth"
 	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
 )
 
 /*
 Konstanta untuk kunci konteks.
-Konstanta ini mendefinisikan kunci untuk menyimpan user ID dan role dalam konteks.
+Konstanta ini mendefinisikan kunci untuk menyimpan user ID, role, dan claims dalam konteks.
 */
 const (
 	UserIDKey   contextKey = "user_id"
 	UserRoleKey contextKey = "user_role"
+	ClaimsKey   contextKey = "claims"
 )
 
 /*
-Type untuk kunci konteks.
-Type ini digunakan sebagai kunci untuk nilai konteks.
+Variabel untuk penyimpanan pencabutan token, penyimpanan sesi, dan verifikator token.
+Variabel ini diisi saat startup agar middleware dapat memverifikasi dan menolak token yang dicabut.
 */
-type contextKey string
+var (
+	revocationStore auth.RevocationStore
+	sessionStore    auth.SessionStore
+	tokenVerifier   auth.TokenVerifier
+)
 
 /*
-Struktur untuk claims JWT.
-Struktur ini berisi claims JWT standar dan role pengguna.
+Type untuk kunci konteks.
+Type ini digunakan sebagai kunci untuk nilai konteks.
 */
-type Claims struct {
-	jwt.RegisteredClaims
-	Role string `json:"role"`
-}
+type contextKey string
 
 /*
 Fungsi untuk middleware JWT.
@@ -41,38 +44,124 @@ Middleware ini memvalidasi token JWT dan memperbarui konteks.
 */
 func JWTMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Cek header authorization
-		auth := r.Header.Get("Authorization")
-		if auth == "" {
-			response.Unauthorized(w, "Token required")
+		claims, msg := parseRequestToken(r)
+		if claims == nil {
+			response.Unauthorized(w, msg)
 			return
 		}
 
-		parts := strings.Split(auth, " ")
-		// Cek format Bearer
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			response.Unauthorized(w, "Invalid token format")
+		serveWithClaims(next, w, r, claims)
+	})
+}
+
+/*
+Fungsi untuk middleware JWT opsional.
+Middleware ini mengisi konteks jika token valid tanpa menolak permintaan tanpa token.
+*/
+func OptionalJWTMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims, _ := parseRequestToken(r); claims != nil {
+			serveWithClaims(next, w, r, claims)
 			return
 		}
 
-		claims := &Claims{}
-		// Parse token
-		token, err := jwt.ParseWithClaims(parts[1], claims, func(t *jwt.Token) (interface{}, error) {
-			return config.GetJWTSecret(), nil
-		})
+		next.ServeHTTP(w, r)
+	})
+}
 
-		// Cek validitas token
-		if err != nil || !token.Valid {
-			response.Unauthorized(w, "Invalid or expired token")
-			return
+/*
+Fungsi untuk mengatur penyimpanan pencabutan token.
+Penyimpanan digunakan oleh middleware JWT untuk setiap permintaan.
+*/
+func SetRevocationStore(store auth.RevocationStore) {
+	revocationStore = store
+}
+
+/*
+Fungsi untuk mengatur penyimpanan sesi login.
+Penyimpanan digunakan middleware JWT untuk menolak token dari sesi yang sudah diakhiri.
+*/
+func SetSessionStore(store auth.SessionStore) {
+	sessionStore = store
+}
+
+/*
+Fungsi untuk mengatur verifikator access token.
+Verifikator digunakan middleware JWT untuk memeriksa tanda tangan berdasarkan kid.
+*/
+func SetTokenVerifier(verifier auth.TokenVerifier) {
+	tokenVerifier = verifier
+}
+
+/*
+Fungsi untuk membaca dan memvalidasi token dari header Authorization.
+Claims dikembalikan jika token valid, atau pesan error jika tidak.
+*/
+func parseRequestToken(r *http.Request) (*auth.Claims, string) {
+	// Cek header authorization
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return nil, "Token required"
+	}
+
+	parts := strings.Split(header, " ")
+	// Cek format Bearer
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, "Invalid token format"
+	}
+
+	if tokenVerifier == nil {
+		log.Printf("JWTMiddleware: token verifier is not configured")
+		return nil, "Unable to verify token"
+	}
+
+	// Verifikasi tanda tangan dan masa berlaku token
+	claims, err := tokenVerifier.Verify(parts[1])
+	if err != nil {
+		return nil, "Invalid or expired token"
+	}
+
+	// Cek daftar pencabutan
+	if revocationStore != nil {
+		var issuedAt time.Time
+		if claims.IssuedAt != nil {
+			issuedAt = claims.IssuedAt.Time
+		}
+		revoked, err := revocationStore.IsRevoked(claims.ID, claims.Subject, claims.Role, issuedAt)
+		if err != nil {
+			log.Printf("JWTMiddleware: revocation check failed: %v", err)
+			return nil, "Unable to verify token"
 		}
+		if revoked {
+			return nil, "Token has been revoked"
+		}
+	}
+
+	// Cek sesi login yang terhubung ke token
+	if sessionStore != nil && claims.SessionID != "" {
+		active, err := sessionStore.IsActive(claims.SessionID)
+		if err != nil {
+			log.Printf("JWTMiddleware: session check failed: %v", err)
+			return nil, "Unable to verify token"
+		}
+		if !active {
+			return nil, message.MsgSessionRevoked
+		}
+		sessionStore.Touch(claims.SessionID, auth.ClientInfoFromRequest(r))
+	}
 
-		// Set context
-		ctx := context.WithValue(r.Context(), UserIDKey, claims.Subject)
-		ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+	return claims, ""
+}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+/*
+Fungsi untuk menyimpan claims ke dalam konteks.
+Konteks baru dengan user ID, role, dan claims dikembalikan.
+*/
+func withClaims(ctx context.Context, claims *auth.Claims) context.Context {
+	ctx = context.WithValue(ctx, UserIDKey, claims.Subject)
+	ctx = context.WithValue(ctx, UserRoleKey, claims.Role)
+	ctx = context.WithValue(ctx, ClaimsKey, claims)
+	return ctx
 }
 
 /*
@@ -92,3 +181,12 @@ func GetUserRole(r *http.Request) string {
 	role, _ := r.Context().Value(UserRoleKey).(string)
 	return role
 }
+
+/*
+Fungsi untuk mendapatkan claims token dari konteks.
+Claims dikembalikan atau nil jika permintaan tidak terautentikasi.
+*/
+func GetClaims(ctx context.Context) *auth.Claims {
+	claims, _ := ctx.Value(ClaimsKey).(*auth.Claims)
+	return claims
+}
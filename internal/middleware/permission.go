@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"lalan-be/internal/auth"
+	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
+)
+
+/*
+Konstanta untuk kunci konteks permission.
+Konstanta ini menyimpan permission yang sudah dihitung agar tidak dibaca ulang dalam satu permintaan.
+*/
+const PermissionsKey contextKey = "permissions"
+
+/*
+Variabel untuk penyimpanan permission.
+Variabel ini diisi saat startup agar middleware dapat memeriksa permission pengguna.
+*/
+var permissionStore auth.PermissionStore
+
+/*
+Fungsi untuk mengatur penyimpanan permission.
+Penyimpanan digunakan oleh middleware Require untuk setiap permintaan.
+*/
+func SetPermissionStore(store auth.PermissionStore) {
+	permissionStore = store
+}
+
+/*
+Fungsi untuk middleware pemeriksaan permission.
+Middleware ini menolak permintaan jika pengguna tidak memiliki semua permission yang diminta.
+*/
+func Require(permissions ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			granted, ok := r.Context().Value(PermissionsKey).(auth.PermissionSet)
+			if !ok {
+				if permissionStore == nil {
+					log.Printf("Require: permission store is not configured")
+					response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+					return
+				}
+				var err error
+				granted, err = permissionStore.Permissions(GetUserID(r), GetUserRole(r))
+				if err != nil {
+					log.Printf("Require: failed to load permissions: %v", err)
+					response.Error(w, http.StatusInternalServerError, message.MsgInternalServerError)
+					return
+				}
+				// Simpan hasil agar guard berikutnya di permintaan yang sama tidak membaca ulang
+				r = r.WithContext(context.WithValue(r.Context(), PermissionsKey, granted))
+			}
+
+			// Cek setiap permission yang diminta
+			for _, permission := range permissions {
+				if !granted.Has(permission) {
+					response.Forbidden(w, message.MsgPermissionDenied)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+/*
+Fungsi untuk membungkus handler dengan pemeriksaan permission.
+Handler yang hanya dijalankan jika permission terpenuhi dikembalikan.
+*/
+func RequireFunc(handler http.HandlerFunc, permissions ...string) http.Handler {
+	return Require(permissions...)(handler)
+}
+
+/*
+Fungsi untuk mendapatkan permission yang sudah dihitung dari konteks.
+Kumpulan permission dikembalikan atau nil jika belum diperiksa.
+*/
+func GetPermissions(ctx context.Context) auth.PermissionSet {
+	permissions, _ := ctx.Value(PermissionsKey).(auth.PermissionSet)
+	return permissions
+}
@@ -3,7 +3,9 @@ package middleware
 import (
 	"net/http"
 
+	"lalan-be/internal/config"
 	"lalan-be/internal/response"
+	"lalan-be/pkg/message"
 )
 
 /*
@@ -37,3 +39,38 @@ func Hoster(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+/*
+Fungsi untuk middleware akses customer.
+Middleware ini memeriksa apakah pengguna memiliki role customer.
+*/
+func Customer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Cek role customer
+		if GetUserRole(r) != "customer" {
+			response.Forbidden(w, "Customer access required")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+/*
+Fungsi untuk middleware kewajiban MFA admin.
+Middleware ini menolak admin yang belum login dengan MFA jika ADMIN_REQUIRE_MFA aktif.
+*/
+func RequireAdminMFA(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Cek kebijakan MFA admin
+		if config.AdminRequiresMFA() {
+			claims := GetClaims(r.Context())
+			if claims == nil || !claims.MFA {
+				response.Forbidden(w, message.MsgMFAEnrollmentNeeded)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
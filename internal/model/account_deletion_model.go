@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model permintaan penghapusan akun.
+Struktur ini menyimpan jadwal penghapusan beserta status pembatalan atau penyelesaiannya.
+*/
+type AccountDeletionModel struct {
+	ID           string     `json:"id" db:"id"`
+	UserID       string     `json:"-" db:"user_id"`
+	Role         string     `json:"-" db:"role"`
+	ScheduledFor time.Time  `json:"scheduled_for" db:"scheduled_for"`
+	CancelledAt  *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
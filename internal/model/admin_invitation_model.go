@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model undangan admin.
+Struktur ini merepresentasikan undangan sekali pakai yang tersimpan dalam bentuk hash.
+*/
+type AdminInvitationModel struct {
+	ID         string     `json:"id" db:"id"`
+	Email      string     `json:"email" db:"email"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	InvitedBy  string     `json:"invited_by" db:"invited_by"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+/*
+Struktur untuk model API key toko hoster.
+Struktur ini merepresentasikan kunci akses terprogram beserta scope dan waktu penggunaan terakhirnya.
+*/
+type APIKeyModel struct {
+	ID         string         `json:"id" db:"id"`
+	StoreID    string         `json:"store_id" db:"store_id"`
+	CreatedBy  string         `json:"created_by" db:"created_by"`
+	Name       string         `json:"name" db:"name"`
+	Prefix     string         `json:"prefix" db:"prefix"`
+	KeyHash    string         `json:"-" db:"key_hash"`
+	Scopes     pq.StringArray `json:"scopes" db:"scopes"`
+	LastUsedAt *time.Time     `json:"last_used_at" db:"last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model periode blackout item.
+Struktur ini merepresentasikan rentang tanggal saat sebagian atau seluruh unit item tidak dapat disewa.
+*/
+type ItemBlackoutModel struct {
+	ID        string    `json:"id" db:"id"`
+	ItemID    string    `json:"item_id" db:"item_id"`
+	StoreID   string    `json:"store_id" db:"store_id"`
+	StartDate time.Time `json:"start_date" db:"start_date"`
+	EndDate   time.Time `json:"end_date" db:"end_date"`
+	Quantity  *int      `json:"quantity" db:"quantity"`
+	Reason    string    `json:"reason,omitempty" db:"reason"`
+	CreatedBy string    `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+/*
+Struktur untuk model ketersediaan harian item.
+Struktur ini berisi jumlah unit yang dibooking, diblokir, ditahan sementara, dan masih dapat disewa pada satu tanggal.
+*/
+type AvailabilityDayModel struct {
+	Date      string `json:"date" db:"date"`
+	Reserved  int    `json:"-" db:"reserved"`
+	Blocked   int    `json:"-" db:"blocked"`
+	Held      int    `json:"-" db:"held"`
+	Available int    `json:"available" db:"-"`
+}
+
+/*
+Struktur untuk model kalender ketersediaan item.
+Struktur ini berisi stok item dan ketersediaan per hari pada rentang tanggal yang diminta.
+*/
+type ItemAvailabilityModel struct {
+	ItemID string                  `json:"item_id"`
+	Stock  int                     `json:"stock"`
+	From   string                  `json:"from"`
+	To     string                  `json:"to"`
+	Days   []*AvailabilityDayModel `json:"days"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+/*
+Konstanta untuk status booking.
+Konstanta ini mendefinisikan tahapan booking dari pengajuan sampai selesai.
+*/
+const (
+	BookingStatusPending   = "pending"
+	BookingStatusConfirmed = "confirmed"
+	BookingStatusRejected  = "rejected"
+	BookingStatusPickedUp  = "picked_up"
+	BookingStatusReturned  = "returned"
+	BookingStatusCompleted = "completed"
+	BookingStatusCancelled = "cancelled"
+)
+
+/*
+Variabel untuk status booking yang memakai stok.
+Variabel ini digunakan saat menghitung stok yang sudah terpakai pada rentang tanggal.
+*/
+var BookingActiveStatuses = []string{BookingStatusPending, BookingStatusConfirmed, BookingStatusPickedUp}
+
+/*
+Struktur untuk model booking.
+Struktur ini merepresentasikan sewa sejumlah unit item oleh customer pada rentang tanggal tertentu.
+*/
+type BookingModel struct {
+	ID           string    `json:"id" db:"id"`
+	ItemID       string    `json:"item_id" db:"item_id"`
+	ItemName     string    `json:"item_name,omitempty" db:"item_name"`
+	CustomerID   string    `json:"customer_id" db:"customer_id"`
+	CustomerName string    `json:"customer_name,omitempty" db:"customer_name"`
+	StoreID      string    `json:"store_id" db:"store_id"`
+	OrderID      *string   `json:"order_id,omitempty" db:"order_id"`
+	Quantity     int       `json:"quantity" db:"quantity"`
+	StartDate    time.Time `json:"start_date" db:"start_date"`
+	EndDate      time.Time `json:"end_date" db:"end_date"`
+	Days         int       `json:"days" db:"days"`
+	PricePerDay  int       `json:"price_per_day" db:"price_per_day"`
+	Discount     int       `json:"discount" db:"discount"`
+	DeliveryFee  int       `json:"delivery_fee" db:"delivery_fee"`
+	TotalPrice   int       `json:"total_price" db:"total_price"`
+	Deposit      int       `json:"deposit" db:"deposit"`
+	Status       string    `json:"status" db:"status"`
+	Notes        string    `json:"notes,omitempty" db:"notes"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+
+	// Waktu setiap perpindahan status
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty" db:"confirmed_at"`
+	RejectedAt  *time.Time `json:"rejected_at,omitempty" db:"rejected_at"`
+	PickedUpAt  *time.Time `json:"picked_up_at,omitempty" db:"picked_up_at"`
+	ReturnedAt  *time.Time `json:"returned_at,omitempty" db:"returned_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+
+	// Kebijakan pembatalan yang berlaku saat booking dibuat dan refund saat dibatalkan
+	CancellationPolicyID *string           `json:"cancellation_policy_id,omitempty" db:"cancellation_policy_id"`
+	CancellationRules    CancellationRules `json:"cancellation_rules,omitempty" db:"cancellation_rules"`
+	RefundPercent        *int              `json:"refund_percent,omitempty" db:"refund_percent"`
+	RefundAmount         *int              `json:"refund_amount,omitempty" db:"refund_amount"`
+
+	// Rincian harga saat booking dibuat
+	Quote *QuoteModel `json:"quote,omitempty" db:"-"`
+
+	// Hold stok yang dikonversi menjadi booking ini, hanya diisi oleh server
+	HoldID *string `json:"-" db:"-"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+/*
+Struktur untuk satu aturan refund pembatalan.
+Struktur ini berisi batas minimal jam sebelum tanggal mulai sewa dan persentase refund jika pembatalan terjadi sebelum batas tersebut.
+*/
+type CancellationRuleModel struct {
+	HoursBefore   int `json:"hours_before"`
+	RefundPercent int `json:"refund_percent"`
+}
+
+/*
+Type untuk daftar aturan refund pembatalan.
+Type ini disimpan sebagai JSONB dan diurutkan dari batas jam terbesar.
+*/
+type CancellationRules []CancellationRuleModel
+
+/*
+Metode untuk mengubah daftar aturan menjadi nilai database.
+JSON aturan dikembalikan sebagai string agar tidak dikirim sebagai bytea, atau NULL jika daftar kosong.
+*/
+func (r CancellationRules) Value() (driver.Value, error) {
+	if r == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+/*
+Metode untuk membaca daftar aturan dari nilai database.
+Daftar aturan diisi dari JSON, atau dikosongkan jika nilai NULL.
+*/
+func (r *CancellationRules) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*r = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, r)
+	case string:
+		return json.Unmarshal([]byte(value), r)
+	}
+	return fmt.Errorf("cannot scan %T into CancellationRules", src)
+}
+
+/*
+Struktur untuk model kebijakan pembatalan.
+Struktur ini merepresentasikan kebijakan pembatalan milik toko yang dapat dipasang ke item atau dijadikan bawaan toko.
+*/
+type CancellationPolicyModel struct {
+	ID          string            `json:"id" db:"id"`
+	StoreID     string            `json:"store_id" db:"store_id"`
+	Name        string            `json:"name" db:"name"`
+	Description string            `json:"description,omitempty" db:"description"`
+	Rules       CancellationRules `json:"rules" db:"rules"`
+	IsDefault   bool              `json:"is_default" db:"is_default"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model item dalam keranjang.
+Struktur ini berisi item, toko pemiliknya, jumlah unit, dan penawaran harga jika tanggal sewa sudah diisi.
+*/
+type CartItemModel struct {
+	ItemID    string      `json:"item_id" db:"item_id"`
+	ItemName  string      `json:"item_name" db:"item_name"`
+	StoreID   string      `json:"store_id" db:"store_id"`
+	StoreName string      `json:"store_name" db:"store_name"`
+	Stock     int         `json:"stock" db:"stock"`
+	Quantity  int         `json:"quantity" db:"quantity"`
+	AddedAt   time.Time   `json:"added_at" db:"added_at"`
+	Quote     *QuoteModel `json:"quote,omitempty" db:"-"`
+}
+
+/*
+Struktur untuk model kelompok keranjang per toko.
+Struktur ini berisi item keranjang dari satu toko yang akan menjadi satu order saat checkout beserta totalnya.
+*/
+type CartGroupModel struct {
+	StoreID    string           `json:"store_id"`
+	StoreName  string           `json:"store_name"`
+	Items      []*CartItemModel `json:"items"`
+	TotalPrice int              `json:"total_price"`
+	Deposit    int              `json:"deposit"`
+	AmountDue  int              `json:"amount_due"`
+}
+
+/*
+Struktur untuk model keranjang customer.
+Struktur ini berisi rentang tanggal sewa bersama, item dikelompokkan per toko, dan total seluruh keranjang.
+*/
+type CartModel struct {
+	StartDate  *time.Time        `json:"start_date" db:"start_date"`
+	EndDate    *time.Time        `json:"end_date" db:"end_date"`
+	Groups     []*CartGroupModel `json:"groups"`
+	TotalPrice int               `json:"total_price"`
+	Deposit    int               `json:"deposit"`
+	AmountDue  int               `json:"amount_due"`
+}
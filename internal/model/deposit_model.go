@@ -0,0 +1,67 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+/*
+Konstanta untuk jenis entri buku besar deposit.
+Konstanta ini membedakan penahanan deposit, potongan kerusakan, dan pengembalian sisa deposit.
+*/
+const (
+	DepositEntryHold      = "hold"
+	DepositEntryDeduction = "deduction"
+	DepositEntryRelease   = "release"
+)
+
+/*
+Konstanta untuk status entri deposit.
+Konstanta ini mencatat tanggapan customer atas potongan; entri penahanan dan pengembalian selalu berstatus posted.
+*/
+const (
+	DepositStatusPosted       = "posted"
+	DepositStatusPending      = "pending"
+	DepositStatusAcknowledged = "acknowledged"
+	DepositStatusDisputed     = "disputed"
+	DepositStatusWithdrawn    = "withdrawn"
+)
+
+/*
+Struktur untuk model entri buku besar deposit.
+Struktur ini merepresentasikan satu pergerakan deposit booking, termasuk alasan dan foto bukti untuk potongan kerusakan serta keputusan admin atas sengketa.
+*/
+type DepositEntryModel struct {
+	ID             string         `json:"id" db:"id"`
+	BookingID      string         `json:"booking_id" db:"booking_id"`
+	EntryType      string         `json:"entry_type" db:"entry_type"`
+	Amount         int            `json:"amount" db:"amount"`
+	Reason         string         `json:"reason,omitempty" db:"reason"`
+	Photos         pq.StringArray `json:"photos,omitempty" db:"photos"`
+	Status         string         `json:"status" db:"status"`
+	CustomerNote   string         `json:"customer_note,omitempty" db:"customer_note"`
+	CreatedBy      *string        `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	RespondedAt    *time.Time     `json:"responded_at,omitempty" db:"responded_at"`
+	ResolutionNote string         `json:"resolution_note,omitempty" db:"resolution_note"`
+	ResolvedBy     *string        `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt     *time.Time     `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+/*
+Struktur untuk model laporan deposit booking.
+Struktur ini berisi total deposit yang ditahan, dipotong, dan dikembalikan beserta sisa saldo dan seluruh entri buku besar.
+*/
+type DepositStatementModel struct {
+	BookingID     string               `json:"booking_id" db:"-"`
+	BookingStatus string               `json:"booking_status" db:"-"`
+	Held          int                  `json:"held" db:"held"`
+	Deducted      int                  `json:"deducted" db:"deducted"`
+	Released      int                  `json:"released" db:"released"`
+	Balance       int                  `json:"balance" db:"-"`
+	Settled       bool                 `json:"settled" db:"settled"`
+	Disputed      int                  `json:"disputed" db:"disputed"`
+	Unresolved    int                  `json:"unresolved" db:"unresolved"`
+	Entries       []*DepositEntryModel `json:"entries" db:"-"`
+}
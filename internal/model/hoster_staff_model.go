@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+/*
+Konstanta untuk role anggota toko hoster.
+Konstanta ini membedakan pemilik toko dari staf yang diundang.
+*/
+const (
+	StoreRoleOwner   = "owner"
+	StoreRoleManager = "manager"
+	StoreRoleCounter = "counter"
+)
+
+/*
+Struktur untuk model staf toko hoster.
+Struktur ini merepresentasikan akun staf beserta toko dan status undangannya.
+*/
+type HosterStaffModel struct {
+	ID              string     `json:"id" db:"id"`
+	StoreID         string     `json:"store_id" db:"store_id"`
+	Email           string     `json:"email" db:"email"`
+	FullName        string     `json:"full_name" db:"full_name"`
+	PasswordHash    string     `json:"-" db:"password_hash"`
+	Role            string     `json:"role" db:"role"`
+	InvitedBy       string     `json:"invited_by,omitempty" db:"invited_by"`
+	InviteTokenHash string     `json:"-" db:"invite_token_hash"`
+	InviteExpiresAt *time.Time `json:"invite_expires_at,omitempty" db:"invite_expires_at"`
+	AcceptedAt      *time.Time `json:"accepted_at" db:"accepted_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model sesi impersonasi hoster.
+Struktur ini merepresentasikan token impersonasi yang diterbitkan admin beserta alasan dan status berakhirnya.
+*/
+type ImpersonationModel struct {
+	ID        string     `json:"id" db:"id"`
+	AdminID   string     `json:"admin_id" db:"admin_id"`
+	HosterID  string     `json:"hoster_id" db:"hoster_id"`
+	StoreID   string     `json:"store_id" db:"store_id"`
+	Reason    string     `json:"reason" db:"reason"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty" db:"ended_at"`
+	EndedBy   *string    `json:"ended_by,omitempty" db:"ended_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+/*
+Struktur untuk model jejak audit impersonasi.
+Struktur ini merepresentasikan satu permintaan yang dilakukan admin atas nama hoster.
+*/
+type ImpersonationLogModel struct {
+	ID              string    `json:"id" db:"id"`
+	ImpersonationID string    `json:"impersonation_id" db:"impersonation_id"`
+	AdminID         string    `json:"admin_id" db:"admin_id"`
+	HosterID        string    `json:"hoster_id" db:"hoster_id"`
+	Method          string    `json:"method" db:"method"`
+	Path            string    `json:"path" db:"path"`
+	Status          int       `json:"status" db:"status"`
+	IPAddress       string    `json:"ip_address" db:"ip_address"`
+	UserAgent       string    `json:"user_agent" db:"user_agent"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
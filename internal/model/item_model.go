@@ -22,17 +22,21 @@ Struktur untuk model item.
 Struktur ini merepresentasikan data item dengan field yang diperlukan.
 */
 type ItemModel struct {
-	ID          string       `json:"id" db:"id"`
-	Name        string       `json:"name" db:"name"`
-	Description string       `json:"description" db:"description"`
-	Photos      []string     `json:"photos" db:"photos"`
-	Stock       int          `json:"stock" db:"stock"`
-	PickupType  PickupMethod `json:"pickup_type"`
-	PricePerDay int          `json:"price_per_day" db:"price_per_day"`
-	Deposit     int          `json:"deposit" db:"deposit"`
-	Discount    int          `json:"discount,omitempty" db:"discount"`
-	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+	ID               string       `json:"id" db:"id"`
+	Name             string       `json:"name" db:"name"`
+	Description      string       `json:"description" db:"description"`
+	Photos           []string     `json:"photos" db:"photos"`
+	Stock            int          `json:"stock" db:"stock"`
+	PickupType       PickupMethod `json:"pickup_type"`
+	PricePerDay      int          `json:"price_per_day" db:"price_per_day"`
+	PricePerWeek     int          `json:"price_per_week,omitempty" db:"price_per_week"`
+	PricePerMonth    int          `json:"price_per_month,omitempty" db:"price_per_month"`
+	WeekendSurcharge int          `json:"weekend_surcharge,omitempty" db:"weekend_surcharge"`
+	DeliveryFee      int          `json:"delivery_fee,omitempty" db:"delivery_fee"`
+	Deposit          int          `json:"deposit" db:"deposit"`
+	Discount         int          `json:"discount,omitempty" db:"discount"`
+	CreatedAt        time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at" db:"updated_at"`
 
 	// Foreign key
 	CategoryID string `json:"category_id" db:"category_id"`
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model percobaan login.
+Struktur ini merepresentasikan jumlah kegagalan login per email atau IP beserta status blokirnya.
+*/
+type LoginAttemptModel struct {
+	ID           string     `json:"id" db:"id"`
+	Kind         string     `json:"kind" db:"kind"`
+	Identifier   string     `json:"identifier" db:"identifier"`
+	Role         string     `json:"role" db:"role"`
+	FailedCount  int        `json:"failed_count" db:"failed_count"`
+	LastFailedAt time.Time  `json:"last_failed_at" db:"last_failed_at"`
+	RetryAt      *time.Time `json:"retry_at,omitempty" db:"retry_at"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty" db:"locked_until"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
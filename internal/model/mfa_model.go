@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model TOTP pengguna.
+Struktur ini merepresentasikan secret TOTP terenkripsi dan status aktivasinya.
+*/
+type MFATOTPModel struct {
+	UserID          string     `json:"user_id" db:"user_id"`
+	Role            string     `json:"role" db:"role"`
+	SecretEncrypted string     `json:"-" db:"secret_encrypted"`
+	EnabledAt       *time.Time `json:"enabled_at,omitempty" db:"enabled_at"`
+	LastUsedStep    *int64     `json:"-" db:"last_used_step"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model state login OpenID Connect.
+Struktur ini menyimpan code verifier PKCE dan nonce yang menunggu callback dari penyedia.
+*/
+type OIDCStateModel struct {
+	ID           string    `json:"id" db:"id"`
+	StateHash    string    `json:"-" db:"state_hash"`
+	Role         string    `json:"role" db:"role"`
+	CodeVerifier string    `json:"-" db:"code_verifier"`
+	Nonce        string    `json:"-" db:"nonce"`
+	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+/*
+Struktur untuk model identitas OpenID Connect yang tertaut ke akun.
+Struktur ini menghubungkan pasangan issuer dan subject dengan akun hoster atau customer.
+*/
+type OIDCIdentityModel struct {
+	ID        string    `json:"id" db:"id"`
+	Issuer    string    `json:"issuer" db:"issuer"`
+	Subject   string    `json:"subject" db:"subject"`
+	Role      string    `json:"role" db:"role"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	Email     string    `json:"email" db:"email"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model order.
+Struktur ini merepresentasikan satu checkout customer pada satu toko yang terdiri dari beberapa baris booking.
+*/
+type OrderModel struct {
+	ID         string          `json:"id" db:"id"`
+	CustomerID string          `json:"customer_id" db:"customer_id"`
+	StoreID    string          `json:"store_id" db:"store_id"`
+	StoreName  string          `json:"store_name,omitempty" db:"store_name"`
+	StartDate  time.Time       `json:"start_date" db:"start_date"`
+	EndDate    time.Time       `json:"end_date" db:"end_date"`
+	TotalPrice int             `json:"total_price" db:"total_price"`
+	Deposit    int             `json:"deposit" db:"deposit"`
+	AmountDue  int             `json:"amount_due" db:"-"`
+	Notes      string          `json:"notes,omitempty" db:"notes"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+	Bookings   []*BookingModel `json:"bookings" db:"-"`
+}
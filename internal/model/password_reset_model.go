@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model token reset password.
+Struktur ini merepresentasikan token reset sekali pakai yang tersimpan dalam bentuk hash.
+*/
+type PasswordResetTokenModel struct {
+	ID        string     `json:"id" db:"id"`
+	UserID    string     `json:"user_id" db:"user_id"`
+	Role      string     `json:"role" db:"role"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,56 @@
+package model
+
+/*
+Konstanta untuk kode baris penawaran harga.
+Konstanta ini membedakan setiap komponen biaya sewa pada penawaran.
+*/
+const (
+	QuoteLineMonthly          = "monthly"
+	QuoteLineWeekly           = "weekly"
+	QuoteLineDaily            = "daily"
+	QuoteLineWeekendSurcharge = "weekend_surcharge"
+	QuoteLineDiscount         = "discount"
+	QuoteLineDeliveryFee      = "delivery_fee"
+	QuoteLineDeposit          = "deposit"
+)
+
+/*
+Konstanta untuk aturan batas hari sewa.
+Konstanta ini menentukan apakah tanggal selesai ikut dihitung sebagai hari sewa.
+*/
+const (
+	RentalDayRuleInclusive = "inclusive"
+	RentalDayRuleNights    = "nights"
+)
+
+/*
+Struktur untuk model baris penawaran harga.
+Struktur ini berisi satu komponen biaya beserta jumlah periode, harga satuan, dan totalnya untuk seluruh unit.
+*/
+type QuoteLineModel struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Periods     int    `json:"periods"`
+	UnitPrice   int    `json:"unit_price"`
+	Amount      int    `json:"amount"`
+}
+
+/*
+Struktur untuk model penawaran harga sewa.
+Struktur ini berisi rincian biaya, total sewa, deposit, dan jumlah yang harus dibayar untuk item, jumlah unit, dan rentang tanggal.
+*/
+type QuoteModel struct {
+	ItemID      string            `json:"item_id"`
+	Quantity    int               `json:"quantity"`
+	StartDate   string            `json:"start_date"`
+	EndDate     string            `json:"end_date"`
+	DayRule     string            `json:"day_rule"`
+	Days        int               `json:"days"`
+	Lines       []*QuoteLineModel `json:"lines"`
+	Subtotal    int               `json:"subtotal"`
+	Discount    int               `json:"discount"`
+	DeliveryFee int               `json:"delivery_fee"`
+	Total       int               `json:"total"`
+	Deposit     int               `json:"deposit"`
+	AmountDue   int               `json:"amount_due"`
+}
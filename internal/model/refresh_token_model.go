@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model refresh token.
+Struktur ini merepresentasikan refresh token yang tersimpan beserta status rotasinya.
+*/
+type RefreshTokenModel struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"user_id" db:"user_id"`
+	Role       string     `json:"role" db:"role"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	FamilyID   string     `json:"family_id" db:"family_id"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	RotatedAt  *time.Time `json:"rotated_at,omitempty" db:"rotated_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	ReplacedBy *string    `json:"replaced_by,omitempty" db:"replaced_by"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+/*
+Struktur untuk model role otorisasi.
+Struktur ini merepresentasikan role per jenis akun beserta daftar permission-nya.
+*/
+type RoleModel struct {
+	ID          string         `json:"id" db:"id"`
+	Name        string         `json:"name" db:"name"`
+	UserType    string         `json:"user_type" db:"user_type"`
+	Description string         `json:"description" db:"description"`
+	IsSystem    bool           `json:"is_system" db:"is_system"`
+	Permissions pq.StringArray `json:"permissions" db:"permissions"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
+}
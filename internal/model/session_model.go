@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+/*
+Struktur untuk model sesi login.
+Struktur ini merepresentasikan satu login beserta perangkat, alamat IP, dan waktu aktivitas terakhir.
+*/
+type SessionModel struct {
+	ID         string     `json:"id" db:"id"`
+	UserID     string     `json:"-" db:"user_id"`
+	Role       string     `json:"-" db:"role"`
+	UserAgent  string     `json:"user_agent" db:"user_agent"`
+	IPAddress  string     `json:"ip_address" db:"ip_address"`
+	LastSeenAt time.Time  `json:"last_seen_at" db:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt  *time.Time `json:"-" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	Current    bool       `json:"current" db:"-"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+/*
+Konstanta untuk status hold stok.
+Konstanta ini mendefinisikan hold yang masih menahan stok serta cara hold berakhir.
+*/
+const (
+	StockHoldStatusActive    = "active"
+	StockHoldStatusConverted = "converted"
+	StockHoldStatusReleased  = "released"
+	StockHoldStatusExpired   = "expired"
+)
+
+/*
+Struktur untuk model hold stok.
+Struktur ini merepresentasikan unit item yang ditahan sementara untuk customer antara penawaran harga dan pembayaran.
+*/
+type StockHoldModel struct {
+	ID         string      `json:"id" db:"id"`
+	CustomerID string      `json:"customer_id" db:"customer_id"`
+	ItemID     string      `json:"item_id" db:"item_id"`
+	ItemName   string      `json:"item_name,omitempty" db:"item_name"`
+	StoreID    string      `json:"store_id" db:"store_id"`
+	Quantity   int         `json:"quantity" db:"quantity"`
+	StartDate  time.Time   `json:"start_date" db:"start_date"`
+	EndDate    time.Time   `json:"end_date" db:"end_date"`
+	Status     string      `json:"status" db:"status"`
+	BookingID  *string     `json:"booking_id,omitempty" db:"booking_id"`
+	ExpiresAt  time.Time   `json:"expires_at" db:"expires_at"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+	Quote      *QuoteModel `json:"quote,omitempty" db:"-"`
+}
@@ -1,8 +1,14 @@
 package response
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 /*
@@ -84,3 +90,68 @@ Respons JSON dengan status Forbidden dikirim.
 func Forbidden(w http.ResponseWriter, message string) {
 	Error(w, http.StatusForbidden, message)
 }
+
+/*
+Fungsi untuk mengirim respons Conflict.
+Respons JSON dengan status Conflict dikirim saat permintaan bertentangan dengan keadaan data saat ini.
+*/
+func Conflict(w http.ResponseWriter, msg string) {
+	Error(w, http.StatusConflict, msg)
+}
+
+/*
+Fungsi untuk mengirim respons TooManyRequests.
+Respons JSON dengan status TooManyRequests dan header Retry-After dikirim.
+*/
+func TooManyRequests(w http.ResponseWriter, msg string, retryAfter time.Duration) {
+	setRetryAfter(w, retryAfter)
+	Error(w, http.StatusTooManyRequests, msg)
+}
+
+/*
+Fungsi untuk mengirim respons Locked.
+Respons JSON dengan status Locked dan header Retry-After dikirim.
+*/
+func Locked(w http.ResponseWriter, msg string, retryAfter time.Duration) {
+	setRetryAfter(w, retryAfter)
+	Error(w, http.StatusLocked, msg)
+}
+
+/*
+Fungsi untuk mengatur header Retry-After dalam detik.
+Durasi dibulatkan ke atas agar klien tidak mencoba terlalu cepat.
+*/
+func setRetryAfter(w http.ResponseWriter, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		return
+	}
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+}
+
+/*
+Fungsi untuk mengirim data JSON di dalam arsip ZIP sebagai unduhan.
+Error dikembalikan tanpa menulis respons apa pun jika arsip gagal dibuat.
+*/
+func Archive(w http.ResponseWriter, filename, entry string, data any) error {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	file, err := archive.Create(entry)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return err
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,48 @@
+/*
+Membuat tabel untuk menyimpan permintaan penghapusan akun.
+Menghasilkan struktur tabel jadwal penghapusan dengan masa tenggang untuk hoster dan customer.
+*/
+CREATE TABLE account_deletions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('hoster', 'customer')),
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index unik untuk permintaan yang masih menunggu.
+Memastikan setiap akun hanya memiliki satu jadwal penghapusan aktif.
+*/
+CREATE UNIQUE INDEX idx_account_deletions_pending ON account_deletions(user_id, role)
+WHERE cancelled_at IS NULL AND completed_at IS NULL;
+
+/*
+Membuat index pada kolom scheduled_for.
+Meningkatkan performa pencarian penghapusan yang sudah jatuh tempo.
+*/
+CREATE INDEX idx_account_deletions_scheduled_for ON account_deletions(scheduled_for);
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_account_deletions_updated_at
+BEFORE UPDATE ON account_deletions
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
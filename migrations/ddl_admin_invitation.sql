@@ -0,0 +1,20 @@
+/*
+Membuat tabel untuk menyimpan undangan admin.
+Menghasilkan struktur tabel dengan hash token sekali pakai, pengundang, dan waktu kedaluwarsa.
+*/
+CREATE TABLE admin_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    invited_by UUID NOT NULL REFERENCES admin(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom email.
+Meningkatkan performa pembatalan undangan lama untuk email yang sama.
+*/
+CREATE INDEX idx_admin_invitations_email ON admin_invitations(LOWER(email));
@@ -0,0 +1,91 @@
+/*
+Membuat tabel untuk menyimpan booking sewa item oleh customer.
+Menghasilkan struktur tabel dengan item, toko, jumlah unit, rentang tanggal, harga, dan status booking.
+*/
+CREATE TABLE bookings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES items(id),
+    customer_id UUID NOT NULL,
+    store_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    days INTEGER NOT NULL CHECK (days > 0),
+    price_per_day INTEGER NOT NULL,
+    discount INTEGER NOT NULL DEFAULT 0,
+    delivery_fee INTEGER NOT NULL DEFAULT 0,
+    total_price INTEGER NOT NULL,
+    deposit INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'rejected', 'picked_up', 'returned', 'completed', 'cancelled')),
+    notes TEXT NOT NULL DEFAULT '',
+    cancellation_policy_id UUID,
+    cancellation_rules JSONB,
+    refund_percent INTEGER,
+    refund_amount INTEGER,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    rejected_at TIMESTAMP WITH TIME ZONE,
+    picked_up_at TIMESTAMP WITH TIME ZONE,
+    returned_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (end_date >= start_date)
+);
+
+/*
+Membuat index pada kolom item_id dan rentang tanggal.
+Meningkatkan performa perhitungan stok terpakai per hari untuk sebuah item.
+*/
+CREATE INDEX idx_bookings_item_dates ON bookings(item_id, start_date, end_date);
+
+/*
+Membuat index pada kolom customer_id.
+Meningkatkan performa pengambilan daftar booking milik customer.
+*/
+CREATE INDEX idx_bookings_customer_id ON bookings(customer_id, created_at);
+
+/*
+Membuat index pada kolom store_id.
+Meningkatkan performa pengambilan daftar booking milik toko hoster.
+*/
+CREATE INDEX idx_bookings_store_id ON bookings(store_id, created_at);
+
+/*
+Mengisi permission booking untuk role bawaan.
+Customer membuat dan membatalkan booking miliknya, sedangkan pemilik dan manajer toko memproses booking item tokonya.
+*/
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+JOIN (VALUES
+    ('support', 'booking:read'),
+    ('hoster', 'booking:read'),
+    ('hoster', 'booking:write'),
+    ('hoster_manager', 'booking:read'),
+    ('hoster_manager', 'booking:write'),
+    ('hoster_counter', 'booking:read'),
+    ('customer', 'booking:read'),
+    ('customer', 'booking:write')
+) AS p(role_name, permission) ON p.role_name = r.name;
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_bookings_updated_at
+BEFORE UPDATE ON bookings
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,53 @@
+/*
+Membuat tabel untuk menyimpan kebijakan pembatalan toko hoster.
+Menghasilkan struktur tabel dengan aturan refund terstruktur berupa daftar batas jam sebelum sewa dan persentase refund.
+*/
+CREATE TABLE cancellation_policies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    store_id UUID NOT NULL REFERENCES hosters(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    rules JSONB NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom store_id.
+Meningkatkan performa pengambilan kebijakan pembatalan milik toko.
+*/
+CREATE INDEX idx_cancellation_policies_store_id ON cancellation_policies(store_id);
+
+/*
+Membuat unique index untuk kebijakan bawaan toko.
+Memastikan setiap toko hanya memiliki satu kebijakan pembatalan bawaan.
+*/
+CREATE UNIQUE INDEX idx_cancellation_policies_default ON cancellation_policies(store_id) WHERE is_default;
+
+/*
+Menambahkan kolom kebijakan pembatalan pada item.
+Item tanpa kebijakan sendiri memakai kebijakan bawaan tokonya.
+*/
+ALTER TABLE items ADD COLUMN cancellation_policy_id UUID REFERENCES cancellation_policies(id) ON DELETE SET NULL;
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_cancellation_policies_updated_at
+BEFORE UPDATE ON cancellation_policies
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
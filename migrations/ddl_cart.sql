@@ -0,0 +1,23 @@
+/*
+Membuat tabel untuk menyimpan keranjang customer.
+Menghasilkan struktur tabel satu keranjang per customer dengan rentang tanggal sewa yang berlaku untuk semua item.
+*/
+CREATE TABLE carts (
+    customer_id UUID PRIMARY KEY REFERENCES customers(id) ON DELETE CASCADE,
+    start_date DATE,
+    end_date DATE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (end_date >= start_date)
+);
+
+/*
+Membuat tabel untuk menyimpan item dalam keranjang customer.
+Menghasilkan struktur tabel item dan jumlah unit, satu baris per item.
+*/
+CREATE TABLE cart_items (
+    customer_id UUID NOT NULL REFERENCES carts(customer_id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    added_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (customer_id, item_id)
+);
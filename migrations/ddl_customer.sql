@@ -10,6 +10,8 @@ CREATE TABLE customers (
     email VARCHAR(255) UNIQUE NOT NULL,
     address TEXT,
     password_hash VARCHAR(255) NOT NULL,
+    email_verified_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
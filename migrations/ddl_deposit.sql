@@ -0,0 +1,32 @@
+/*
+Membuat tabel buku besar deposit booking.
+Menghasilkan struktur tabel berisi penahanan, potongan kerusakan, dan pengembalian deposit per booking beserta tanggapan customer dan keputusan admin atas setiap potongan.
+*/
+CREATE TABLE deposit_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    entry_type VARCHAR(20) NOT NULL CHECK (entry_type IN ('hold', 'deduction', 'release')),
+    amount INTEGER NOT NULL CHECK (amount >= 0),
+    reason TEXT NOT NULL DEFAULT '',
+    photos TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'posted' CHECK (status IN ('posted', 'pending', 'acknowledged', 'disputed', 'withdrawn')),
+    customer_note TEXT NOT NULL DEFAULT '',
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    responded_at TIMESTAMP WITH TIME ZONE,
+    resolution_note TEXT NOT NULL DEFAULT '',
+    resolved_by UUID,
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+/*
+Membuat index pada kolom booking_id.
+Meningkatkan performa penyusunan laporan deposit sebuah booking.
+*/
+CREATE INDEX idx_deposit_entries_booking_id ON deposit_entries(booking_id, created_at);
+
+/*
+Membuat unique index untuk penahanan dan pengembalian deposit.
+Memastikan deposit setiap booking hanya ditahan dan dikembalikan satu kali.
+*/
+CREATE UNIQUE INDEX idx_deposit_entries_single ON deposit_entries(booking_id, entry_type) WHERE entry_type IN ('hold', 'release');
@@ -12,6 +12,8 @@ CREATE TABLE hosters (
     email VARCHAR(255) UNIQUE NOT NULL,
     address TEXT NOT NULL,
     password_hash VARCHAR(255) NOT NULL,
+    email_verified_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
     website VARCHAR(500),
     instagram VARCHAR(255),
     tiktok VARCHAR(255),
@@ -0,0 +1,64 @@
+/*
+Membuat tabel untuk menyimpan API key toko hoster.
+Menghasilkan struktur tabel kunci akses terprogram dengan scope, hash, dan waktu penggunaan terakhir.
+*/
+CREATE TABLE hoster_api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    store_id UUID NOT NULL REFERENCES hosters(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) UNIQUE NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom store_id.
+Meningkatkan performa query daftar API key per toko.
+*/
+CREATE INDEX idx_hoster_api_keys_store_id ON hoster_api_keys(store_id);
+
+/*
+Membuat index pada kolom created_by.
+Meningkatkan performa pencabutan API key saat staf pembuatnya dihapus.
+*/
+CREATE INDEX idx_hoster_api_keys_created_by ON hoster_api_keys(created_by);
+
+/*
+Menambahkan permission pengelolaan API key ke pemilik toko dan manajer.
+Permission mengikuti katalog di internal/auth/permission.go.
+*/
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+JOIN (VALUES
+    ('hoster', 'apikey:read'),
+    ('hoster', 'apikey:write'),
+    ('hoster_manager', 'apikey:read'),
+    ('hoster_manager', 'apikey:write')
+) AS p(role_name, permission) ON p.role_name = r.name;
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_hoster_api_keys_updated_at
+BEFORE UPDATE ON hoster_api_keys
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
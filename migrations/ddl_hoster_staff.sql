@@ -0,0 +1,80 @@
+/*
+Membuat tabel untuk menyimpan staf toko hoster.
+Menghasilkan struktur tabel akun staf dengan role toko, undangan, dan kredensial sendiri.
+*/
+CREATE TABLE hoster_staff (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    store_id UUID NOT NULL REFERENCES hosters(id) ON DELETE CASCADE,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    full_name VARCHAR(255),
+    password_hash VARCHAR(255),
+    role VARCHAR(20) NOT NULL CHECK (role IN ('manager', 'counter')),
+    invited_by UUID NOT NULL,
+    invite_token_hash VARCHAR(64),
+    invite_expires_at TIMESTAMP WITH TIME ZONE,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom store_id.
+Meningkatkan performa query daftar staf per toko.
+*/
+CREATE INDEX idx_hoster_staff_store_id ON hoster_staff(store_id);
+
+/*
+Membuat index unik pada hash token undangan.
+Memastikan satu token undangan hanya menunjuk ke satu staf.
+*/
+CREATE UNIQUE INDEX idx_hoster_staff_invite_token_hash ON hoster_staff(invite_token_hash) WHERE invite_token_hash IS NOT NULL;
+
+/*
+Mengisi role bawaan untuk staf toko.
+Pemilik toko tetap memakai role hoster, sedangkan staf diberi role sesuai jabatannya.
+*/
+INSERT INTO roles (name, user_type, description, is_system) VALUES
+    ('hoster_manager', 'hoster', 'Store manager: manages items and terms', TRUE),
+    ('hoster_counter', 'hoster', 'Counter staff: read-only store access', TRUE);
+
+/*
+Mengisi permission role staf dan menambahkan permission staf ke pemilik toko.
+Permission mengikuti katalog di internal/auth/permission.go.
+*/
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+JOIN (VALUES
+    ('hoster', 'staff:read'),
+    ('hoster', 'staff:write'),
+    ('hoster_manager', 'profile:read'),
+    ('hoster_manager', 'item:read'),
+    ('hoster_manager', 'item:write'),
+    ('hoster_manager', 'terms:read'),
+    ('hoster_manager', 'terms:write'),
+    ('hoster_manager', 'staff:read'),
+    ('hoster_counter', 'profile:read'),
+    ('hoster_counter', 'item:read'),
+    ('hoster_counter', 'terms:read')
+) AS p(role_name, permission) ON p.role_name = r.name;
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_hoster_staff_updated_at
+BEFORE UPDATE ON hoster_staff
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
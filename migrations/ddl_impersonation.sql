@@ -0,0 +1,62 @@
+/*
+Membuat tabel untuk menyimpan sesi impersonasi hoster oleh admin.
+Menghasilkan struktur tabel dengan ID token, admin yang bertindak, hoster tujuan, alasan, dan masa berlaku.
+*/
+CREATE TABLE impersonations (
+    id UUID PRIMARY KEY,
+    admin_id UUID NOT NULL,
+    hoster_id UUID NOT NULL,
+    store_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    ended_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom hoster_id.
+Meningkatkan performa pencarian riwayat impersonasi sebuah hoster.
+*/
+CREATE INDEX idx_impersonations_hoster_id ON impersonations(hoster_id);
+
+/*
+Membuat index pada kolom created_at.
+Meningkatkan performa query pengurutan berdasarkan waktu pembuatan.
+*/
+CREATE INDEX idx_impersonations_created_at ON impersonations(created_at);
+
+/*
+Membuat tabel untuk menyimpan jejak audit setiap permintaan selama impersonasi.
+Menghasilkan struktur tabel dengan metode, path, status respons, dan perangkat untuk setiap permintaan.
+*/
+CREATE TABLE impersonation_audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    impersonation_id UUID NOT NULL REFERENCES impersonations(id) ON DELETE CASCADE,
+    admin_id UUID NOT NULL,
+    hoster_id UUID NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    status INTEGER NOT NULL,
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom impersonation_id.
+Meningkatkan performa pengambilan jejak audit per sesi impersonasi.
+*/
+CREATE INDEX idx_impersonation_audit_logs_impersonation_id ON impersonation_audit_logs(impersonation_id, created_at);
+
+/*
+Mengisi permission impersonasi untuk role support.
+Tim support dapat memulai impersonasi hoster dan membaca jejak auditnya.
+*/
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+JOIN (VALUES
+    ('support', 'hoster:impersonate'),
+    ('support', 'audit:read')
+) AS p(role_name, permission) ON p.role_name = r.name;
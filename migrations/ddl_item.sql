@@ -11,7 +11,11 @@ CREATE TABLE items (
     pickup_type VARCHAR(50) NOT NULL CHECK (pickup_type IN ('pickup', 'delivery')),
     price_per_day INTEGER NOT NULL,
     deposit INTEGER NOT NULL DEFAULT 0,
-    discount INTEGER DEFAULT 0,
+    discount INTEGER DEFAULT 0 CHECK (discount BETWEEN 0 AND 100),
+    price_per_week INTEGER NOT NULL DEFAULT 0,
+    price_per_month INTEGER NOT NULL DEFAULT 0,
+    weekend_surcharge INTEGER NOT NULL DEFAULT 0 CHECK (weekend_surcharge BETWEEN 0 AND 100),
+    delivery_fee INTEGER NOT NULL DEFAULT 0,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     category_id UUID NOT NULL,
@@ -0,0 +1,22 @@
+/*
+Membuat tabel untuk menyimpan periode blackout item.
+Menghasilkan struktur tabel rentang tanggal saat sebagian atau seluruh unit item tidak dapat disewa, misalnya untuk perawatan.
+*/
+CREATE TABLE item_blackouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    store_id UUID NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    quantity INTEGER CHECK (quantity > 0),
+    reason TEXT NOT NULL DEFAULT '',
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (end_date >= start_date)
+);
+
+/*
+Membuat index pada kolom item_id dan rentang tanggal.
+Meningkatkan performa perhitungan ketersediaan harian sebuah item.
+*/
+CREATE INDEX idx_item_blackouts_item_dates ON item_blackouts(item_id, start_date, end_date);
@@ -0,0 +1,45 @@
+/*
+Membuat tabel untuk mencatat percobaan login yang gagal.
+Menghasilkan struktur tabel per email atau IP dengan jumlah kegagalan, backoff, dan lockout.
+*/
+CREATE TABLE login_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('email', 'ip')),
+    identifier VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'hoster', 'customer')),
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    retry_at TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (kind, identifier, role)
+);
+
+/*
+Membuat index pada kolom locked_until dan retry_at.
+Meningkatkan performa query daftar lockout yang masih aktif.
+*/
+CREATE INDEX idx_login_attempts_locked_until ON login_attempts(locked_until);
+CREATE INDEX idx_login_attempts_retry_at ON login_attempts(retry_at);
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_login_attempts_updated_at
+BEFORE UPDATE ON login_attempts
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
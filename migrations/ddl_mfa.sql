@@ -0,0 +1,49 @@
+/*
+Membuat tabel untuk menyimpan secret TOTP pengguna.
+Menghasilkan struktur tabel dengan secret terenkripsi, status aktif, dan langkah terakhir yang dipakai.
+*/
+CREATE TABLE mfa_totp (
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'hoster', 'customer')),
+    secret_encrypted TEXT NOT NULL,
+    enabled_at TIMESTAMP WITH TIME ZONE,
+    last_used_step BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, role)
+);
+
+/*
+Membuat tabel untuk menyimpan kode pemulihan MFA.
+Menghasilkan struktur tabel dengan hash kode sekali pakai.
+*/
+CREATE TABLE mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'hoster', 'customer')),
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (user_id, role, code_hash)
+);
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_mfa_totp_updated_at
+BEFORE UPDATE ON mfa_totp
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,62 @@
+/*
+Membuat tabel untuk menyimpan state login OpenID Connect.
+Menghasilkan struktur tabel state sekali pakai dengan code verifier PKCE dan nonce.
+*/
+CREATE TABLE oidc_login_states (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    state_hash VARCHAR(64) UNIQUE NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('hoster', 'customer')),
+    code_verifier VARCHAR(128) NOT NULL,
+    nonce VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom expires_at.
+Meningkatkan performa pembersihan state yang kedaluwarsa.
+*/
+CREATE INDEX idx_oidc_login_states_expires_at ON oidc_login_states(expires_at);
+
+/*
+Membuat tabel untuk menyimpan tautan akun dengan identitas OpenID Connect.
+Menghasilkan struktur tabel pasangan issuer dan subject untuk setiap akun hoster atau customer.
+*/
+CREATE TABLE oidc_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    issuer VARCHAR(500) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('hoster', 'customer')),
+    user_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (issuer, subject, role)
+);
+
+/*
+Membuat index pada kolom user_id dan role.
+Meningkatkan performa pencarian identitas milik akun.
+*/
+CREATE INDEX idx_oidc_identities_user ON oidc_identities(user_id, role);
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_oidc_identities_updated_at
+BEFORE UPDATE ON oidc_identities
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,34 @@
+/*
+Membuat tabel untuk menyimpan order customer.
+Menghasilkan struktur tabel yang mengelompokkan beberapa booking dari satu toko yang dibayar dalam satu checkout.
+*/
+CREATE TABLE orders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    store_id UUID NOT NULL REFERENCES hosters(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    total_price INTEGER NOT NULL DEFAULT 0,
+    deposit INTEGER NOT NULL DEFAULT 0,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (end_date >= start_date)
+);
+
+/*
+Membuat index pada kolom customer_id.
+Meningkatkan performa pengambilan riwayat order customer.
+*/
+CREATE INDEX idx_orders_customer_id ON orders(customer_id, created_at);
+
+/*
+Menambahkan kolom order pada booking.
+Booking hasil checkout keranjang menunjuk ke order-nya, booking tunggal tidak memiliki order.
+*/
+ALTER TABLE bookings ADD COLUMN order_id UUID REFERENCES orders(id) ON DELETE SET NULL;
+
+/*
+Membuat index pada kolom order_id.
+Meningkatkan performa pengambilan baris booking sebuah order.
+*/
+CREATE INDEX idx_bookings_order_id ON bookings(order_id);
@@ -0,0 +1,19 @@
+/*
+Membuat tabel untuk menyimpan token reset password.
+Menghasilkan struktur tabel dengan hash token sekali pakai dan waktu kedaluwarsa.
+*/
+CREATE TABLE password_reset_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'hoster', 'customer')),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom user_id dan role.
+Meningkatkan performa invalidasi token lama milik pengguna.
+*/
+CREATE INDEX idx_password_reset_tokens_user ON password_reset_tokens(user_id, role);
@@ -0,0 +1,96 @@
+/*
+Membuat tabel untuk menyimpan role otorisasi.
+Menghasilkan struktur tabel role per jenis akun dengan penanda role bawaan sistem.
+*/
+CREATE TABLE roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) UNIQUE NOT NULL,
+    user_type VARCHAR(20) NOT NULL CHECK (user_type IN ('admin', 'hoster', 'customer')),
+    description TEXT,
+    is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat tabel untuk menyimpan permission setiap role.
+Menghasilkan struktur tabel pasangan role dan permission seperti category:write.
+*/
+CREATE TABLE role_permissions (
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
+
+/*
+Membuat tabel untuk menyimpan role yang diberikan ke pengguna.
+Pengguna tanpa baris di tabel ini memakai role bawaan sesuai jenis akunnya.
+*/
+CREATE TABLE user_roles (
+    user_id UUID NOT NULL,
+    user_type VARCHAR(20) NOT NULL CHECK (user_type IN ('admin', 'hoster', 'customer')),
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, user_type, role_id)
+);
+
+/*
+Membuat index pada kolom role_id.
+Meningkatkan performa pencarian pengguna berdasarkan role.
+*/
+CREATE INDEX idx_user_roles_role_id ON user_roles(role_id);
+
+/*
+Mengisi role bawaan sistem.
+Role dengan nama sama dengan jenis akun menjadi role bawaan pengguna tanpa role eksplisit.
+*/
+INSERT INTO roles (name, user_type, description, is_system) VALUES
+    ('admin', 'admin', 'Full administrator access', TRUE),
+    ('support', 'admin', 'Read-only support access', TRUE),
+    ('category_moderator', 'admin', 'Manage categories only', TRUE),
+    ('hoster', 'hoster', 'Default hoster access', TRUE),
+    ('customer', 'customer', 'Default customer access', TRUE);
+
+/*
+Mengisi permission role bawaan sistem.
+Permission mengikuti katalog di internal/auth/permission.go.
+*/
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM roles r
+JOIN (VALUES
+    ('admin', '*'),
+    ('support', 'admin:read'),
+    ('support', 'security:read'),
+    ('support', 'role:read'),
+    ('support', 'deposit:read'),
+    ('category_moderator', 'category:write'),
+    ('hoster', 'profile:read'),
+    ('hoster', 'item:read'),
+    ('hoster', 'item:write'),
+    ('hoster', 'terms:read'),
+    ('hoster', 'terms:write'),
+    ('customer', 'profile:read'),
+    ('customer', 'profile:write')
+) AS p(role_name, permission) ON p.role_name = r.name;
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_roles_updated_at
+BEFORE UPDATE ON roles
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
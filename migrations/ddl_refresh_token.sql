@@ -0,0 +1,56 @@
+/*
+Membuat tabel untuk menyimpan refresh token yang diterbitkan.
+Menghasilkan struktur tabel dengan hash token, keluarga rotasi, dan status pencabutan.
+*/
+CREATE TABLE refresh_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'hoster', 'customer')),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    family_id UUID NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    rotated_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    replaced_by UUID REFERENCES refresh_tokens(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom family_id.
+Meningkatkan performa pencabutan seluruh keluarga token.
+*/
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+/*
+Membuat index pada kolom user_id dan role.
+Meningkatkan performa query token berdasarkan pengguna.
+*/
+CREATE INDEX idx_refresh_tokens_user ON refresh_tokens(user_id, role);
+
+/*
+Membuat index pada kolom expires_at.
+Meningkatkan performa pembersihan token kedaluwarsa.
+*/
+CREATE INDEX idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_refresh_tokens_updated_at
+BEFORE UPDATE ON refresh_tokens
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
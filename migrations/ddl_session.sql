@@ -0,0 +1,49 @@
+/*
+Membuat tabel untuk menyimpan sesi login pengguna.
+Menghasilkan struktur tabel sesi per login yang terhubung ke keluarga refresh token melalui ID yang sama.
+*/
+CREATE TABLE sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'hoster', 'customer')),
+    user_agent TEXT,
+    ip_address VARCHAR(64),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom user_id dan role.
+Meningkatkan performa query daftar sesi milik pengguna.
+*/
+CREATE INDEX idx_sessions_user ON sessions(user_id, role);
+
+/*
+Membuat index pada kolom expires_at.
+Meningkatkan performa pembersihan sesi kedaluwarsa.
+*/
+CREATE INDEX idx_sessions_expires_at ON sessions(expires_at);
+
+/*
+Membuat fungsi untuk update otomatis kolom updated_at.
+Mengembalikan baris yang diperbarui dengan timestamp baru.
+*/
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+/*
+Membuat trigger untuk memanggil fungsi update sebelum perubahan.
+Memastikan kolom updated_at selalu diperbarui saat update.
+*/
+CREATE TRIGGER update_sessions_updated_at
+BEFORE UPDATE ON sessions
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,31 @@
+/*
+Membuat tabel untuk menyimpan hold stok sementara saat checkout.
+Menghasilkan struktur tabel unit item yang ditahan customer untuk rentang tanggal tertentu sampai dibayar menjadi booking atau kedaluwarsa.
+*/
+CREATE TABLE stock_holds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    store_id UUID NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'converted', 'released', 'expired')),
+    booking_id UUID REFERENCES bookings(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (end_date >= start_date)
+);
+
+/*
+Membuat index parsial untuk hold yang masih aktif.
+Meningkatkan performa perhitungan ketersediaan harian dan pembersihan hold kedaluwarsa.
+*/
+CREATE INDEX idx_stock_holds_active ON stock_holds(item_id, start_date, end_date) WHERE status = 'active';
+CREATE INDEX idx_stock_holds_expires_at ON stock_holds(expires_at) WHERE status = 'active';
+
+/*
+Membuat index pada kolom customer_id.
+Meningkatkan performa pengambilan hold milik customer.
+*/
+CREATE INDEX idx_stock_holds_customer_id ON stock_holds(customer_id, created_at);
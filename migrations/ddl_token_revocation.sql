@@ -0,0 +1,29 @@
+/*
+Membuat tabel untuk menyimpan access token yang dicabut.
+Menghasilkan struktur tabel dengan jti token, pemilik, dan waktu kedaluwarsa.
+*/
+CREATE TABLE revoked_tokens (
+    jti UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+/*
+Membuat index pada kolom expires_at.
+Meningkatkan performa pembersihan token yang sudah kedaluwarsa.
+*/
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+
+/*
+Membuat tabel untuk menyimpan batas pencabutan token per pengguna.
+Menghasilkan struktur tabel dimana token yang terbit sebelum revoked_before dianggap tidak berlaku.
+*/
+CREATE TABLE user_token_revocations (
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    revoked_before TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, role)
+);
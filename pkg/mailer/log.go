@@ -0,0 +1,71 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+/*
+Struktur untuk mailer log.
+Struktur ini menulis email ke log aplikasi untuk development.
+*/
+type logMailer struct{}
+
+/*
+Struktur untuk mailer file.
+Struktur ini menambahkan email ke sebuah file agar dapat dibaca saat development dan pengujian.
+*/
+type fileMailer struct {
+	path string
+	mu   sync.Mutex
+}
+
+/*
+Metode untuk menulis email ke log.
+Email dicetak ke log tanpa dikirim.
+*/
+func (m *logMailer) Send(msg Message) error {
+	log.Printf("Mailer: to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+/*
+Metode untuk menulis email ke file.
+Email ditambahkan ke akhir file tujuan.
+*/
+func (m *fileMailer) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n----\n",
+		time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	return err
+}
+
+/*
+Fungsi untuk membuat instance baru dari mailer log.
+Instance mailer dikembalikan.
+*/
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+/*
+Fungsi untuk membuat instance baru dari mailer file.
+Instance mailer dikembalikan dengan path bawaan jika kosong.
+*/
+func NewFileMailer(path string) Mailer {
+	if path == "" {
+		path = "tmp/mail.log"
+	}
+	return &fileMailer{path: path}
+}
@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Struktur untuk pesan email.
+Struktur ini berisi penerima, subjek, dan isi email teks.
+*/
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+/*
+Struktur untuk konfigurasi mailer.
+Struktur ini menentukan driver dan parameter koneksi pengiriman email.
+*/
+type Config struct {
+	Driver   string
+	From     string
+	Host     string
+	Port     string
+	Username string
+	Password string
+	FilePath string
+}
+
+/*
+Antarmuka untuk pengirim email.
+Antarmuka ini diimplementasikan oleh driver SMTP, log, dan file.
+*/
+type Mailer interface {
+	Send(msg Message) error
+}
+
+/*
+Fungsi untuk membuat mailer sesuai driver yang dikonfigurasi.
+Instance mailer dikembalikan atau error jika driver tidak dikenal.
+*/
+func New(cfg Config) (Mailer, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case "", "log":
+		return NewLogMailer(), nil
+	case "file":
+		return NewFileMailer(cfg.FilePath), nil
+	case "smtp":
+		return NewSMTPMailer(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,45 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+/*
+Struktur untuk mailer SMTP.
+Struktur ini mengirim email melalui server SMTP dengan autentikasi PLAIN.
+*/
+type smtpMailer struct {
+	cfg Config
+}
+
+/*
+Metode untuk mengirim email melalui SMTP.
+Email dikirim atau error koneksi dikembalikan.
+*/
+func (m *smtpMailer) Send(msg Message) error {
+	addr := m.cfg.Host + ":" + m.cfg.Port
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	return smtp.SendMail(addr, auth, m.cfg.From, []string{msg.To}, []byte(b.String()))
+}
+
+/*
+Fungsi untuk membuat instance baru dari mailer SMTP.
+Instance mailer dikembalikan.
+*/
+func NewSMTPMailer(cfg Config) Mailer {
+	return &smtpMailer{cfg: cfg}
+}
@@ -26,6 +26,41 @@ const (
 	MsgHosterNotFound           = "Hoster not found."
 	MsgHosterFetched            = "Hoster data retrieved successfully."
 
+	// Pesan staf toko hoster
+	MsgStaffInvited           = "Staff invitation sent successfully."
+	MsgStaffInvitationInvalid = "Staff invitation is invalid or has expired."
+	MsgStaffInvitationAccept  = "Staff account activated successfully. Please log in."
+	MsgStaffEmailExists       = "An account with this email already exists."
+	MsgStaffRoleInvalid       = "Staff role must be manager or counter."
+	MsgStaffIDRequired        = "Staff ID is required."
+	MsgStaffNotFound          = "Staff member not found."
+	MsgStaffUpdated           = "Staff role updated successfully."
+	MsgStaffRemoved           = "Staff member removed successfully."
+	MsgStaffOwnerOnly         = "Only the store owner can manage staff."
+	MsgStoreAccessDenied      = "This resource belongs to another store."
+
+	// Pesan API key hoster
+	MsgAPIKeyInvalid        = "Invalid or revoked API key."
+	MsgAPIKeyNameRequired   = "API key name is required."
+	MsgAPIKeyScopesRequired = "At least one API key scope is required."
+	MsgAPIKeyScopeInvalid   = "API key scope is not allowed."
+	MsgAPIKeyIDRequired     = "API key ID is required."
+	MsgAPIKeyNotFound       = "API key not found."
+	MsgAPIKeyCreated        = "API key created successfully. Store it now, it will not be shown again."
+	MsgAPIKeyRevoked        = "API key revoked successfully."
+
+	// Pesan admin dan undangan
+	MsgAdminEmailExists          = "An admin with this email already exists."
+	MsgAdminFullNameRequired     = "Full name is required."
+	MsgAdminAlreadyBootstrapped  = "An admin already exists, use an invitation to add more admins."
+	MsgAdminInvitationSent       = "Admin invitation sent successfully."
+	MsgAdminInvitationInvalid    = "Invalid or expired invitation."
+	MsgAdminInvitationAccepted   = "Invitation accepted, admin account created."
+	MsgAdminInvitationMissing    = "Invitation token is required."
+	MsgAdminInvitationRevoked    = "Invitation revoked successfully."
+	MsgAdminInvitationNotFound   = "Invitation not found or already used."
+	MsgAdminInvitationIDRequired = "Invitation ID is required."
+
 	// Pesan autentikasi customer
 	MsgCustomerCreatedSuccess     = "Customer created successfully."
 	MsgCustomerCreatedFailed      = "Failed to create customer."
@@ -37,6 +72,124 @@ const (
 	MsgCustomerWeakPassword       = "The provided password is too weak."
 	MsgCustomerNotFound           = "Customer not found."
 	MsgCustomerFetched            = "Customer data retrieved successfully."
+	MsgCustomerFullNameRequired   = "Full name is required."
+	MsgCustomerPhoneTooLong       = "Phone number must not exceed 20 characters."
+	MsgCustomerProfileUpdated     = "Customer profile updated successfully."
+	MsgCustomerPhotoRequired      = "Profile photo is required."
+	MsgCustomerPhotoInvalid       = "Profile photo must be a valid http or https URL."
+	MsgCustomerPhotoUpdated       = "Profile photo updated successfully."
+	MsgCustomerPhotoRemoved       = "Profile photo removed successfully."
+
+	// Pesan token autentikasi
+	MsgRefreshTokenRequired = "Refresh token is required."
+	MsgRefreshTokenInvalid  = "Invalid refresh token."
+	MsgRefreshTokenExpired  = "Refresh token has expired."
+	MsgRefreshTokenReused   = "Refresh token reuse detected, all sessions in this chain have been revoked."
+	MsgTokenRefreshed       = "Token refreshed successfully."
+	MsgLogoutSuccess        = "Logged out successfully."
+	MsgLogoutAllSuccess     = "Logged out from all devices successfully."
+	MsgUserTokensRevoked    = "All tokens for the user have been revoked."
+	MsgRoleInvalid          = "Role must be one of admin, hoster or customer."
+
+	// Pesan sesi login
+	MsgSessionIDRequired = "Session ID is required."
+	MsgSessionNotFound   = "Session not found."
+	MsgSessionTerminated = "Session terminated successfully."
+	MsgSessionRevoked    = "Session has been terminated"
+
+	// Pesan login OpenID Connect
+	MsgOIDCDisabled          = "Single sign-on is not configured."
+	MsgOIDCStateInvalid      = "Sign-in request is invalid or has expired."
+	MsgOIDCCodeRequired      = "State and authorization code are required."
+	MsgOIDCExchangeFailed    = "Could not complete sign-in with the identity provider."
+	MsgOIDCEmailUnverified   = "The identity provider did not return a verified email."
+	MsgOIDCAccountUnverified = "An account with this email exists but its email is not verified. Sign in with your password and verify your email first."
+	MsgOIDCLoginSuccess      = "Signed in successfully."
+
+	// Pesan role dan permission
+	MsgPermissionDenied       = "You do not have permission to perform this action."
+	MsgRoleNameRequired       = "Role name is required."
+	MsgRoleNameExists         = "A role with this name already exists."
+	MsgRoleNotFound           = "Role not found."
+	MsgRoleIDRequired         = "Role ID is required."
+	MsgRoleSystemImmutable    = "System roles cannot be deleted."
+	MsgRolePermissionInvalid  = "One or more permissions are not recognised."
+	MsgRoleUserTypeMismatch   = "Role does not apply to this user type."
+	MsgRoleCreated            = "Role created successfully."
+	MsgRoleUpdated            = "Role updated successfully."
+	MsgRoleDeleted            = "Role deleted successfully."
+	MsgRoleAssigned           = "Role assigned successfully."
+	MsgRoleUnassigned         = "Role removed from user successfully."
+	MsgRoleAssignmentNotFound = "The user does not have this role."
+
+	// Pesan MFA
+	MsgMFARequired          = "Two-factor verification required."
+	MsgMFACodeRequired      = "Verification code is required."
+	MsgMFACodeInvalid       = "Invalid or already used verification code."
+	MsgMFAAlreadyEnabled    = "Two-factor authentication is already enabled."
+	MsgMFANotEnabled        = "Two-factor authentication is not enabled."
+	MsgMFANotPending        = "No pending two-factor enrollment, start enrollment first."
+	MsgMFAChallengeRequired = "Challenge token is required."
+	MsgMFAChallengeInvalid  = "Invalid or expired challenge token."
+	MsgMFAEnrollmentStarted = "Scan the QR code with your authenticator app and confirm with a code."
+	MsgMFAEnabled           = "Two-factor authentication enabled, store your recovery codes safely."
+	MsgMFADisabled          = "Two-factor authentication disabled."
+	MsgMFARecoveryCodes     = "New recovery codes generated, previous codes are no longer valid."
+	MsgMFAEnrollmentNeeded  = "Two-factor authentication must be enabled before using this endpoint."
+	MsgMFARequiredByPolicy  = "Two-factor authentication is mandatory for admins and cannot be disabled."
+
+	// Pesan perlindungan login
+	MsgTooManyLoginAttempts = "Too many failed login attempts, please try again later."
+	MsgAccountLocked        = "Account temporarily locked due to too many failed login attempts."
+	MsgLoginBlockNotFound   = "Login lockout not found."
+	MsgLoginBlockIDRequired = "Lockout ID is required."
+	MsgLoginBlockCleared    = "Login lockout cleared successfully."
+
+	// Pesan reset password
+	MsgPasswordRequired          = "Password is required."
+	MsgEmailRequired             = "Email is required."
+	MsgPasswordResetRequested    = "If the email is registered, a password reset link has been sent."
+	MsgPasswordResetTokenMissing = "Reset token is required."
+	MsgPasswordResetTokenInvalid = "Reset token is invalid or has expired."
+	MsgPasswordResetSuccess      = "Password has been reset successfully. Please log in again."
+
+	// Pesan kebijakan dan penggantian password
+	MsgPasswordTooShort        = "Password must be at least %d characters."
+	MsgPasswordTooLong         = "Password must be at most %d bytes."
+	MsgPasswordTooWeak         = "Password is too easy to guess, use a longer mix of words, numbers or symbols."
+	MsgPasswordPersonal        = "Password must not contain your email or name."
+	MsgPasswordBreached        = "This password has appeared in a data breach, please choose a different one."
+	MsgPasswordCurrentRequired = "Current password is required."
+	MsgPasswordCurrentInvalid  = "Current password is incorrect."
+	MsgPasswordUnchanged       = "New password must be different from the current password."
+	MsgPasswordChanged         = "Password changed successfully. Please log in again."
+
+	// Pesan impersonasi hoster
+	MsgImpersonationStarted        = "Impersonation started."
+	MsgImpersonationEnded          = "Impersonation ended."
+	MsgImpersonationNotFound       = "Impersonation not found or already ended."
+	MsgImpersonationIDRequired     = "Impersonation ID is required."
+	MsgImpersonationHosterRequired = "Hoster ID is required."
+	MsgImpersonationReasonRequired = "A reason is required to impersonate a hoster."
+	MsgImpersonationForbidden      = "This action is not allowed while impersonating a hoster."
+
+	// Pesan ekspor data dan penghapusan akun
+	MsgAccountExported               = "Account data exported successfully."
+	MsgAccountExportFormatInvalid    = "Export format must be json or zip."
+	MsgAccountDeletionScheduled      = "Account deletion scheduled. You can cancel it before the scheduled date."
+	MsgAccountDeletionPending        = "Account deletion is already scheduled."
+	MsgAccountDeletionNotFound       = "No pending account deletion."
+	MsgAccountDeletionCancelled      = "Account deletion cancelled."
+	MsgAccountDeletionOwnerOnly      = "Only the store owner can delete the store account, staff are removed by the owner."
+	MsgAccountDeletionActiveBookings = "Account cannot be deleted while bookings are active. Cancel or complete them first."
+	MsgAccountDeletionActiveRentals  = "Store cannot be deleted while rented items are still out or awaiting deposit settlement."
+
+	// Pesan verifikasi email
+	MsgEmailVerificationMissing = "Verification token is required."
+	MsgEmailVerificationInvalid = "Verification link is invalid or has expired."
+	MsgEmailVerified            = "Email verified successfully."
+	MsgEmailVerificationSent    = "If the account exists and is not yet verified, a verification email has been sent."
+	MsgEmailNotVerified         = "Please verify your email address before creating items."
 
 	// Pesan kategori
 	MsgCategoryCreatedSuccess = "Category created successfully."
@@ -49,17 +202,105 @@ const (
 	MsgCategoryIDRequired     = "Category ID is required."
 
 	// Pesan item
-	MsgItemCreatedSuccess     = "Item created successfully."
-	MsgItemUpdatedSuccess     = "Item updated successfully."
-	MsgItemDeletedSuccess     = "Item deleted successfully."
-	MsgItemNameExists         = "Item name already exists."
-	MsgItemNotFound           = "Item not found."
-	MsgItemNameRequired       = "Item name is required."
-	MsgItemNameTooLong        = "Item name must not exceed 255 characters."
-	MsgItemIDRequired         = "Item ID is required."
-	MsgItemStockInvalid       = "Item stock cannot be negative."
-	MsgItemPricePerDayInvalid = "Item price per day cannot be negative."
-	MsgItemDepositInvalid     = "Item deposit cannot be negative."
+	MsgItemCreatedSuccess          = "Item created successfully."
+	MsgItemUpdatedSuccess          = "Item updated successfully."
+	MsgItemDeletedSuccess          = "Item deleted successfully."
+	MsgItemNameExists              = "Item name already exists."
+	MsgItemNotFound                = "Item not found."
+	MsgItemNameRequired            = "Item name is required."
+	MsgItemNameTooLong             = "Item name must not exceed 255 characters."
+	MsgItemIDRequired              = "Item ID is required."
+	MsgItemStockInvalid            = "Item stock cannot be negative."
+	MsgItemPricePerDayInvalid      = "Item price per day cannot be negative."
+	MsgItemDepositInvalid          = "Item deposit cannot be negative."
+	MsgItemDiscountInvalid         = "Item discount must be between 0 and 100 percent."
+	MsgItemTierPriceInvalid        = "Item weekly and monthly prices cannot be negative."
+	MsgItemWeekendSurchargeInvalid = "Item weekend surcharge must be between 0 and 100 percent."
+	MsgItemDeliveryFeeInvalid      = "Item delivery fee cannot be negative."
+
+	// Pesan booking
+	MsgBookingCreated             = "Booking created successfully."
+	MsgBookingConfirmed           = "Booking confirmed."
+	MsgBookingRejected            = "Booking rejected."
+	MsgBookingCancelled           = "Booking cancelled."
+	MsgBookingPickedUp            = "Booking marked as picked up."
+	MsgBookingReturned            = "Booking marked as returned."
+	MsgBookingCompleted           = "Booking completed."
+	MsgBookingNotFound            = "Booking not found."
+	MsgBookingIDRequired          = "Booking ID is required."
+	MsgBookingQuantityInvalid     = "Booking quantity must be at least 1."
+	MsgBookingDateInvalid         = "Booking dates must use the YYYY-MM-DD format."
+	MsgBookingDateRangeInvalid    = "Booking end date cannot be before the start date."
+	MsgBookingDateInPast          = "Booking cannot start in the past."
+	MsgBookingQuantityAboveStock  = "Booking quantity cannot exceed the item's stock."
+	MsgBookingPeriodTooLong       = "Rental period cannot span more than 366 days."
+	MsgBookingStockUnavailable    = "Not enough stock available for the selected dates."
+	MsgBookingStatusFilterInvalid = "Booking status filter is invalid."
+
+	// Pesan keranjang dan order
+	MsgCartItemAdded     = "Item added to cart."
+	MsgCartItemUpdated   = "Cart item updated."
+	MsgCartItemRemoved   = "Item removed from cart."
+	MsgCartDatesUpdated  = "Cart rental dates updated."
+	MsgCartCleared       = "Cart cleared."
+	MsgCartEmpty         = "Cart is empty."
+	MsgCartItemNotFound  = "Item is not in the cart."
+	MsgCartDatesRequired = "Cart rental dates must be set before checkout."
+	MsgCartCheckedOut    = "Checkout completed, one order was created per store."
+	MsgOrderNotFound     = "Order not found."
+	MsgOrderIDRequired   = "Order ID is required."
+
+	// Pesan hold stok
+	MsgStockHoldCreated    = "Stock held until the hold expires."
+	MsgStockHoldReleased   = "Stock hold released."
+	MsgStockHoldNotFound   = "Stock hold not found."
+	MsgStockHoldIDRequired = "Stock hold ID is required."
+	MsgStockHoldExpired    = "Stock hold has expired or is no longer active."
+	MsgStockHoldLimit      = "Too many active stock holds. Book or release a hold before holding more."
+	MsgStockHoldItemLimit  = "Too many units of this item are already held by you."
+	MsgStockHoldMismatch   = "Stock hold does not match a cart item with the same quantity and dates."
+	MsgCartHeld            = "Cart items held until the holds expire."
+
+	// Pesan ketersediaan dan blackout item
+	MsgAvailabilityRangeInvalid = "Availability range must end on or after its start and span at most 366 days."
+	MsgBlackoutCreated          = "Blackout period created successfully."
+	MsgBlackoutDeleted          = "Blackout period deleted successfully."
+	MsgBlackoutNotFound         = "Blackout period not found."
+	MsgBlackoutIDRequired       = "Blackout ID is required."
+	MsgBlackoutQuantityInvalid  = "Blackout quantity must be at least 1 and not exceed the item stock."
+	MsgBlackoutConflict         = "Existing bookings leave too few units to block for the selected dates."
+
+	// Pesan deposit booking
+	MsgDepositReleased                = "Deposit released."
+	MsgDepositNone                    = "This booking has no deposit."
+	MsgDepositAlreadyReleased         = "Deposit has already been released."
+	MsgDepositNotReturned             = "Deposit can only be settled after the item is returned or the booking is cancelled."
+	MsgDepositDeductionCreated        = "Deposit deduction recorded."
+	MsgDepositDeductionWithdrawn      = "Deposit deduction withdrawn."
+	MsgDepositDeductionAcknowledged   = "Deposit deduction acknowledged."
+	MsgDepositDeductionDisputed       = "Deposit deduction disputed."
+	MsgDepositDeductionNotFound       = "Deposit deduction not found or no longer open."
+	MsgDepositDeductionIDRequired     = "Deposit deduction ID is required."
+	MsgDepositDeductionAmountInvalid  = "Deduction amount must be positive and not exceed the remaining deposit."
+	MsgDepositDeductionReasonRequired = "Deduction reason is required."
+	MsgDepositDeductionPhotosInvalid  = "Deduction photos must be at most 10 http or https URLs."
+	MsgDepositDisputeNoteRequired     = "A note explaining the dispute is required."
+	MsgDepositDeductionsUnresolved    = "Deposit cannot be released while deductions are pending or disputed."
+	MsgDepositDisputeResolved         = "Deposit dispute resolved."
+	MsgDepositResolutionInvalid       = "Resolution outcome must be uphold or withdraw."
+	MsgDepositResolutionNoteRequired  = "A note explaining the resolution is required."
+
+	// Pesan kebijakan pembatalan
+	MsgCancellationPolicyCreated       = "Cancellation policy created successfully."
+	MsgCancellationPolicyUpdated       = "Cancellation policy updated successfully."
+	MsgCancellationPolicyDeleted       = "Cancellation policy deleted successfully."
+	MsgCancellationPolicyAssigned      = "Item cancellation policy updated successfully."
+	MsgCancellationPolicyNotFound      = "Cancellation policy not found."
+	MsgCancellationPolicyNone          = "No cancellation policy applies to this item."
+	MsgCancellationPolicyIDRequired    = "Cancellation policy ID is required."
+	MsgCancellationPolicyNameRequired  = "Cancellation policy name is required."
+	MsgCancellationPolicyPresetInvalid = "Cancellation policy preset must be flexible, moderate, or strict."
+	MsgCancellationPolicyRulesInvalid  = "Cancellation policy needs 1 to 10 rules with unique non-negative hours and refund percentages between 0 and 100."
 
 	// Pesan terms and conditions
 	MsgTermAndConditionsCreatedSuccess      = "Terms and conditions created successfully."